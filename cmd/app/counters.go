@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go-gin-realworld-api/internal/bootstrap"
+	"go-gin-realworld-api/internal/config"
+)
+
+const reconcileCountersUsage = `usage: app reconcile-counters
+
+recomputes every article's favorites_count from the favorites table and lists the articles it fixed`
+
+// runReconcileCounters handles the `reconcile-counters` subcommand
+func runReconcileCounters(args []string) {
+	if len(args) != 0 {
+		log.Fatal(reconcileCountersUsage)
+	}
+
+	if config.LoadConfig().Database.Driver == config.DriverMemory {
+		log.Fatal("the memory driver keeps no data to reconcile")
+	}
+	if err := config.InitDB(); err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	appContainer, err := bootstrap.NewAppContainer()
+	if err != nil {
+		log.Fatalf("Failed to initialize application: %v", err)
+	}
+	defer appContainer.Close()
+
+	drifts, err := appContainer.FavoriteService.ReconcileFavoritesCounts(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to reconcile counters: %v", err)
+	}
+	for _, drift := range drifts {
+		fmt.Printf("article %d (%s): favorites_count %d -> %d\n", drift.ArticleID, drift.Slug, drift.Stored, drift.Actual)
+	}
+	fmt.Printf("fixed %d articles\n", len(drifts))
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
 
 	"go-gin-realworld-api/internal/bootstrap"
 	"go-gin-realworld-api/internal/config"
@@ -15,13 +17,52 @@ func main() {
 	// Load config
 	cfg := config.LoadConfig()
 
+	// Subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			runMigrate(os.Args[2:])
+			return
+		case "search":
+			runSearch(os.Args[2:])
+			return
+		case "reconcile-counters":
+			runReconcileCounters(os.Args[2:])
+			return
+		case "tags":
+			runTags(os.Args[2:])
+			return
+		case "trending":
+			runTrending(os.Args[2:])
+			return
+		}
+	}
+
 	// Initialize database
 	if err := config.InitDB(); err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
 
 	// Initialize app container (contains all repositories, services and handlers)
-	appContainer := bootstrap.NewAppContainer()
+	appContainer, err := bootstrap.NewAppContainer()
+	if err != nil {
+		log.Fatalf("Failed to initialize application: %v", err)
+	}
+	defer appContainer.Close()
+
+	// Background workers stop with the server
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	// Precompute the trending rankings in the background
+	if cfg.Trending.RefreshInterval > 0 {
+		go appContainer.TrendingService.RunTrendingRefresher(ctx, cfg.Trending.RefreshInterval)
+	}
+
+	// Publish scheduled articles when they fall due
+	if cfg.Schedule.Interval > 0 {
+		go appContainer.ArticleService.RunScheduler(ctx, cfg.Schedule.Interval)
+	}
 
 	// Create Gin router
 	router := gin.Default()
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"go-gin-realworld-api/internal/config"
+	"go-gin-realworld-api/internal/migrations"
+)
+
+// migrationsDir is where `migrate create` writes new files (relative to the repository root)
+const migrationsDir = "internal/migrations/sql"
+
+const migrateUsage = `usage: app migrate <command>
+
+commands:
+  up [N]         apply all (or the next N) pending migrations
+  down [N]       roll back the last (or the last N) applied migrations
+  status         list migrations and whether they are applied
+  unlock         release the migration lock left behind by a process that died
+  create NAME    create an empty up/down migration pair for every driver`
+
+// runMigrate handles the `migrate` subcommand
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		log.Fatal(migrateUsage)
+	}
+
+	command, rest := args[0], args[1:]
+
+	if command == "create" {
+		if len(rest) != 1 {
+			log.Fatal(migrateUsage)
+		}
+		created, err := migrations.Create(migrationsDir, rest[0])
+		if err != nil {
+			log.Fatalf("Failed to create migration: %v", err)
+		}
+		for _, file := range created {
+			fmt.Println("created", file)
+		}
+		return
+	}
+
+	steps := 0
+	if len(rest) > 0 {
+		n, err := strconv.Atoi(rest[0])
+		if err != nil || n <= 0 {
+			log.Fatalf("invalid step count %q", rest[0])
+		}
+		steps = n
+	}
+
+	driver := config.LoadConfig().Database.Driver
+	if driver == config.DriverMemory {
+		log.Fatal("the memory driver has no schema to migrate")
+	}
+	if err := config.ConnectDB(); err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	migrator, err := migrations.New(config.DB, driver)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx, steps)
+		for _, m := range applied {
+			fmt.Printf("applied  %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+	case "down":
+		reverted, err := migrator.Down(ctx, steps)
+		for _, m := range reverted {
+			fmt.Printf("reverted %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatalf("Rollback failed: %v", err)
+		}
+		if len(reverted) == 0 {
+			fmt.Println("nothing to roll back")
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.AppliedAt != nil {
+				state = "applied " + s.AppliedAt.Format("2006-01-02T15:04:05Z07:00")
+			}
+			fmt.Printf("%04d_%-40s %s\n", s.Version, s.Name, state)
+		}
+	case "unlock":
+		released, err := migrator.Unlock(ctx)
+		if err != nil {
+			log.Fatalf("Failed to release the migration lock: %v", err)
+		}
+		if released {
+			fmt.Println("released the migration lock")
+		} else {
+			fmt.Println("the migration lock is not held")
+		}
+	default:
+		log.Fatal(migrateUsage)
+	}
+}
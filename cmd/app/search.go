@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go-gin-realworld-api/internal/bootstrap"
+	"go-gin-realworld-api/internal/config"
+)
+
+const searchUsage = `usage: app search <command>
+
+commands:
+  reindex        rebuild the embedded search index (SEARCH_BACKEND=index) from the database`
+
+// runSearch handles the `search` subcommand
+func runSearch(args []string) {
+	if len(args) != 1 || args[0] != "reindex" {
+		log.Fatal(searchUsage)
+	}
+
+	cfg := config.LoadConfig()
+	if cfg.Search.Backend != config.SearchBackendIndex {
+		log.Fatal("the embedded search index is disabled; set SEARCH_BACKEND=index")
+	}
+	if cfg.Database.Driver == config.DriverMemory {
+		log.Fatal("the memory driver keeps no data to index")
+	}
+
+	if err := config.InitDB(); err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	appContainer, err := bootstrap.NewAppContainer()
+	if err != nil {
+		log.Fatalf("Failed to initialize application: %v", err)
+	}
+	defer appContainer.Close()
+
+	indexed, err := appContainer.ArticleService.RebuildSearchIndex(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to rebuild search index: %v", err)
+	}
+	fmt.Printf("indexed %d articles into %s\n", indexed, cfg.Search.IndexDir)
+}
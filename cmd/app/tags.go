@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go-gin-realworld-api/internal/bootstrap"
+	"go-gin-realworld-api/internal/config"
+	"go-gin-realworld-api/internal/utils"
+)
+
+const tagsUsage = `usage: app tags <command>
+
+commands:
+  rename OLD NEW   rename a tag on every article that uses it
+  merge FROM INTO  retag the articles of FROM with INTO and delete FROM
+  normalize        give every tag its normalized name, merging tags that differ only by
+                   case, spacing or Unicode form
+  gc               delete the tags no article uses`
+
+// runTags handles the `tags` subcommand
+func runTags(args []string) {
+	if len(args) == 0 {
+		log.Fatal(tagsUsage)
+	}
+	switch {
+	case args[0] == "rename" && len(args) == 3:
+	case args[0] == "merge" && len(args) == 3:
+	case args[0] == "normalize" && len(args) == 1:
+	case args[0] == "gc" && len(args) == 1:
+	default:
+		log.Fatal(tagsUsage)
+	}
+
+	cfg := config.LoadConfig()
+	if cfg.Database.Driver == config.DriverMemory {
+		log.Fatal("the memory driver keeps no tags to administer")
+	}
+	if err := config.InitDB(); err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	appContainer, err := bootstrap.NewAppContainer()
+	if err != nil {
+		log.Fatalf("Failed to initialize application: %v", err)
+	}
+	defer appContainer.Close()
+
+	ctx := context.Background()
+	tagService := appContainer.TagService
+	switch args[0] {
+	case "rename":
+		if err := tagService.RenameTag(ctx, args[1], args[2]); err != nil {
+			log.Fatalf("Failed to rename tag: %v", err)
+		}
+		fmt.Printf("renamed %s to %s\n", utils.NormalizeTag(args[1]), utils.NormalizeTag(args[2]))
+	case "merge":
+		merged, err := tagService.MergeTags(ctx, args[1], args[2])
+		if err != nil {
+			log.Fatalf("Failed to merge tags: %v", err)
+		}
+		fmt.Printf("merged %s into %s on %d articles\n", utils.NormalizeTag(args[1]), utils.NormalizeTag(args[2]), merged)
+	case "normalize":
+		changed, err := tagService.NormalizeExistingTags(ctx)
+		if err != nil {
+			log.Fatalf("Failed to normalize tags: %v", err)
+		}
+		for _, tag := range changed {
+			if tag.Merged {
+				fmt.Printf("merged %s into %s\n", tag.From, tag.To)
+			} else {
+				fmt.Printf("renamed %s to %s\n", tag.From, tag.To)
+			}
+		}
+		fmt.Printf("normalized %d tags\n", len(changed))
+	case "gc":
+		deleted, err := tagService.DeleteUnusedTags(ctx)
+		if err != nil {
+			log.Fatalf("Failed to delete unused tags: %v", err)
+		}
+		for _, name := range deleted {
+			fmt.Println(name)
+		}
+		fmt.Printf("deleted %d unused tags\n", len(deleted))
+		return
+	}
+
+	// The tag service re-indexed the affected articles, but only in this process's copy
+	if cfg.Search.Backend == config.SearchBackendIndex {
+		fmt.Println("updated the search index; restart running servers to load it")
+	}
+}
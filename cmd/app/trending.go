@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go-gin-realworld-api/internal/bootstrap"
+	"go-gin-realworld-api/internal/config"
+)
+
+const trendingUsage = `usage: app trending refresh
+
+recomputes the trending rankings, for running from cron when TRENDING_REFRESH_INTERVAL=0`
+
+// runTrending handles the `trending` subcommand
+func runTrending(args []string) {
+	if len(args) != 1 || args[0] != "refresh" {
+		log.Fatal(trendingUsage)
+	}
+
+	if config.LoadConfig().Database.Driver == config.DriverMemory {
+		log.Fatal("the memory driver keeps no data to rank")
+	}
+	if err := config.InitDB(); err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	appContainer, err := bootstrap.NewAppContainer()
+	if err != nil {
+		log.Fatalf("Failed to initialize application: %v", err)
+	}
+	defer appContainer.Close()
+
+	if err := appContainer.TrendingService.RefreshTrending(context.Background()); err != nil {
+		log.Fatalf("Failed to refresh trending articles: %v", err)
+	}
+	fmt.Println("refreshed trending articles")
+}
@@ -1,10 +1,18 @@
 package bootstrap
 
 import (
+	"fmt"
+
 	"go-gin-realworld-api/internal/config"
 	"go-gin-realworld-api/internal/handlers"
-	"go-gin-realworld-api/internal/repository/mysql"
+	"go-gin-realworld-api/internal/repository"
+	"go-gin-realworld-api/internal/repository/cached"
+	"go-gin-realworld-api/internal/repository/memory"
+	"go-gin-realworld-api/internal/repository/sqlrepo"
+	"go-gin-realworld-api/internal/search"
 	"go-gin-realworld-api/internal/services"
+
+	"gorm.io/gorm"
 )
 
 type AppContainer struct {
@@ -16,26 +24,146 @@ type AppContainer struct {
 	CommentHandler  *handlers.CommentHandler
 	FavoriteHandler *handlers.FavoriteHandler
 	TagHandler      *handlers.TagHandler
+	TrendingHandler *handlers.TrendingHandler
+	RelatedHandler  *handlers.RelatedHandler
+	CacheHandler    *handlers.CacheHandler
+
+	// Services used outside of HTTP handlers (subcommands)
+	ArticleService  *services.ArticleService
+	FavoriteService *services.FavoriteService
+	TagService      *services.TagService
+	TrendingService *services.TrendingService
+
+	searchIndex search.SearchIndex
+}
+
+// repositories groups the repository implementations for one database driver
+type repositories struct {
+	user     repository.UserRepository
+	profile  repository.ProfileRepository
+	follow   repository.FollowRepository
+	article  repository.ArticleRepository
+	comment  repository.CommentRepository
+	favorite repository.FavoriteRepository
+	tag      repository.TagRepository
+	timeline repository.TimelineRepository
+	trending repository.TrendingRepository
+	related  repository.RelatedRepository
+}
+
+// newRepositories returns the repository implementations matching the database driver
+func newRepositories(driver string) *repositories {
+	switch driver {
+	case config.DriverPostgres:
+		return newSqlRepositories(sqlrepo.PostgresFullText{})
+	case config.DriverMemory:
+		store := memory.StoreOf(config.DB)
+		return &repositories{
+			user:     memory.NewMemoryUserRepository(store),
+			profile:  memory.NewMemoryProfileRepository(store),
+			follow:   memory.NewMemoryFollowRepository(store),
+			article:  memory.NewMemoryArticleRepository(store),
+			comment:  memory.NewMemoryCommentRepository(store),
+			favorite: memory.NewMemoryFavoriteRepository(store),
+			tag:      memory.NewMemoryTagRepository(store),
+			timeline: memory.NewMemoryTimelineRepository(store),
+			trending: memory.NewMemoryTrendingRepository(store),
+			related:  memory.NewMemoryRelatedRepository(store),
+		}
+	case config.DriverSQLite:
+		return newSqlRepositories(sqlrepo.SqliteFullText{})
+	default:
+		return newSqlRepositories(sqlrepo.MySqlFullText{})
+	}
+}
+
+// newSqlRepositories returns the GORM repositories shared by the SQL databases, searching
+// articles with the database's full-text support
+func newSqlRepositories(fullText sqlrepo.FullText) *repositories {
+	return &repositories{
+		user:     sqlrepo.NewSqlUserRepository(),
+		profile:  sqlrepo.NewSqlProfileRepository(),
+		follow:   sqlrepo.NewSqlFollowRepository(),
+		article:  sqlrepo.NewSqlArticleRepository(fullText),
+		comment:  sqlrepo.NewSqlCommentRepository(),
+		favorite: sqlrepo.NewSqlFavoriteRepository(),
+		tag:      sqlrepo.NewSqlTagRepository(),
+		timeline: sqlrepo.NewSqlTimelineRepository(),
+		trending: sqlrepo.NewSqlTrendingRepository(),
+		related:  sqlrepo.NewSqlRelatedRepository(),
+	}
 }
 
-func NewAppContainer() *AppContainer {
-	// Initialize repositories
-	userRepo := mysql.NewMySqlUserRepository()
-	profileRepo := mysql.NewMySqlProfileRepository()
-	followRepo := mysql.NewMySqlFollowRepository()
-	articleRepo := mysql.NewMySqlArticleRepository()
-	commentRepo := mysql.NewMySqlCommentRepository()
-	favoriteRepo := mysql.NewMySqlFavoriteRepository()
-	tagRepo := mysql.NewMySqlTagRepository()
+// cacheRepositories wraps the repositories whose reads are cached, or whose writes change
+// cached entries, in caching decorators and returns their caches, or nil when caching is
+// disabled. The caches fill and invalidate through db, the primary database.
+func cacheRepositories(repos *repositories, cfg config.CacheConfig, db *gorm.DB) *cached.Caches {
+	if cfg.Size <= 0 {
+		return nil
+	}
+	caches := cached.NewCaches(cfg.Size, cfg.TTL)
+	caches.UsePrimary(db)
+	repos.user = cached.NewCachedUserRepository(repos.user, caches)
+	repos.article = cached.NewCachedArticleRepository(repos.article, caches)
+	repos.favorite = cached.NewCachedFavoriteRepository(repos.favorite, caches)
+	repos.tag = cached.NewCachedTagRepository(repos.tag, caches)
+	return caches
+}
+
+// newSearchIndex opens the embedded search index when SEARCH_BACKEND=index; otherwise it
+// returns nil and search uses the database. With the memory driver the index is not
+// persisted either, since the data it indexes is lost on restart.
+func newSearchIndex(cfg *config.Config) (search.SearchIndex, error) {
+	switch cfg.Search.Backend {
+	case config.SearchBackendDatabase:
+		return nil, nil
+	case config.SearchBackendIndex:
+		if cfg.Database.Driver == config.DriverMemory {
+			return search.NewInvertedIndex(), nil
+		}
+		return search.OpenInvertedIndex(cfg.Search.IndexDir)
+	default:
+		return nil, fmt.Errorf("unsupported search backend: %q", cfg.Search.Backend)
+	}
+}
+
+func NewAppContainer() (*AppContainer, error) {
+	cfg := config.LoadConfig()
+
+	// Initialize repositories for the configured database driver
+	repos := newRepositories(cfg.Database.Driver)
+	caches := cacheRepositories(repos, cfg.Cache, config.DB)
+	userRepo := repos.user
+	profileRepo := repos.profile
+	followRepo := repos.follow
+	articleRepo := repos.article
+	commentRepo := repos.comment
+	favoriteRepo := repos.favorite
+	tagRepo := repos.tag
+	timelineRepo := repos.timeline
+	trendingRepo := repos.trending
+	relatedRepo := repos.related
+
+	searchIndex, err := newSearchIndex(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	// Initialize services
 	authService := services.NewAuthService(config.DB, userRepo)
 	userService := services.NewUserService(config.DB, userRepo, profileRepo, followRepo)
-	profileService := services.NewProfileService(config.DB, userRepo, profileRepo, followRepo)
-	articleService := services.NewArticleService(config.DB, articleRepo)
-	commentService := services.NewCommentService(config.DB, commentRepo, articleRepo)
+	profileService := services.NewProfileService(config.DB, userRepo, profileRepo, followRepo, timelineRepo)
+	articleService := services.NewArticleService(config.DB, config.ReadDB, articleRepo, favoriteRepo, followRepo, timelineRepo, searchIndex)
+	articleService.SetFanOutMaxFollowers(cfg.Feed.FanOutMaxFollowers)
+	commentService := services.NewCommentService(config.DB, config.ReadDB, commentRepo, articleRepo)
 	favoriteService := services.NewFavoriteService(config.DB, favoriteRepo, articleRepo)
-	tagService := services.NewTagService(config.DB, tagRepo)
+	tagService := services.NewTagService(config.DB, config.ReadDB, tagRepo)
+	// Tag and username changes alter the search documents of articles
+	userService.SetArticleService(articleService)
+	tagService.SetArticleService(articleService)
+	trendingService := services.NewTrendingService(config.DB, config.ReadDB, trendingRepo, articleService)
+	relatedService := services.NewRelatedService(config.ReadDB, relatedRepo, articleRepo, articleService)
+	relatedService.SetWeights(cfg.Related.TagWeight, cfg.Related.CoFavoriteWeight)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService)
@@ -45,6 +173,13 @@ func NewAppContainer() *AppContainer {
 	commentHandler := handlers.NewCommentHandler(commentService)
 	favoriteHandler := handlers.NewFavoriteHandler(favoriteService)
 	tagHandler := handlers.NewTagHandler(tagService)
+	trendingHandler := handlers.NewTrendingHandler(trendingService)
+	relatedHandler := handlers.NewRelatedHandler(relatedService)
+	// A nil *cached.Caches must not become a non-nil interface
+	cacheHandler := handlers.NewCacheHandler(nil)
+	if caches != nil {
+		cacheHandler = handlers.NewCacheHandler(caches)
+	}
 
 	return &AppContainer{
 		UserHandler:     userHandler,
@@ -54,5 +189,21 @@ func NewAppContainer() *AppContainer {
 		CommentHandler:  commentHandler,
 		FavoriteHandler: favoriteHandler,
 		TagHandler:      tagHandler,
+		TrendingHandler: trendingHandler,
+		RelatedHandler:  relatedHandler,
+		CacheHandler:    cacheHandler,
+		ArticleService:  articleService,
+		FavoriteService: favoriteService,
+		TagService:      tagService,
+		TrendingService: trendingService,
+		searchIndex:     searchIndex,
+	}, nil
+}
+
+// Close releases resources held by the container
+func (c *AppContainer) Close() error {
+	if c.searchIndex != nil {
+		return c.searchIndex.Close()
 	}
+	return nil
 }
@@ -0,0 +1,135 @@
+// Package cache provides an in-process LRU cache with expiring entries.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Stats reports how a cache has been used since it was created
+type Stats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Size      int    `json:"size"`
+	Capacity  int    `json:"capacity"`
+}
+
+// LRU is a cache of at most capacity entries that drops the least recently used entry
+// when full. Entries expire ttl after they are set; a ttl <= 0 keeps them until evicted.
+// It is safe for concurrent use.
+type LRU[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	items    map[K]*list.Element
+	order    *list.List // most recently used first
+	stats    Stats
+}
+
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// NewLRU creates an empty cache holding at most capacity entries (at least one)
+func NewLRU[K comparable, V any](capacity int, ttl time.Duration) *LRU[K, V] {
+	capacity = max(capacity, 1)
+	return &LRU[K, V]{
+		capacity: capacity,
+		ttl:      ttl,
+		items:    make(map[K]*list.Element, capacity),
+		order:    list.New(),
+		stats:    Stats{Capacity: capacity},
+	}
+}
+
+// Get returns the value cached for key. An expired entry is removed and counts as a miss.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		e := elem.Value.(*entry[K, V])
+		if c.ttl <= 0 || time.Now().Before(e.expires) {
+			c.order.MoveToFront(elem)
+			c.stats.Hits++
+			return e.value, true
+		}
+		c.removeElement(elem)
+	}
+	c.stats.Misses++
+	var zero V
+	return zero, false
+}
+
+// Set caches value under key, evicting the least recently used entry if the cache is full
+func (c *LRU[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := time.Now().Add(c.ttl)
+	if elem, ok := c.items[key]; ok {
+		e := elem.Value.(*entry[K, V])
+		e.value, e.expires = value, expires
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	if c.order.Len() >= c.capacity {
+		c.removeElement(c.order.Back())
+		c.stats.Evictions++
+	}
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, expires: expires})
+}
+
+// Remove drops the entry for key, if any
+func (c *LRU[K, V]) Remove(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.removeElement(elem)
+	}
+}
+
+// RemoveFunc drops every entry for which match returns true
+func (c *LRU[K, V]) RemoveFunc(match func(key K, value V) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for elem := c.order.Front(); elem != nil; {
+		next := elem.Next()
+		if e := elem.Value.(*entry[K, V]); match(e.key, e.value) {
+			c.removeElement(elem)
+		}
+		elem = next
+	}
+}
+
+// Clear drops every entry; the statistics are kept
+func (c *LRU[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[K]*list.Element, c.capacity)
+	c.order.Init()
+}
+
+// Stats returns the current statistics
+func (c *LRU[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Size = c.order.Len()
+	return stats
+}
+
+// removeElement drops an entry (caller must hold the lock)
+func (c *LRU[K, V]) removeElement(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.items, elem.Value.(*entry[K, V]).key)
+}
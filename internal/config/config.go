@@ -2,7 +2,10 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -11,6 +14,12 @@ type Config struct {
 	Server   ServerConfig
 	Database DatabaseConfig
 	JWT      JWTConfig
+	Search   SearchConfig
+	Feed     FeedConfig
+	Cache    CacheConfig
+	Trending TrendingConfig
+	Related  RelatedConfig
+	Schedule ScheduleConfig
 }
 
 type ServerConfig struct {
@@ -19,18 +28,68 @@ type ServerConfig struct {
 }
 
 type DatabaseConfig struct {
-	Driver   string
-	Host     string
-	Port     string
-	User     string
-	Password string
-	Database string
+	Driver      string
+	Host        string
+	Port        string
+	User        string
+	Password    string
+	Database    string
+	Path        string
+	SSLMode     string
+	AutoMigrate bool
+	// ReplicaDSNs are read replicas of the primary, in the driver's DSN format
+	ReplicaDSNs []string
 }
 
 type JWTConfig struct {
 	Secret string
 }
 
+// Search backends (SEARCH_BACKEND)
+const (
+	SearchBackendDatabase = "database"
+	SearchBackendIndex    = "index"
+)
+
+type SearchConfig struct {
+	// Backend is "database" for the database's full-text search or "index" for the embedded index
+	Backend string
+	// IndexDir is where the embedded index is persisted
+	IndexDir string
+}
+
+type FeedConfig struct {
+	// FanOutMaxFollowers is the follower count above which an author's new articles are
+	// read into feeds on demand instead of being copied to every follower's timeline
+	FanOutMaxFollowers int64
+}
+
+type CacheConfig struct {
+	// Size is the most articles cached in process; 0 disables the repository caches
+	Size int
+	// TTL is how long a cached entry is served before it is read again
+	TTL time.Duration
+}
+
+type TrendingConfig struct {
+	// RefreshInterval is how often the server recomputes the trending rankings; 0 disables
+	// the background refresh (run `app trending refresh` instead)
+	RefreshInterval time.Duration
+}
+
+type ScheduleConfig struct {
+	// Interval is how often the server publishes the scheduled articles that are due; 0
+	// disables the scheduler
+	Interval time.Duration
+}
+
+type RelatedConfig struct {
+	// TagWeight is what each tag an article shares with another adds to its related score
+	TagWeight float64
+	// CoFavoriteWeight is what each user who favorited both articles adds to the score
+	CoFavoriteWeight float64
+}
+
 var (
 	cfg  *Config
 	once sync.Once
@@ -54,10 +113,36 @@ func LoadConfig() *Config {
 				User:     getEnv("DB_USER", "root"),
 				Password: getEnv("DB_PASSWORD", "password"),
 				Database: getEnv("DB_NAME", "realworld_api"),
+				Path:     getEnv("DB_PATH", "realworld_api.db"),
+				SSLMode:  getEnv("DB_SSLMODE", "disable"),
+				// Apply pending migrations on startup instead of refusing to start
+				AutoMigrate: getEnv("DB_AUTO_MIGRATE", "false") == "true",
+				ReplicaDSNs: getEnvList("DB_REPLICA_DSNS"),
 			},
 			JWT: JWTConfig{
 				Secret: getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
 			},
+			Search: SearchConfig{
+				Backend:  getEnv("SEARCH_BACKEND", SearchBackendDatabase),
+				IndexDir: getEnv("SEARCH_INDEX_DIR", "search_index"),
+			},
+			Feed: FeedConfig{
+				FanOutMaxFollowers: getEnvInt("FEED_FANOUT_MAX_FOLLOWERS", 10000),
+			},
+			Cache: CacheConfig{
+				Size: int(getEnvInt("CACHE_SIZE", 1000)),
+				TTL:  getEnvDuration("CACHE_TTL", 30*time.Second),
+			},
+			Trending: TrendingConfig{
+				RefreshInterval: getEnvDuration("TRENDING_REFRESH_INTERVAL", 5*time.Minute),
+			},
+			Schedule: ScheduleConfig{
+				Interval: getEnvDuration("SCHEDULE_INTERVAL", 30*time.Second),
+			},
+			Related: RelatedConfig{
+				TagWeight:        getEnvFloat("RELATED_TAG_WEIGHT", 2),
+				CoFavoriteWeight: getEnvFloat("RELATED_COFAVORITE_WEIGHT", 1),
+			},
 		}
 	})
 	return cfg
@@ -70,3 +155,41 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// getEnvInt parses an integer variable, falling back to the default when unset or invalid
+func getEnvInt(key string, defaultValue int64) int64 {
+	value, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvFloat parses a decimal variable, falling back to the default when unset or invalid
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvDuration parses a duration such as "30s", falling back to the default when unset or invalid
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvList splits a comma-separated variable, skipping empty entries
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -1,28 +1,87 @@
 package config
 
 import (
+	"context"
+	"fmt"
 	"log"
 
-	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/migrations"
+	"go-gin-realworld-api/internal/repository/memory"
 
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
+)
+
+// Supported database drivers (DB_DRIVER)
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory"
 )
 
 var DB *gorm.DB
 
+// ReadDB serves read-only queries that may lag behind the primary.
+// It is the replica pool when DB_REPLICA_DSNS is set, otherwise the same handle as DB.
+var ReadDB *gorm.DB
+
 // BuildDSN builds the MySQL DSN from database config
 func BuildDSN() string {
 	dbConfig := LoadConfig().Database
 	return dbConfig.User + ":" + dbConfig.Password + "@tcp(" + dbConfig.Host + ":" + dbConfig.Port + ")/" + dbConfig.Database + "?charset=utf8mb4&parseTime=True&loc=Local"
 }
 
+// BuildPostgresDSN builds the PostgreSQL DSN from database config
+func BuildPostgresDSN() string {
+	dbConfig := LoadConfig().Database
+	return "host=" + dbConfig.Host + " port=" + dbConfig.Port + " user=" + dbConfig.User + " password=" + dbConfig.Password + " dbname=" + dbConfig.Database + " sslmode=" + dbConfig.SSLMode + " TimeZone=UTC"
+}
+
+// BuildSQLiteDSN builds the SQLite DSN from database config.
+// Foreign keys are enabled so that ON DELETE CASCADE behaves like MySQL.
+func BuildSQLiteDSN() string {
+	dbConfig := LoadConfig().Database
+	return "file:" + dbConfig.Path + "?_foreign_keys=on&_busy_timeout=5000&_journal_mode=WAL"
+}
+
 // ConnectToMySQL establishes a connection to the MySQL database
 func ConnectToMySQL(dsn string) error {
 	var err error
 	DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger:         logger.Default.LogMode(logger.Info),
+		TranslateError: true,
+	})
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+		return err
+	}
+
+	// Configure connection pool
+	sqlDB, err := DB.DB()
+	if err != nil {
+		log.Fatalf("Failed to get database instance: %v", err)
+		return err
+	}
+
+	// Set connection pool settings
+	sqlDB.SetMaxIdleConns(10)
+	sqlDB.SetMaxOpenConns(100)
+
+	log.Println("✅ Database connected successfully")
+	return nil
+}
+
+// ConnectToPostgres establishes a connection to the PostgreSQL database
+func ConnectToPostgres(dsn string) error {
+	var err error
+	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Info),
+		TranslateError: true,
 	})
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -44,36 +103,163 @@ func ConnectToMySQL(dsn string) error {
 	return nil
 }
 
-// MigrateDB performs schema migration to create/update tables
-func MigrateDB() error {
-	if err := DB.AutoMigrate(
-		&models.User{},
-		&models.Profile{},
-		&models.Follow{},
-		&models.Article{},
-		&models.Comment{},
-		&models.Favorite{},
-		&models.Tag{},
-		&models.ArticleTag{},
-	); err != nil {
-		log.Fatalf("Failed to migrate database: %v", err)
+// ConnectToSQLite opens (or creates) the SQLite database file
+func ConnectToSQLite(dsn string) error {
+	var err error
+	DB, err = gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Info),
+		TranslateError: true,
+	})
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+		return err
+	}
+
+	sqlDB, err := DB.DB()
+	if err != nil {
+		log.Fatalf("Failed to get database instance: %v", err)
+		return err
+	}
+
+	// SQLite allows a single writer; serialize access through one connection
+	// to avoid "database is locked" errors under concurrent requests.
+	sqlDB.SetMaxOpenConns(1)
+
+	log.Println("✅ Database connected successfully")
+	return nil
+}
+
+// ConnectToMemory sets up a database handle that performs no I/O.
+// The memory repositories keep all data in process; it is lost on restart.
+func ConnectToMemory() error {
+	var err error
+	DB, err = gorm.Open(memory.Open(), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		log.Fatalf("Failed to set up in-memory database: %v", err)
+		return err
+	}
+
+	log.Println("✅ Running with in-memory database (demo mode, data is not persisted)")
+	return nil
+}
+
+// ConnectToReplicas opens the read replica pool. Queries are spread randomly
+// across the replicas; without replicas ReadDB falls back to the primary.
+func ConnectToReplicas(driver string, dsns []string) error {
+	if len(dsns) == 0 {
+		ReadDB = DB
+		return nil
+	}
+
+	dialectors := make([]gorm.Dialector, 0, len(dsns))
+	for _, dsn := range dsns {
+		switch driver {
+		case DriverMySQL:
+			dialectors = append(dialectors, mysql.Open(dsn))
+		case DriverPostgres:
+			dialectors = append(dialectors, postgres.Open(dsn))
+		default:
+			return fmt.Errorf("read replicas are not supported by the %q driver", driver)
+		}
+	}
+
+	var err error
+	ReadDB, err = gorm.Open(dialectors[0], &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Info),
+		TranslateError: true,
+	})
+	if err != nil {
+		log.Fatalf("Failed to connect to read replica: %v", err)
+		return err
+	}
+
+	// Balance queries across every replica, including the first one
+	resolver := dbresolver.Register(dbresolver.Config{Replicas: dialectors})
+	if err := ReadDB.Use(resolver); err != nil {
+		log.Fatalf("Failed to configure read replicas: %v", err)
+		return err
+	}
+
+	// Set connection pool settings
+	resolver.SetMaxIdleConns(10)
+	resolver.SetMaxOpenConns(100)
+
+	log.Printf("✅ Connected to %d read replica(s)", len(dsns))
+	return nil
+}
+
+// MigrateDB applies pending versioned migrations (see internal/migrations)
+func MigrateDB(ctx context.Context) error {
+	migrator, err := migrations.New(DB, LoadConfig().Database.Driver)
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up(ctx, 0)
+	if err != nil {
+		log.Printf("Failed to migrate database: %v", err)
 		return err
 	}
 
-	log.Println("✅ Database migration completed successfully")
+	log.Printf("✅ Database migration completed successfully (%d applied)", len(applied))
 	return nil
 }
 
-// InitDB initializes database connection and performs migration
+// CheckSchema returns an error when the database schema is behind the embedded migrations
+func CheckSchema(ctx context.Context) error {
+	migrator, err := migrations.New(DB, LoadConfig().Database.Driver)
+	if err != nil {
+		return err
+	}
+	return migrator.CheckUpToDate(ctx)
+}
+
+// ConnectDB establishes a connection for the configured driver without touching the schema
+func ConnectDB() error {
+	switch driver := LoadConfig().Database.Driver; driver {
+	case DriverMySQL:
+		return ConnectToMySQL(BuildDSN())
+	case DriverPostgres:
+		return ConnectToPostgres(BuildPostgresDSN())
+	case DriverSQLite:
+		return ConnectToSQLite(BuildSQLiteDSN())
+	case DriverMemory:
+		return ConnectToMemory()
+	default:
+		return fmt.Errorf("unsupported database driver: %q", driver)
+	}
+}
+
+// InitDB initializes the database connections and makes sure the schema is up to date.
+// Pending migrations are applied when DB_AUTO_MIGRATE is enabled; otherwise the server
+// refuses to start until `migrate up` has been run.
 func InitDB() error {
-	dsn := BuildDSN()
-	if err := ConnectToMySQL(dsn); err != nil {
+	if err := ConnectDB(); err != nil {
 		return err
 	}
 
-	if err := MigrateDB(); err != nil {
+	dbConfig := LoadConfig().Database
+	if err := ConnectToReplicas(dbConfig.Driver, dbConfig.ReplicaDSNs); err != nil {
 		return err
 	}
 
+	// The in-memory backend has no schema
+	if LoadConfig().Database.Driver == DriverMemory {
+		return nil
+	}
+
+	ctx := context.Background()
+	if LoadConfig().Database.AutoMigrate {
+		if err := MigrateDB(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := CheckSchema(ctx); err != nil {
+		return fmt.Errorf("%w; run `migrate up` first", err)
+	}
+
 	return nil
 }
@@ -1,11 +1,102 @@
 package dtos
 
+import (
+	"slices"
+	"time"
+
+	"go-gin-realworld-api/internal/models"
+)
+
 type ListArticlesQuery struct {
-	Tag       string `form:"tag"`
-	Author    string `form:"author"`
-	Favorited *bool  `form:"favorited"`
-	Limit     int    `form:"limit,default=20"`
-	Offset    int    `form:"offset,default=0"`
+	// Tags are comma-separated or repeated; TagMode says whether articles need all of them or any
+	Tags        []string `form:"tag" collection_format:"csv" binding:"max=10"`
+	TagMode     string   `form:"tagMode" binding:"omitempty,oneof=all any"`
+	ExcludeTags []string `form:"excludeTag" collection_format:"csv" binding:"max=10"`
+	Author      string   `form:"author"`
+	Favorited   *bool    `form:"favorited"`
+	// CreatedAfter (inclusive) and CreatedBefore (exclusive) are RFC 3339 timestamps
+	CreatedAfter  time.Time `form:"createdAfter"`
+	CreatedBefore time.Time `form:"createdBefore"`
+	Sort          string    `form:"sort" binding:"omitempty,oneof=newest oldest mostFavorited mostCommented recentlyUpdated"`
+	Limit         int       `form:"limit,default=20"`
+	Offset        int       `form:"offset,default=0"`
+	// Cursor is a nextCursor/prevCursor from a previous page; it takes precedence over Offset.
+	// Only the newest-first sort has cursors.
+	Cursor    string `form:"cursor"`
+	SkipCount bool   `form:"skipCount"`
+}
+
+// FeedArticlesQuery pages through the personal feed, sorted and restricted to a creation
+// date range like ListArticlesQuery
+type FeedArticlesQuery struct {
+	CreatedAfter  time.Time `form:"createdAfter"`
+	CreatedBefore time.Time `form:"createdBefore"`
+	Sort          string    `form:"sort" binding:"omitempty,oneof=newest oldest mostFavorited mostCommented recentlyUpdated"`
+	Limit         int       `form:"limit,default=20"`
+	Offset        int       `form:"offset,default=0"`
+	// Only the newest-first sort has cursors
+	Cursor    string `form:"cursor"`
+	SkipCount bool   `form:"skipCount"`
+}
+
+// ListDraftsQuery lists the current user's unpublished articles: drafts and scheduled
+// ones, or only those with Status
+type ListDraftsQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=draft scheduled archived"`
+	Limit  int    `form:"limit,default=20"`
+	Offset int    `form:"offset,default=0"`
+}
+
+type TrendingArticlesQuery struct {
+	Window string `form:"window,default=24h" binding:"oneof=24h 7d"`
+	Limit  int    `form:"limit,default=20"`
+	Offset int    `form:"offset,default=0"`
+}
+
+type RelatedArticlesQuery struct {
+	Limit int `form:"limit,default=5" binding:"min=1,max=20"`
+}
+
+type SearchArticlesQuery struct {
+	Q      string `form:"q" binding:"required,max=200"`
+	Tag    string `form:"tag"`
+	Author string `form:"author"`
+	Limit  int    `form:"limit,default=20"`
+	Offset int    `form:"offset,default=0"`
+}
+
+// PatchArticleRequest is a JSON Merge Patch of an article: absent members are left alone,
+// a null or empty tagList removes every tag and a null publishedAt unschedules the article
+type PatchArticleRequest struct {
+	Article *ArticlePatch `json:"article" binding:"required"`
+}
+
+type ArticlePatch struct {
+	Title       Optional[string]    `json:"title"`
+	Description Optional[string]    `json:"description"`
+	Body        Optional[string]    `json:"body"`
+	TagList     Optional[[]string]  `json:"tagList"`
+	Status      Optional[string]    `json:"status"`
+	PublishedAt Optional[time.Time] `json:"publishedAt"`
+}
+
+// Validate returns an error message by member for the members that cannot be applied
+func (r *PatchArticleRequest) Validate() map[string]string {
+	errs := make(map[string]string)
+	p := r.Article
+	notNullable(errs, map[string]bool{
+		"title":       p.Title.Null,
+		"description": p.Description.Null,
+		"body":        p.Body.Null,
+		"status":      p.Status.Null,
+	})
+	if p.Title.Set && !p.Title.Null && p.Title.Value == "" {
+		errs["title"] = "cannot be empty"
+	}
+	if p.Status.Set && !p.Status.Null && !slices.Contains(ArticleStatuses, p.Status.Value) {
+		errs["status"] = "is invalid"
+	}
+	return errs
 }
 
 type ArticleAuthorResponse struct {
@@ -13,41 +104,78 @@ type ArticleAuthorResponse struct {
 }
 
 type ArticleResponse struct {
-	Slug           string                `json:"slug"`
-	Title          string                `json:"title"`
-	Description    string                `json:"description"`
-	Body           string                `json:"body"`
-	TagList        []string              `json:"tagList"`
-	CreatedAt      string                `json:"createdAt"`
-	UpdatedAt      string                `json:"updatedAt"`
-	Favorited      bool                  `json:"favorited"`
-	FavoritesCount int                   `json:"favoritesCount"`
-	Author         ArticleAuthorResponse `json:"author"`
+	Slug           string   `json:"slug"`
+	Title          string   `json:"title"`
+	Description    string   `json:"description"`
+	Body           string   `json:"body"`
+	TagList        []string `json:"tagList"`
+	CreatedAt      string   `json:"createdAt"`
+	UpdatedAt      string   `json:"updatedAt"`
+	Favorited      bool     `json:"favorited"`
+	FavoritesCount int      `json:"favoritesCount"`
+	Version        int64    `json:"version"`
+	Status         string   `json:"status"`
+	// PublishedAt is when the article was or, for a scheduled one, will be published
+	PublishedAt string                `json:"publishedAt,omitempty"`
+	Author      ArticleAuthorResponse `json:"author"`
 }
 
 type ArticlesListResponse struct {
-	Articles      []ArticleResponse `json:"articles"`
-	ArticlesCount int               `json:"articlesCount"`
+	Articles []ArticleResponse `json:"articles"`
+	// ArticlesCount is omitted when the client asked to skip the count
+	ArticlesCount *int   `json:"articlesCount,omitempty"`
+	NextCursor    string `json:"nextCursor,omitempty"`
+	PrevCursor    string `json:"prevCursor,omitempty"`
 }
 
+// ArticleSearchHighlight holds HTML-escaped excerpts with the matched words wrapped in <em>
+type ArticleSearchHighlight struct {
+	Title   string `json:"title"`
+	Snippet string `json:"snippet"`
+}
+
+type ArticleSearchResult struct {
+	ArticleResponse
+	Highlight ArticleSearchHighlight `json:"highlight"`
+}
+
+type ArticleSearchResponse struct {
+	Articles      []ArticleSearchResult `json:"articles"`
+	ArticlesCount int                   `json:"articlesCount"`
+}
+
+// ArticleStatuses are the statuses an article can be given
+var ArticleStatuses = []string{models.ArticleStatusDraft, models.ArticleStatusPublished, models.ArticleStatusScheduled, models.ArticleStatusArchived}
+
+// CreateArticleRequest creates a published article, unless Status says otherwise. A
+// scheduled article needs a PublishedAt in the future.
 type CreateArticleRequest struct {
 	Article struct {
-		Title       string   `json:"title" binding:"required"`
-		Description string   `json:"description" binding:"required"`
-		Body        string   `json:"body" binding:"required"`
-		TagList     []string `json:"tagList"`
+		Title       string     `json:"title" binding:"required"`
+		Description string     `json:"description" binding:"required"`
+		Body        string     `json:"body" binding:"required"`
+		TagList     []string   `json:"tagList"`
+		Status      string     `json:"status" binding:"omitempty,oneof=draft published scheduled"`
+		PublishedAt *time.Time `json:"publishedAt"`
 	} `json:"article" binding:"required"`
 }
 
 type UpdateArticleRequest struct {
 	Article struct {
-		Title       string   `json:"title"`
-		Description string   `json:"description"`
-		Body        string   `json:"body"`
-		TagList     []string `json:"tagList"`
+		Title       string     `json:"title"`
+		Description string     `json:"description"`
+		Body        string     `json:"body"`
+		TagList     []string   `json:"tagList"`
+		Status      string     `json:"status" binding:"omitempty,oneof=draft published scheduled archived"`
+		PublishedAt *time.Time `json:"publishedAt"`
 	} `json:"article" binding:"required"`
 }
 
 type ArticleDetailResponse struct {
 	Article ArticleResponse `json:"article"`
 }
+
+// ArticleMovedResponse accompanies the redirect from an old article slug
+type ArticleMovedResponse struct {
+	Slug string `json:"slug"`
+}
@@ -0,0 +1,38 @@
+package dtos
+
+import "encoding/json"
+
+// Optional is a member of a JSON Merge Patch (RFC 7386) body: an absent member leaves
+// the target alone, null clears it and any other value replaces it
+type Optional[T any] struct {
+	Set   bool // the member was present
+	Null  bool // the member was null
+	Value T
+}
+
+// OptionalOf returns an Optional that replaces the target with value
+func OptionalOf[T any](value T) Optional[T] {
+	return Optional[T]{Set: true, Value: value}
+}
+
+// UnmarshalJSON is only called for members present in the body
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		o.Null = true
+		var zero T
+		o.Value = zero
+		return nil
+	}
+	o.Null = false
+	return json.Unmarshal(data, &o.Value)
+}
+
+// notNullable records in errs the members of a patch that are null but cannot be cleared
+func notNullable(errs map[string]string, members map[string]bool) {
+	for name, null := range members {
+		if null {
+			errs[name] = "cannot be null"
+		}
+	}
+}
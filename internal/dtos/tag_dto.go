@@ -0,0 +1,22 @@
+package dtos
+
+type ListTagsQuery struct {
+	// WithCounts returns each tag with the number of articles that use it
+	WithCounts bool   `form:"withCounts"`
+	Sort       string `form:"sort" binding:"omitempty,oneof=name popular"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=1000"`
+}
+
+type SuggestTagsQuery struct {
+	Prefix string `form:"prefix" binding:"required,max=50"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type TagCountResponse struct {
+	Name          string `json:"name"`
+	ArticlesCount int64  `json:"articlesCount"`
+}
+
+type TagCountsResponse struct {
+	Tags []TagCountResponse `json:"tags"`
+}
@@ -18,6 +18,37 @@ type UpdateUserRequest struct {
 	} `json:"user" binding:"required"`
 }
 
+// PatchUserRequest is a JSON Merge Patch of the current user: absent members are left
+// alone and a null image or bio clears it
+type PatchUserRequest struct {
+	User *UserPatch `json:"user" binding:"required"`
+}
+
+type UserPatch struct {
+	Email    Optional[string] `json:"email"`
+	Username Optional[string] `json:"username"`
+	Password Optional[string] `json:"password"`
+	Image    Optional[string] `json:"image"`
+	Bio      Optional[string] `json:"bio"`
+}
+
+// Validate returns an error message by member for the members that cannot be applied
+func (r *PatchUserRequest) Validate() map[string]string {
+	errs := make(map[string]string)
+	p := r.User
+	notNullable(errs, map[string]bool{
+		"email":    p.Email.Null,
+		"username": p.Username.Null,
+		"password": p.Password.Null,
+	})
+	for name, member := range map[string]Optional[string]{"email": p.Email, "username": p.Username, "password": p.Password} {
+		if member.Set && !member.Null && member.Value == "" {
+			errs[name] = "cannot be empty"
+		}
+	}
+	return errs
+}
+
 type UserResponse struct {
 	User struct {
 		ID       int64  `json:"id"`
@@ -2,5 +2,13 @@ package errors
 
 // MySQL error codes
 const (
-	MySQLErrDuplicateEntry uint16 = 1062
+	MySQLErrDuplicateEntry  uint16 = 1062
+	MySQLErrRowIsReferenced uint16 = 1451
+	MySQLErrNoReferencedRow uint16 = 1452
+)
+
+// PostgreSQL SQLSTATE codes
+const (
+	PostgresErrUniqueViolation     = "23505"
+	PostgresErrForeignKeyViolation = "23503"
 )
@@ -0,0 +1,53 @@
+package errors
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+// sqlStateError is implemented by driver errors exposing a SQLSTATE code (e.g. pgconn.PgError)
+type sqlStateError interface {
+	SQLState() string
+}
+
+// IsUniqueViolation reports whether err is a unique constraint violation on any supported driver.
+// Connections opened with TranslateError already report gorm.ErrDuplicatedKey;
+// raw driver errors are classified by their native codes.
+func IsUniqueViolation(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == MySQLErrDuplicateEntry
+	}
+
+	var stateErr sqlStateError
+	if errors.As(err, &stateErr) {
+		return stateErr.SQLState() == PostgresErrUniqueViolation
+	}
+
+	return false
+}
+
+// IsForeignKeyViolation reports whether err is a foreign key constraint violation on any supported driver
+func IsForeignKeyViolation(err error) bool {
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return true
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == MySQLErrRowIsReferenced || mysqlErr.Number == MySQLErrNoReferencedRow
+	}
+
+	var stateErr sqlStateError
+	if errors.As(err, &stateErr) {
+		return stateErr.SQLState() == PostgresErrForeignKeyViolation
+	}
+
+	return false
+}
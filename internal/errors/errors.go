@@ -2,6 +2,7 @@ package errors
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -19,8 +20,38 @@ var (
 	ErrInvalidAuthHeader       = errors.New("invalid authorization header format")
 	ErrInvalidToken            = errors.New("invalid or expired token")
 	ErrUnexpectedSigningMethod = errors.New("unexpected signing method")
+	ErrInvalidCursor           = errors.New("invalid pagination cursor")
+	ErrCursorWithSort          = errors.New("pagination cursors require the newest-first sort")
+	ErrSlugConflict            = errors.New("could not allocate a unique slug")
+	ErrEmptySearchQuery        = errors.New("search query has no words")
+	ErrEmptyTagName            = errors.New("tag name is empty")
+	ErrTagTooLong              = errors.New("tag name is too long")
+	ErrTooManyTags             = errors.New("too many tags")
+	ErrTagExists               = errors.New("a tag with this name already exists")
+	ErrMergeTagIntoItself      = errors.New("cannot merge a tag into itself")
+	ErrInvalidPublishedAt      = errors.New("invalid publication time")
+	ErrInvalidDateRange        = errors.New("must be later than createdAfter")
 )
 
+// SlugMovedError is returned when an article is looked up by a slug it used before a title change
+type SlugMovedError struct {
+	Slug string // current (canonical) slug of the article
+}
+
+func (e *SlugMovedError) Error() string {
+	return "article moved to " + e.Slug
+}
+
+// VersionConflictError is returned when an article update is based on a version of the
+// article that is no longer current
+type VersionConflictError struct {
+	Version int64 // current version of the article
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("article is at version %d", e.Version)
+}
+
 // Error response
 type APIErrorResponse struct {
 	Code    int         `json:"code"`
@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"go-gin-realworld-api/internal/dtos"
 	appErrors "go-gin-realworld-api/internal/errors"
 	"go-gin-realworld-api/internal/services"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -36,11 +38,21 @@ func (h *ArticleHandler) ListArticles(c *gin.Context) {
 	// Get articles from service
 	response, err := h.articleService.ListArticles(c.Request.Context(), &query, currentUserID)
 	if err != nil {
-		appErrors.RespondError(c, http.StatusInternalServerError, "failed to fetch articles")
+		switch err {
+		case appErrors.ErrInvalidCursor:
+			appErrors.RespondError(c, http.StatusBadRequest, "invalid cursor")
+		case appErrors.ErrCursorWithSort:
+			appErrors.RespondError(c, http.StatusBadRequest, "cursor pagination is only available with sort=newest; use offset")
+		case appErrors.ErrInvalidDateRange:
+			appErrors.RespondError(c, http.StatusBadRequest, "Validation failed", map[string]string{"createdBefore": err.Error()})
+		default:
+			appErrors.RespondError(c, http.StatusInternalServerError, "failed to fetch articles")
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	varyByUser(c)
+	respondConditional(c, response, currentUserID != nil)
 }
 
 // FeedArticles handles getting feed of articles from followed users
@@ -53,19 +65,74 @@ func (h *ArticleHandler) FeedArticles(c *gin.Context) {
 	}
 
 	// Parse pagination parameters
-	type FeedQuery struct {
-		Limit  int `form:"limit,default=20"`
-		Offset int `form:"offset,default=0"`
+	var query dtos.FeedArticlesQuery
+	if appErrors.HandleBindError(c, c.ShouldBindQuery(&query)) {
+		return
+	}
+
+	response, err := h.articleService.GetFeedArticles(c.Request.Context(), userID.(int64), &query)
+	if err != nil {
+		switch err {
+		case appErrors.ErrInvalidCursor:
+			appErrors.RespondError(c, http.StatusBadRequest, "invalid cursor")
+		case appErrors.ErrCursorWithSort:
+			appErrors.RespondError(c, http.StatusBadRequest, "cursor pagination is only available with sort=newest; use offset")
+		case appErrors.ErrInvalidDateRange:
+			appErrors.RespondError(c, http.StatusBadRequest, "Validation failed", map[string]string{"createdBefore": err.Error()})
+		default:
+			appErrors.RespondError(c, http.StatusInternalServerError, "failed to fetch feed")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListDrafts handles listing the current user's unpublished articles
+func (h *ArticleHandler) ListDrafts(c *gin.Context) {
+	// Get current user ID (required)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		appErrors.RespondError(c, http.StatusUnauthorized, "authentication required")
+		return
 	}
 
-	var query FeedQuery
+	var query dtos.ListDraftsQuery
 	if appErrors.HandleBindError(c, c.ShouldBindQuery(&query)) {
 		return
 	}
 
-	response, err := h.articleService.GetFeedArticles(c.Request.Context(), userID.(int64), query.Limit, query.Offset)
+	response, err := h.articleService.ListDrafts(c.Request.Context(), userID.(int64), &query)
 	if err != nil {
-		appErrors.RespondError(c, http.StatusInternalServerError, "failed to fetch feed")
+		appErrors.RespondError(c, http.StatusInternalServerError, "failed to fetch drafts")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SearchArticles handles full-text search over articles
+func (h *ArticleHandler) SearchArticles(c *gin.Context) {
+	var query dtos.SearchArticlesQuery
+	if appErrors.HandleBindError(c, c.ShouldBindQuery(&query)) {
+		return
+	}
+
+	// Get current user ID if authenticated
+	var currentUserID *int64
+	if userID, exists := c.Get("user_id"); exists {
+		id := userID.(int64)
+		currentUserID = &id
+	}
+
+	response, err := h.articleService.SearchArticles(c.Request.Context(), &query, currentUserID)
+	if err != nil {
+		switch err {
+		case appErrors.ErrEmptySearchQuery:
+			appErrors.RespondError(c, http.StatusBadRequest, "search query must contain at least one word")
+		default:
+			appErrors.RespondError(c, http.StatusInternalServerError, "failed to search articles")
+		}
 		return
 	}
 
@@ -85,11 +152,15 @@ func (h *ArticleHandler) GetArticle(c *gin.Context) {
 
 	article, err := h.articleService.GetArticleBySlug(c.Request.Context(), slug, currentUserID)
 	if err != nil {
+		if redirectMovedSlug(c, err) {
+			return
+		}
 		appErrors.RespondError(c, http.StatusNotFound, "article not found")
 		return
 	}
 
-	c.JSON(http.StatusOK, article)
+	varyByUser(c)
+	respondConditional(c, article, currentUserID != nil)
 }
 
 // CreateArticle handles creating a new article
@@ -109,7 +180,15 @@ func (h *ArticleHandler) CreateArticle(c *gin.Context) {
 
 	article, err := h.articleService.CreateArticle(c.Request.Context(), &req, userID.(int64))
 	if err != nil {
-		appErrors.RespondError(c, http.StatusInternalServerError, "failed to create article")
+		if respondInvalidArticle(c, err) {
+			return
+		}
+		switch err {
+		case appErrors.ErrSlugConflict:
+			appErrors.RespondError(c, http.StatusConflict, "an article with a similar title already exists, please choose another title")
+		default:
+			appErrors.RespondError(c, http.StatusInternalServerError, "failed to create article")
+		}
 		return
 	}
 
@@ -133,9 +212,50 @@ func (h *ArticleHandler) UpdateArticle(c *gin.Context) {
 		return
 	}
 
-	article, err := h.articleService.UpdateArticle(c.Request.Context(), slug, &req, userID.(int64))
+	article, err := h.articleService.UpdateArticle(c.Request.Context(), slug, &req, userID.(int64), parseIfMatch(c.GetHeader("If-Match")))
+	respondArticleUpdate(c, article, err)
+}
+
+// PatchArticle handles a JSON Merge Patch of an article
+func (h *ArticleHandler) PatchArticle(c *gin.Context) {
+	slug := c.Param("slug")
+
+	// Get current user ID (required)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		appErrors.RespondError(c, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	var req dtos.PatchArticleRequest
+
+	if bindMergePatch(c, &req) {
+		return
+	}
+
+	article, err := h.articleService.PatchArticle(c.Request.Context(), slug, &req, userID.(int64), parseIfMatch(c.GetHeader("If-Match")))
+	respondArticleUpdate(c, article, err)
+}
+
+// respondArticleUpdate responds with the result of an article update
+func respondArticleUpdate(c *gin.Context, article *dtos.ArticleDetailResponse, err error) {
 	if err != nil {
-		appErrors.RespondError(c, http.StatusNotFound, "article not found")
+		var conflict *appErrors.VersionConflictError
+		if errors.As(err, &conflict) {
+			appErrors.RespondError(c, http.StatusPreconditionFailed, "article has been changed since it was read", gin.H{"version": conflict.Version})
+			return
+		}
+		if respondInvalidArticle(c, err) {
+			return
+		}
+		switch err {
+		case appErrors.ErrSlugConflict:
+			appErrors.RespondError(c, http.StatusConflict, "an article with a similar title already exists, please choose another title")
+		case appErrors.ErrForbidden:
+			appErrors.RespondError(c, http.StatusForbidden, "you can only edit your own articles")
+		default:
+			appErrors.RespondError(c, http.StatusNotFound, "article not found")
+		}
 		return
 	}
 
@@ -147,16 +267,62 @@ func (h *ArticleHandler) DeleteArticle(c *gin.Context) {
 	slug := c.Param("slug")
 
 	// Get current user ID (required)
-	_, exists := c.Get("user_id")
+	userID, exists := c.Get("user_id")
 	if !exists {
 		appErrors.RespondError(c, http.StatusUnauthorized, "authentication required")
 		return
 	}
 
-	if err := h.articleService.DeleteArticle(c.Request.Context(), slug); err != nil {
-		appErrors.RespondError(c, http.StatusNotFound, "article not found")
+	if err := h.articleService.DeleteArticle(c.Request.Context(), slug, userID.(int64)); err != nil {
+		switch err {
+		case appErrors.ErrForbidden:
+			appErrors.RespondError(c, http.StatusForbidden, "you can only delete your own articles")
+		default:
+			appErrors.RespondError(c, http.StatusNotFound, "article not found")
+		}
 		return
 	}
 
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// respondInvalidArticle responds with a validation error when err is about a tag list that
+// breaks the tag limits or a publication time that does not fit the status, and reports
+// whether it did
+func respondInvalidArticle(c *gin.Context, err error) bool {
+	var field string
+	switch {
+	case errors.Is(err, appErrors.ErrTagTooLong), errors.Is(err, appErrors.ErrTooManyTags):
+		field = "tagList"
+	case errors.Is(err, appErrors.ErrInvalidPublishedAt):
+		field = "publishedAt"
+	default:
+		return false
+	}
+	appErrors.RespondError(c, http.StatusBadRequest, "Validation failed", map[string]string{field: err.Error()})
+	return true
+}
+
+// redirectMovedSlug redirects to the same route under the canonical slug when err is a
+// *appErrors.SlugMovedError, and reports whether it did. GET requests get a 301; other
+// methods get a 308 so that clients repeat the same method and body.
+func redirectMovedSlug(c *gin.Context, err error) bool {
+	var moved *appErrors.SlugMovedError
+	if !errors.As(err, &moved) {
+		return false
+	}
+
+	location := strings.Replace(c.Request.URL.Path, "/articles/"+c.Param("slug"), "/articles/"+moved.Slug, 1)
+	if c.Request.URL.RawQuery != "" {
+		location += "?" + c.Request.URL.RawQuery
+	}
+
+	status := http.StatusMovedPermanently
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		status = http.StatusPermanentRedirect
+	}
+
+	c.Header("Location", location)
+	c.JSON(status, dtos.ArticleMovedResponse{Slug: moved.Slug})
+	return true
+}
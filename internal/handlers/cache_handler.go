@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go-gin-realworld-api/internal/cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheStatsSource reports the statistics of a set of caches by name
+type CacheStatsSource interface {
+	Stats() map[string]cache.Stats
+}
+
+type CacheHandler struct {
+	source CacheStatsSource // nil when caching is disabled
+}
+
+func NewCacheHandler(source CacheStatsSource) *CacheHandler {
+	return &CacheHandler{
+		source: source,
+	}
+}
+
+// GetCacheStats handles the request for the hit/miss statistics of the in-process caches
+func (h *CacheHandler) GetCacheStats(c *gin.Context) {
+	if h.source == nil {
+		c.JSON(http.StatusOK, gin.H{"enabled": false})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"enabled": true, "caches": h.source.Stats()})
+}
@@ -37,6 +37,9 @@ func (h *CommentHandler) CreateComment(c *gin.Context) {
 
 	comment, err := h.commentService.CreateComment(c.Request.Context(), &req, slug, userID.(int64))
 	if err != nil {
+		if redirectMovedSlug(c, err) {
+			return
+		}
 		switch err {
 		case appErrors.ErrNotFound:
 			appErrors.RespondError(c, http.StatusNotFound, "article not found")
@@ -53,8 +56,18 @@ func (h *CommentHandler) CreateComment(c *gin.Context) {
 func (h *CommentHandler) GetComments(c *gin.Context) {
 	slug := c.Param("slug")
 
-	comments, err := h.commentService.GetCommentsByArticleSlug(c.Request.Context(), slug)
+	// Get current user ID if authenticated; only the author sees an unpublished article
+	var currentUserID *int64
+	if userID, exists := c.Get("user_id"); exists {
+		id := userID.(int64)
+		currentUserID = &id
+	}
+
+	comments, err := h.commentService.GetCommentsByArticleSlug(c.Request.Context(), slug, currentUserID)
 	if err != nil {
+		if redirectMovedSlug(c, err) {
+			return
+		}
 		switch err {
 		case appErrors.ErrNotFound:
 			appErrors.RespondError(c, http.StatusNotFound, "article not found")
@@ -64,7 +77,8 @@ func (h *CommentHandler) GetComments(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, comments)
+	varyByUser(c)
+	respondConditional(c, comments, currentUserID != nil)
 }
 
 // DeleteComment handles deleting a comment
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	appErrors "go-gin-realworld-api/internal/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondConditional writes body as JSON with a strong ETag, a hash of the body, so the tag
+// changes whenever anything in the body does, counts, deletions and the viewer's favorited
+// and following included. A client whose copy is current gets 304 Not Modified instead.
+// Responses carry no Last-Modified, as no timestamp advances when a row leaves a collection
+// or a favorite count changes. Clients must revalidate every time (no-cache). A
+// personalized body, such as one with favorited or following for the current user, is
+// private, so shared caches never store it.
+func respondConditional(c *gin.Context, body any, personalized bool) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		appErrors.RespondError(c, http.StatusInternalServerError, "failed to encode response")
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Header("ETag", etag)
+	if personalized {
+		c.Header("Cache-Control", "private, no-cache")
+	} else {
+		c.Header("Cache-Control", "public, no-cache")
+	}
+
+	if notModified(c.Request, etag) {
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
+
+// varyByUser marks a response of a route with optional authentication as depending on the
+// Authorization header, so that caches keep the anonymous and authenticated bodies apart
+func varyByUser(c *gin.Context) {
+	c.Header("Vary", "Authorization")
+}
+
+// notModified reports whether an If-None-Match of the request lists the current ETag
+func notModified(r *http.Request, etag string) bool {
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIfMatch returns the article versions listed in an If-Match header, whose entity tags
+// are the version field of the article quoted, such as "3", rather than the ETag of a GET,
+// which changes with favorite counts that do not conflict with an edit. It returns nil when the header is absent or "*", both of which
+// any existing article matches, and an empty list when no tag is a version. Weak tags never
+// match, as If-Match uses the strong comparison.
+func parseIfMatch(header string) []int64 {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return nil
+	}
+
+	versions := []int64{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if strings.HasPrefix(tag, "W/") {
+			continue
+		}
+		if version, err := strconv.ParseInt(strings.Trim(tag, `"`), 10, 64); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
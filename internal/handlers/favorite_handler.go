@@ -35,6 +35,9 @@ func (h *FavoriteHandler) FavoriteArticle(c *gin.Context) {
 	// Call service to favorite article
 	result, err := h.favoriteService.FavoriteArticle(c.Request.Context(), slug, currentUserID)
 	if err != nil {
+		if redirectMovedSlug(c, err) {
+			return
+		}
 		switch err {
 		case appErrors.ErrNotFound:
 			appErrors.RespondError(c, http.StatusNotFound, "article not found")
@@ -64,6 +67,9 @@ func (h *FavoriteHandler) UnfavoriteArticle(c *gin.Context) {
 	// Call service to unfavorite article
 	result, err := h.favoriteService.UnfavoriteArticle(c.Request.Context(), slug, currentUserID)
 	if err != nil {
+		if redirectMovedSlug(c, err) {
+			return
+		}
 		switch err {
 		case appErrors.ErrNotFound:
 			appErrors.RespondError(c, http.StatusNotFound, "article not found")
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	appErrors "go-gin-realworld-api/internal/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// mergePatchContentType is the media type of JSON Merge Patch (RFC 7386) bodies
+const mergePatchContentType = "application/merge-patch+json"
+
+// mergePatch is a patch body that can tell which of its members cannot be applied
+type mergePatch interface {
+	Validate() map[string]string
+}
+
+// bindMergePatch binds and validates a JSON Merge Patch body, sent as
+// application/merge-patch+json or application/json, and reports whether it responded
+// with an error instead
+func bindMergePatch(c *gin.Context, req mergePatch) bool {
+	switch c.ContentType() {
+	case mergePatchContentType, binding.MIMEJSON:
+	default:
+		appErrors.RespondError(c, http.StatusUnsupportedMediaType, "request body must be "+mergePatchContentType+" or application/json")
+		return true
+	}
+
+	if appErrors.HandleBindError(c, c.ShouldBindWith(req, binding.JSON)) {
+		return true
+	}
+	if errs := req.Validate(); len(errs) > 0 {
+		appErrors.RespondError(c, http.StatusBadRequest, "Validation failed", errs)
+		return true
+	}
+	return false
+}
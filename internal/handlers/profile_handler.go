@@ -35,7 +35,9 @@ func (h *ProfileHandler) GetProfile(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, profile)
+	// Profiles have no modification time, so only the ETag applies
+	varyByUser(c)
+	respondConditional(c, profile, currentUserID != 0)
 }
 
 // FollowUser handles following a user
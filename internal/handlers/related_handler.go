@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"go-gin-realworld-api/internal/dtos"
+	appErrors "go-gin-realworld-api/internal/errors"
+	"go-gin-realworld-api/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RelatedHandler struct {
+	relatedService *services.RelatedService
+}
+
+func NewRelatedHandler(relatedService *services.RelatedService) *RelatedHandler {
+	return &RelatedHandler{
+		relatedService: relatedService,
+	}
+}
+
+// RelatedArticles handles listing the articles sharing tags or favoriting users with an article
+func (h *RelatedHandler) RelatedArticles(c *gin.Context) {
+	slug := c.Param("slug")
+	var query dtos.RelatedArticlesQuery
+
+	if appErrors.HandleBindError(c, c.ShouldBindQuery(&query)) {
+		return
+	}
+
+	// Get current user ID if authenticated
+	var currentUserID *int64
+	if userID, exists := c.Get("user_id"); exists {
+		id := userID.(int64)
+		currentUserID = &id
+	}
+
+	response, err := h.relatedService.RelatedArticles(c.Request.Context(), slug, &query, currentUserID)
+	if err != nil {
+		if redirectMovedSlug(c, err) {
+			return
+		}
+		switch err {
+		case appErrors.ErrNotFound:
+			appErrors.RespondError(c, http.StatusNotFound, "article not found")
+		default:
+			appErrors.RespondError(c, http.StatusInternalServerError, "failed to fetch related articles")
+		}
+		return
+	}
+
+	varyByUser(c)
+	respondConditional(c, response, currentUserID != nil)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"go-gin-realworld-api/internal/dtos"
 	appErrors "go-gin-realworld-api/internal/errors"
 	"go-gin-realworld-api/internal/services"
 	"net/http"
@@ -18,12 +19,55 @@ func NewTagHandler(tagService *services.TagService) *TagHandler {
 	}
 }
 
-// GetTags handles the request to get all unique tags
+// GetTags handles the request to get all unique tags, optionally with their article
+// counts, sorted by popularity or limited
 func (h *TagHandler) GetTags(c *gin.Context) {
-	tags, err := h.tagService.GetAllTags(c.Request.Context())
+	var query dtos.ListTagsQuery
+
+	if appErrors.HandleBindError(c, c.ShouldBindQuery(&query)) {
+		return
+	}
+
+	// The plain tag list comes from the cache
+	if !query.WithCounts && query.Sort == "" && query.Limit == 0 {
+		tags, err := h.tagService.GetAllTags(c.Request.Context())
+		if err != nil {
+			appErrors.RespondError(c, http.StatusInternalServerError, "failed to retrieve tags")
+			return
+		}
+		respondConditional(c, gin.H{"tags": tags}, false)
+		return
+	}
+
+	counts, err := h.tagService.ListTagCounts(c.Request.Context(), query.Sort, query.Limit)
+	if err != nil {
+		appErrors.RespondError(c, http.StatusInternalServerError, "failed to retrieve tags")
+		return
+	}
+	if query.WithCounts {
+		respondConditional(c, dtos.TagCountsResponse{Tags: counts}, false)
+		return
+	}
+
+	tags := make([]string, 0, len(counts))
+	for _, count := range counts {
+		tags = append(tags, count.Name)
+	}
+	respondConditional(c, gin.H{"tags": tags}, false)
+}
+
+// SuggestTags handles the type-ahead request for the most used tags that start with a prefix
+func (h *TagHandler) SuggestTags(c *gin.Context) {
+	query := dtos.SuggestTagsQuery{Limit: 10}
+
+	if appErrors.HandleBindError(c, c.ShouldBindQuery(&query)) {
+		return
+	}
+
+	tags, err := h.tagService.SuggestTags(c.Request.Context(), query.Prefix, query.Limit)
 	if err != nil {
 		appErrors.RespondError(c, http.StatusInternalServerError, "failed to retrieve tags")
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"tags": tags})
+	respondConditional(c, gin.H{"tags": tags}, false)
 }
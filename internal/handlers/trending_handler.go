@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"go-gin-realworld-api/internal/dtos"
+	appErrors "go-gin-realworld-api/internal/errors"
+	"go-gin-realworld-api/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrendingHandler struct {
+	trendingService *services.TrendingService
+}
+
+func NewTrendingHandler(trendingService *services.TrendingService) *TrendingHandler {
+	return &TrendingHandler{
+		trendingService: trendingService,
+	}
+}
+
+// TrendingArticles handles listing the articles with the most recent favorites and comments
+func (h *TrendingHandler) TrendingArticles(c *gin.Context) {
+	var query dtos.TrendingArticlesQuery
+
+	if appErrors.HandleBindError(c, c.ShouldBindQuery(&query)) {
+		return
+	}
+
+	// Get current user ID if authenticated
+	var currentUserID *int64
+	if userID, exists := c.Get("user_id"); exists {
+		id := userID.(int64)
+		currentUserID = &id
+	}
+
+	response, err := h.trendingService.TrendingArticles(c.Request.Context(), &query, currentUserID)
+	if err != nil {
+		appErrors.RespondError(c, http.StatusInternalServerError, "failed to fetch trending articles")
+		return
+	}
+
+	varyByUser(c)
+	respondConditional(c, response, currentUserID != nil)
+}
@@ -105,3 +105,39 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// PatchUser handles a JSON Merge Patch of the current user
+func (h *UserHandler) PatchUser(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		appErrors.RespondError(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	var req dtos.PatchUserRequest
+
+	if bindMergePatch(c, &req) {
+		return
+	}
+
+	user, err := h.userService.PatchUser(c.Request.Context(), userID.(int64), &req)
+	if err != nil {
+		switch err {
+		case appErrors.ErrUserAlreadyExists:
+			appErrors.RespondError(c, http.StatusConflict, "username or email is already taken")
+		default:
+			appErrors.RespondError(c, http.StatusInternalServerError, "failed to update user")
+		}
+		return
+	}
+
+	// Return the stored values, which the patch may have cleared
+	resp := dtos.UpdateUserResponse{}
+	resp.User.ID = user.ID
+	resp.User.Username = user.Username
+	resp.User.Email = user.Email
+	resp.User.Image = user.Profile.Image.String
+	resp.User.Bio = user.Profile.Bio.String
+
+	c.JSON(http.StatusOK, resp)
+}
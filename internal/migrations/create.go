@@ -0,0 +1,60 @@
+package migrations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var nonWordChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Create writes an empty up/down pair with the next version number into
+// every driver directory under dir (e.g. internal/migrations/sql) and
+// returns the paths of the created files.
+func Create(dir, name string) ([]string, error) {
+	name = strings.Trim(nonWordChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, fmt.Errorf("%w: migration name is empty", ErrInvalidMigration)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// The version must be free for every driver, so take the highest across all of them
+	var drivers []string
+	var latest int64
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		drivers = append(drivers, entry.Name())
+
+		migrations, err := load(os.DirFS(dir), entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if n := len(migrations); n > 0 && migrations[n-1].Version > latest {
+			latest = migrations[n-1].Version
+		}
+	}
+	if len(drivers) == 0 {
+		return nil, fmt.Errorf("no driver directories in %s", dir)
+	}
+
+	var created []string
+	for _, driver := range drivers {
+		for _, direction := range []string{"up", "down"} {
+			fileName := filepath.Join(dir, driver, fmt.Sprintf("%04d_%s.%s.sql", latest+1, name, direction))
+			content := fmt.Sprintf("-- %s (%s)\n", name, direction)
+			if err := os.WriteFile(fileName, []byte(content), 0o644); err != nil {
+				return created, err
+			}
+			created = append(created, fileName)
+		}
+	}
+	return created, nil
+}
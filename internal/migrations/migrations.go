@@ -0,0 +1,432 @@
+package migrations
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	appErrors "go-gin-realworld-api/internal/errors"
+
+	"gorm.io/gorm"
+)
+
+// Migration files live in sql/<driver>/<version>_<name>.(up|down).sql
+//
+//go:embed sql
+var files embed.FS
+
+var (
+	ErrSchemaBehind     = errors.New("database schema is behind")
+	ErrUnknownDriver    = errors.New("no migrations for database driver")
+	ErrLockTimeout      = errors.New("timed out waiting for the migration lock")
+	ErrInvalidMigration = errors.New("invalid migration file")
+)
+
+// lockRowID is the single row of schema_migrations_lock; holding it means holding the lock
+const lockRowID = 1
+
+// Migration is one numbered schema change with its up and down SQL
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status describes whether a migration has been applied
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+// schemaMigration records an applied migration
+type schemaMigration struct {
+	Version   int64     `gorm:"column:version;primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"column:name;type:varchar(255);not null"`
+	AppliedAt time.Time `gorm:"column:applied_at;type:timestamp;not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// schemaMigrationLock is a single-row table; inserting the row acquires the lock.
+// A unique-key violation means another process holds it.
+type schemaMigrationLock struct {
+	ID       int64     `gorm:"column:id;primaryKey;autoIncrement:false"`
+	LockedAt time.Time `gorm:"column:locked_at;type:timestamp;not null"`
+	LockedBy string    `gorm:"column:locked_by;type:varchar(255);not null"`
+}
+
+func (schemaMigrationLock) TableName() string {
+	return "schema_migrations_lock"
+}
+
+type Migrator struct {
+	db          *gorm.DB
+	migrations  []Migration
+	LockTimeout time.Duration
+	// LockExpiry is how old a lock must be for another process to break it. The holder
+	// refreshes the lock while it migrates, so only the lock of a process that died expires.
+	// Zero keeps locks until they are released or removed with Unlock.
+	LockExpiry time.Duration
+}
+
+// New creates a migrator with the embedded migrations for the database driver
+func New(db *gorm.DB, driver string) (*Migrator, error) {
+	migrations, err := load(files, path.Join("sql", driver))
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:          db,
+		migrations:  migrations,
+		LockTimeout: time.Minute,
+		LockExpiry:  5 * time.Minute,
+	}, nil
+}
+
+// Up applies pending migrations in version order; steps <= 0 applies all of them. Each
+// migration runs in a transaction, but MySQL commits DDL statements implicitly, so a MySQL
+// migration that fails partway keeps the statements before the failure while it is not
+// recorded as applied; they must be undone by hand before running it again.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		done, err := m.appliedVersions(db)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if steps > 0 && len(applied) == steps {
+				break
+			}
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			if err := db.Transaction(func(tx *gorm.DB) error {
+				if err := execScript(tx, migration.Up); err != nil {
+					return err
+				}
+				return tx.Create(&schemaMigration{
+					Version:   migration.Version,
+					Name:      migration.Name,
+					AppliedAt: time.Now(),
+				}).Error
+			}); err != nil {
+				return fmt.Errorf("migration %04d_%s up: %w", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rolls back the most recently applied migrations; steps <= 0 rolls back one
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		steps = 1
+	}
+
+	var reverted []Migration
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		done, err := m.appliedVersions(db)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+
+			if err := db.Transaction(func(tx *gorm.DB) error {
+				if err := execScript(tx, migration.Down); err != nil {
+					return err
+				}
+				return tx.Where("version = ?", migration.Version).Delete(&schemaMigration{}).Error
+			}); err != nil {
+				return fmt.Errorf("migration %04d_%s down: %w", migration.Version, migration.Name, err)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lists every known migration with its applied time, if any
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	db := m.db.WithContext(ctx)
+	if err := m.ensureTables(db); err != nil {
+		return nil, err
+	}
+
+	done, err := m.appliedVersions(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if record, ok := done[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// CheckUpToDate returns ErrSchemaBehind when migrations are pending
+func (m *Migrator) CheckUpToDate(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%w: %d pending migration(s)", ErrSchemaBehind, pending)
+	}
+	return nil
+}
+
+// withLock runs fn while holding the schema_migrations_lock row, waiting up to LockTimeout for it.
+// A lock older than LockExpiry is left by a process that died, and is broken.
+func (m *Migrator) withLock(ctx context.Context, fn func(db *gorm.DB) error) error {
+	db := m.db.WithContext(ctx)
+	if err := m.ensureTables(db); err != nil {
+		return err
+	}
+
+	owner, _ := os.Hostname()
+	owner = fmt.Sprintf("%s:%d", owner, os.Getpid())
+
+	deadline := time.Now().Add(m.LockTimeout)
+	for {
+		err := db.Create(&schemaMigrationLock{ID: lockRowID, LockedAt: time.Now(), LockedBy: owner}).Error
+		if err == nil {
+			break
+		}
+		if !appErrors.IsUniqueViolation(err) {
+			return err
+		}
+
+		if m.LockExpiry > 0 {
+			expired := db.Where("id = ? AND locked_at < ?", lockRowID, time.Now().Add(-m.LockExpiry)).Delete(&schemaMigrationLock{})
+			if expired.Error != nil {
+				return expired.Error
+			}
+			if expired.RowsAffected > 0 {
+				continue
+			}
+		}
+		if time.Now().After(deadline) {
+			var holder schemaMigrationLock
+			db.First(&holder, lockRowID)
+			return fmt.Errorf("%w (held by %s since %s; if that process is gone, run `app migrate unlock`)", ErrLockTimeout, holder.LockedBy, holder.LockedAt.Format(time.RFC3339))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+
+	// Release with a fresh context so a cancelled run does not leave the lock behind, unless
+	// the lock expired and another process holds it now
+	defer m.db.Where("locked_by = ?", owner).Delete(&schemaMigrationLock{}, lockRowID)
+	if m.LockExpiry > 0 {
+		stop := make(chan struct{})
+		defer close(stop)
+		go m.refreshLock(owner, stop)
+	}
+	return fn(db)
+}
+
+// refreshLock keeps the lock of owner from expiring until stop is closed
+func (m *Migrator) refreshLock(owner string, stop <-chan struct{}) {
+	ticker := time.NewTicker(m.LockExpiry / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			m.db.Model(&schemaMigrationLock{}).
+				Where("id = ? AND locked_by = ?", lockRowID, owner).
+				Update("locked_at", time.Now())
+		}
+	}
+}
+
+// Unlock removes the migration lock, whoever holds it, and reports whether it was held. It is
+// meant for a lock left behind by a process that died; migrating while another process does
+// can corrupt the schema.
+func (m *Migrator) Unlock(ctx context.Context) (bool, error) {
+	db := m.db.WithContext(ctx)
+	if err := m.ensureTables(db); err != nil {
+		return false, err
+	}
+
+	result := db.Delete(&schemaMigrationLock{}, lockRowID)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ensureTables creates the bookkeeping tables if they are missing
+func (m *Migrator) ensureTables(db *gorm.DB) error {
+	for _, table := range []interface{}{&schemaMigration{}, &schemaMigrationLock{}} {
+		if db.Migrator().HasTable(table) {
+			continue
+		}
+		// Another process may create the table concurrently; only fail if it is still missing
+		if err := db.Migrator().CreateTable(table); err != nil && !db.Migrator().HasTable(table) {
+			return err
+		}
+	}
+	return nil
+}
+
+// appliedVersions returns the applied migrations keyed by version
+func (m *Migrator) appliedVersions(db *gorm.DB) (map[int64]schemaMigration, error) {
+	var records []schemaMigration
+	if err := db.Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	done := make(map[int64]schemaMigration, len(records))
+	for _, record := range records {
+		done[record.Version] = record
+	}
+	return done, nil
+}
+
+// execScript runs each statement of a migration file.
+// Statements are separated by a semicolon at the end of a line.
+func execScript(tx *gorm.DB, script string) error {
+	for _, statement := range splitStatements(script) {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
+
+// load reads and pairs the up/down files of a migration directory
+func load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownDriver, path.Base(dir))
+		}
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		version, name, direction, err := parseFileName(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if migration.Name != name {
+			return nil, fmt.Errorf("%w: version %d has names %q and %q", ErrInvalidMigration, version, migration.Name, name)
+		}
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" {
+			return nil, fmt.Errorf("%w: %04d_%s has no up script", ErrInvalidMigration, migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// parseFileName splits "0001_create_users.up.sql" into its version, name and direction
+func parseFileName(fileName string) (int64, string, string, error) {
+	base, ok := strings.CutSuffix(fileName, ".sql")
+	if !ok {
+		return 0, "", "", fmt.Errorf("%w: %s", ErrInvalidMigration, fileName)
+	}
+
+	direction := path.Ext(base)
+	if direction != ".up" && direction != ".down" {
+		return 0, "", "", fmt.Errorf("%w: %s", ErrInvalidMigration, fileName)
+	}
+	base = strings.TrimSuffix(base, direction)
+
+	versionPart, name, ok := strings.Cut(base, "_")
+	if !ok {
+		return 0, "", "", fmt.Errorf("%w: %s", ErrInvalidMigration, fileName)
+	}
+	version, err := strconv.ParseInt(versionPart, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, "", "", fmt.Errorf("%w: %s", ErrInvalidMigration, fileName)
+	}
+
+	return version, name, strings.TrimPrefix(direction, "."), nil
+}
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS article_tags;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS favorites;
+DROP TABLE IF EXISTS comments;
+DROP TABLE IF EXISTS articles;
+DROP TABLE IF EXISTS follows;
+DROP TABLE IF EXISTS profiles;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1 @@
+DROP INDEX idx_articles_created_at_id ON articles;
@@ -0,0 +1,2 @@
+-- Keyset pagination orders by (created_at, id)
+CREATE INDEX idx_articles_created_at_id ON articles (created_at, id);
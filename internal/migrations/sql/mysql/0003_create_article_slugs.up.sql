@@ -0,0 +1,13 @@
+-- Every slug an article has had, so old links can be redirected
+CREATE TABLE IF NOT EXISTS article_slugs (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  article_id BIGINT NOT NULL,
+  slug VARCHAR(500) NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE INDEX idx_article_slugs_slug (slug),
+  INDEX idx_article_slugs_article_id (article_id),
+  CONSTRAINT fk_articles_slugs FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE
+);
+
+INSERT INTO article_slugs (article_id, slug, created_at)
+SELECT id, slug, created_at FROM articles;
@@ -0,0 +1,2 @@
+-- Full-text search over title, description and body (GET /api/articles/search)
+CREATE FULLTEXT INDEX idx_articles_fulltext ON articles (title, description, body);
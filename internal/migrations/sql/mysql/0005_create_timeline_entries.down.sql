@@ -0,0 +1,2 @@
+ALTER TABLE articles DROP COLUMN fan_out_on_read;
+DROP TABLE IF EXISTS timeline_entries;
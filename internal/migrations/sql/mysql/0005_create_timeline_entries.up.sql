@@ -0,0 +1,21 @@
+-- Materialized personal feeds: one row per article for each follower of its author
+CREATE TABLE IF NOT EXISTS timeline_entries (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  article_id BIGINT NOT NULL,
+  author_id BIGINT NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE INDEX idx_timeline_entries (user_id, article_id),
+  INDEX idx_timeline_entries_user_created_at (user_id, created_at, article_id),
+  INDEX idx_timeline_entries_article_id (article_id),
+  CONSTRAINT fk_timeline_entries_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+  CONSTRAINT fk_articles_timeline_entries FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE
+);
+
+-- Articles by authors with too many followers are not copied to timelines but read through follows.
+-- Counting followers uses the index MySQL created for fk_follows_followee.
+ALTER TABLE articles ADD COLUMN fan_out_on_read BOOLEAN NOT NULL DEFAULT FALSE;
+
+INSERT INTO timeline_entries (user_id, article_id, author_id, created_at)
+SELECT follows.follower_id, articles.id, articles.author_id, articles.created_at
+FROM articles JOIN follows ON follows.followee_id = articles.author_id;
@@ -0,0 +1,2 @@
+DROP INDEX idx_articles_updated_at_id ON articles;
+DROP INDEX idx_articles_favorites_count_id ON articles;
@@ -0,0 +1,3 @@
+DROP INDEX idx_comments_created_at ON comments;
+DROP INDEX idx_favorites_created_at ON favorites;
+DROP TABLE IF EXISTS trending_articles;
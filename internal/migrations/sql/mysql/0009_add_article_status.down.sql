@@ -0,0 +1,3 @@
+DROP INDEX idx_articles_status_published_at ON articles;
+ALTER TABLE articles DROP COLUMN published_at;
+ALTER TABLE articles DROP COLUMN status;
@@ -0,0 +1,13 @@
+-- Every slug an article has had, so old links can be redirected
+CREATE TABLE IF NOT EXISTS article_slugs (
+  id BIGSERIAL PRIMARY KEY,
+  article_id BIGINT NOT NULL,
+  slug VARCHAR(500) NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_articles_slugs FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_article_slugs_slug ON article_slugs (slug);
+CREATE INDEX IF NOT EXISTS idx_article_slugs_article_id ON article_slugs (article_id);
+
+INSERT INTO article_slugs (article_id, slug, created_at)
+SELECT id, slug, created_at FROM articles;
@@ -0,0 +1,3 @@
+-- Full-text search over title, description and body (GET /api/articles/search).
+-- The expression must match searchDocument in postgres_article_search.go.
+CREATE INDEX IF NOT EXISTS idx_articles_fulltext ON articles USING GIN ((setweight(to_tsvector('english', title), 'A') || setweight(to_tsvector('english', description), 'B') || setweight(to_tsvector('english', body), 'C')));
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_follows_followee_id;
+ALTER TABLE articles DROP COLUMN IF EXISTS fan_out_on_read;
+DROP TABLE IF EXISTS timeline_entries;
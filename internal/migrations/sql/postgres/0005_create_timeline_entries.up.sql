@@ -0,0 +1,21 @@
+-- Materialized personal feeds: one row per article for each follower of its author
+CREATE TABLE IF NOT EXISTS timeline_entries (
+  id BIGSERIAL PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  article_id BIGINT NOT NULL,
+  author_id BIGINT NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_timeline_entries_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+  CONSTRAINT fk_articles_timeline_entries FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_timeline_entries ON timeline_entries (user_id, article_id);
+CREATE INDEX IF NOT EXISTS idx_timeline_entries_user_created_at ON timeline_entries (user_id, created_at, article_id);
+CREATE INDEX IF NOT EXISTS idx_timeline_entries_article_id ON timeline_entries (article_id);
+
+-- Articles by authors with too many followers are not copied to timelines but read through follows
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS fan_out_on_read BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX IF NOT EXISTS idx_follows_followee_id ON follows (followee_id);
+
+INSERT INTO timeline_entries (user_id, article_id, author_id, created_at)
+SELECT follows.follower_id, articles.id, articles.author_id, articles.created_at
+FROM articles JOIN follows ON follows.followee_id = articles.author_id;
@@ -0,0 +1,2 @@
+-- Incremented by every edit of an article; updates carry the version they read (optimistic locking)
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
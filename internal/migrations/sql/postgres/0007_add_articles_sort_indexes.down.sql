@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_articles_updated_at_id;
+DROP INDEX IF EXISTS idx_articles_favorites_count_id;
@@ -0,0 +1,15 @@
+-- Trending scores precomputed by the background worker, one ranking per window (span)
+CREATE TABLE IF NOT EXISTS trending_articles (
+  span VARCHAR(8) NOT NULL,
+  article_id BIGINT NOT NULL,
+  score DOUBLE PRECISION NOT NULL,
+  computed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (span, article_id),
+  CONSTRAINT fk_articles_trending_articles FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_trending_articles_span_score ON trending_articles (span, score, article_id);
+CREATE INDEX IF NOT EXISTS idx_trending_articles_article_id ON trending_articles (article_id);
+
+-- Scores are computed from the favorites and comments made within the window
+CREATE INDEX IF NOT EXISTS idx_favorites_created_at ON favorites (created_at);
+CREATE INDEX IF NOT EXISTS idx_comments_created_at ON comments (created_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_articles_status_published_at;
+ALTER TABLE articles DROP COLUMN IF EXISTS published_at;
+ALTER TABLE articles DROP COLUMN IF EXISTS status;
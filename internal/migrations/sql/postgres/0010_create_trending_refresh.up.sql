@@ -0,0 +1,7 @@
+-- When the trending rankings were last refreshed; a server's background worker claims a
+-- refresh by moving refreshed_at forward, so only one of the servers runs it
+CREATE TABLE IF NOT EXISTS trending_refresh (
+  id INTEGER NOT NULL PRIMARY KEY,
+  refreshed_at TIMESTAMP NOT NULL
+);
+INSERT INTO trending_refresh (id, refreshed_at) VALUES (1, '1970-01-02 00:00:00') ON CONFLICT (id) DO NOTHING;
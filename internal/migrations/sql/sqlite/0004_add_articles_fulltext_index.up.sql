@@ -0,0 +1,2 @@
+-- SQLite has no full-text index; search scans the articles table.
+-- This migration keeps version numbers aligned with the other drivers.
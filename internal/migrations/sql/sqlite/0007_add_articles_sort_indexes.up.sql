@@ -0,0 +1,3 @@
+-- Listing sorts: mostFavorited orders by (favorites_count, id), recentlyUpdated by (updated_at, id)
+CREATE INDEX IF NOT EXISTS idx_articles_favorites_count_id ON articles (favorites_count, id);
+CREATE INDEX IF NOT EXISTS idx_articles_updated_at_id ON articles (updated_at, id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_comments_created_at;
+DROP INDEX IF EXISTS idx_favorites_created_at;
+DROP TABLE IF EXISTS trending_articles;
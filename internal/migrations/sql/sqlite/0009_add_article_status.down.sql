@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_articles_status_published_at;
+ALTER TABLE articles DROP COLUMN published_at;
+ALTER TABLE articles DROP COLUMN status;
@@ -0,0 +1,6 @@
+-- Article lifecycle: draft, published, scheduled (published at published_at by the scheduler) or archived
+ALTER TABLE articles ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'published';
+ALTER TABLE articles ADD COLUMN published_at TIMESTAMP NULL;
+UPDATE articles SET published_at = created_at;
+-- The scheduler looks up the scheduled articles that are due
+CREATE INDEX IF NOT EXISTS idx_articles_status_published_at ON articles (status, published_at);
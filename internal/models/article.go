@@ -2,18 +2,32 @@ package models
 
 import "time"
 
+// Article statuses. Only published articles are listed, searched and counted; the others
+// are seen by their author alone.
+const (
+	ArticleStatusDraft     = "draft"
+	ArticleStatusPublished = "published"
+	ArticleStatusScheduled = "scheduled" // published by the scheduler at PublishedAt
+	ArticleStatusArchived  = "archived"
+)
+
 type Article struct {
-	ID             int64         `gorm:"column:id;primaryKey" json:"id"`
-	Slug           string        `gorm:"column:slug;type:varchar(500);uniqueIndex;not null" json:"slug"`
-	Title          string        `gorm:"column:title;type:varchar(500);not null" json:"title"`
-	Description    string        `gorm:"column:description;type:text;not null" json:"description"`
-	Body           string        `gorm:"column:body;type:text;not null" json:"body"`
-	AuthorID       int64         `gorm:"column:author_id;not null;index" json:"author_id"`
-	FavoritesCount int           `gorm:"column:favorites_count;default:0" json:"favorites_count"`
-	CreatedAt      time.Time     `gorm:"column:created_at;type:timestamp;autoCreateTime;not null" json:"created_at"`
-	UpdatedAt      time.Time     `gorm:"column:updated_at;type:timestamp;autoUpdateTime;not null" json:"updated_at"`
-	Author         *User         `gorm:"foreignKey:AuthorID;constraint:OnDelete:CASCADE" json:"-"`
-	Comments       []*Comment    `gorm:"foreignKey:ArticleID;constraint:OnDelete:CASCADE" json:"-"`
-	ArticleTags    []*ArticleTag `gorm:"foreignKey:ArticleID;constraint:OnDelete:CASCADE" json:"-"`
-	Favorites      []*Favorite   `gorm:"foreignKey:ArticleID;constraint:OnDelete:CASCADE" json:"-"`
+	ID             int64          `gorm:"column:id;primaryKey;index:idx_articles_created_at_id,priority:2;index:idx_articles_favorites_count_id,priority:2;index:idx_articles_updated_at_id,priority:2" json:"id"`
+	Slug           string         `gorm:"column:slug;type:varchar(500);uniqueIndex;not null" json:"slug"`
+	Title          string         `gorm:"column:title;type:varchar(500);not null" json:"title"`
+	Description    string         `gorm:"column:description;type:text;not null" json:"description"`
+	Body           string         `gorm:"column:body;type:text;not null" json:"body"`
+	AuthorID       int64          `gorm:"column:author_id;not null;index" json:"author_id"`
+	FavoritesCount int            `gorm:"column:favorites_count;default:0;index:idx_articles_favorites_count_id,priority:1" json:"favorites_count"`
+	FanOutOnRead   bool           `gorm:"column:fan_out_on_read;not null;default:false" json:"fan_out_on_read"`
+	Version        int64          `gorm:"column:version;not null;default:1" json:"version"`
+	Status         string         `gorm:"column:status;type:varchar(16);not null;default:'published';index:idx_articles_status_published_at,priority:1" json:"status"`
+	PublishedAt    *time.Time     `gorm:"column:published_at;type:timestamp;index:idx_articles_status_published_at,priority:2" json:"published_at"`
+	CreatedAt      time.Time      `gorm:"column:created_at;type:timestamp;autoCreateTime;not null;index:idx_articles_created_at_id,priority:1" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"column:updated_at;type:timestamp;autoUpdateTime;not null;index:idx_articles_updated_at_id,priority:1" json:"updated_at"`
+	Author         *User          `gorm:"foreignKey:AuthorID;constraint:OnDelete:CASCADE" json:"-"`
+	Comments       []*Comment     `gorm:"foreignKey:ArticleID;constraint:OnDelete:CASCADE" json:"-"`
+	ArticleTags    []*ArticleTag  `gorm:"foreignKey:ArticleID;constraint:OnDelete:CASCADE" json:"-"`
+	Favorites      []*Favorite    `gorm:"foreignKey:ArticleID;constraint:OnDelete:CASCADE" json:"-"`
+	Slugs          []*ArticleSlug `gorm:"foreignKey:ArticleID;constraint:OnDelete:CASCADE" json:"-"`
 }
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// ArticleSlug records every slug an article has had, including the current one.
+// The unique index keeps a slug from ever being reassigned to a different article.
+type ArticleSlug struct {
+	ID        int64     `gorm:"column:id;primaryKey" json:"id"`
+	ArticleID int64     `gorm:"column:article_id;not null;index" json:"article_id"`
+	Slug      string    `gorm:"column:slug;type:varchar(500);uniqueIndex;not null" json:"slug"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime;not null" json:"created_at"`
+	Article   *Article  `gorm:"foreignKey:ArticleID;constraint:OnDelete:CASCADE" json:"-"`
+}
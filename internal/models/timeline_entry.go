@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// TimelineEntry puts an article in the personal feed of a follower of its author.
+// CreatedAt is copied from the article so a feed page is read from one index.
+// Articles with FanOutOnRead set, by authors with too many followers to copy them
+// to every timeline, have no entries and are read through follows instead.
+type TimelineEntry struct {
+	ID        int64     `gorm:"column:id;primaryKey" json:"id"`
+	UserID    int64     `gorm:"column:user_id;not null;uniqueIndex:idx_timeline_entries,priority:1;index:idx_timeline_entries_user_created_at,priority:1" json:"user_id"`
+	ArticleID int64     `gorm:"column:article_id;not null;uniqueIndex:idx_timeline_entries,priority:2;index:idx_timeline_entries_user_created_at,priority:3;index" json:"article_id"`
+	AuthorID  int64     `gorm:"column:author_id;not null" json:"author_id"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;not null;index:idx_timeline_entries_user_created_at,priority:2" json:"created_at"`
+	User      *User     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Article   *Article  `gorm:"foreignKey:ArticleID;constraint:OnDelete:CASCADE" json:"-"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// TrendingArticle is the trending score of an article over a window such as "24h",
+// precomputed from its recent favorites and comments. Articles without activity in the
+// window have no row.
+type TrendingArticle struct {
+	Span       string    `gorm:"column:span;type:varchar(8);primaryKey;index:idx_trending_articles_span_score,priority:1" json:"span"`
+	ArticleID  int64     `gorm:"column:article_id;primaryKey;index:idx_trending_articles_span_score,priority:3;index" json:"article_id"`
+	Score      float64   `gorm:"column:score;not null;index:idx_trending_articles_span_score,priority:2" json:"score"`
+	ComputedAt time.Time `gorm:"column:computed_at;type:timestamp;not null" json:"computed_at"`
+	Article    *Article  `gorm:"foreignKey:ArticleID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TrendingRefresh is the single row recording when the trending rankings were last
+// refreshed by a server's background worker. A server refreshes only after claiming the
+// row, so servers sharing a database take turns instead of all refreshing.
+type TrendingRefresh struct {
+	ID          int64     `gorm:"column:id;primaryKey" json:"id"`
+	RefreshedAt time.Time `gorm:"column:refreshed_at;type:timestamp;not null" json:"refreshed_at"`
+}
+
+func (TrendingRefresh) TableName() string {
+	return "trending_refresh"
+}
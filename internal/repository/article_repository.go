@@ -1,17 +1,120 @@
 package repository
 
 import (
+	"errors"
+	"time"
+
 	"go-gin-realworld-api/internal/models"
 
 	"gorm.io/gorm"
 )
 
+// ErrVersionConflict is returned by UpdateArticle when the stored article no longer has
+// the version the update was based on, because another update came first
+var ErrVersionConflict = errors.New("article was changed by another update")
+
+// ArticleCursor is a keyset position in the newest-first (created_at DESC, id DESC) article order
+type ArticleCursor struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+// ArticleSort orders listed articles. Each order ends with the article ID, so it is stable.
+type ArticleSort string
+
+const (
+	ArticleSortNewest          ArticleSort = "newest"
+	ArticleSortOldest          ArticleSort = "oldest"
+	ArticleSortMostFavorited   ArticleSort = "mostFavorited"
+	ArticleSortMostCommented   ArticleSort = "mostCommented"
+	ArticleSortRecentlyUpdated ArticleSort = "recentlyUpdated"
+)
+
+// ArticlePage selects a page of articles in newest-first order, or in the order of Sort.
+// After returns the articles older than the cursor and Before the ones newer than it
+// (the Limit closest to the cursor, still newest first); without a cursor Offset is applied.
+// Cursors apply to the newest-first order only; other sorts page by Offset.
+// SkipCount skips the COUNT query, in which case the returned total is 0.
+type ArticlePage struct {
+	Limit     int
+	Offset    int
+	After     *ArticleCursor
+	Before    *ArticleCursor
+	SkipCount bool
+	Sort      ArticleSort
+}
+
+// TagMode says whether listed articles need all the tags of a filter or any of them
+type TagMode string
+
+const (
+	TagModeAll TagMode = "all"
+	TagModeAny TagMode = "any"
+)
+
+// ArticleFilter selects the articles to list; unset fields match every published article.
+// Tags need all or, with TagModeAny, any of the names; ExcludeTags none of them. Tag
+// names must be distinct.
+// Author is a username and AuthorID a user ID (0 for any author).
+// Favorited applies only when the caller knows the current user.
+// CreatedAfter is inclusive and CreatedBefore exclusive, so ranges can be chained.
+// Statuses lists articles with any of these statuses instead of the published ones.
+type ArticleFilter struct {
+	Tags          []string
+	TagMode       TagMode
+	ExcludeTags   []string
+	Author        string
+	AuthorID      int64
+	Favorited     *bool
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Statuses      []string
+}
+
+// ArticleSearch is a full-text query over article titles, descriptions and bodies.
+// Query is the raw text for engines with their own parser; Terms are its lowercased words.
+// Articles matching any term are returned, most relevant first.
+type ArticleSearch struct {
+	Query  string
+	Terms  []string
+	Tag    string
+	Author string
+	Limit  int
+	Offset int
+}
+
+// Filter returns the tag and author filter of a search
+func (s ArticleSearch) Filter() ArticleFilter {
+	filter := ArticleFilter{Author: s.Author}
+	if s.Tag != "" {
+		filter.Tags = []string{s.Tag}
+	}
+	return filter
+}
+
 type ArticleRepository interface {
-	ListArticles(db *gorm.DB, tag, author string, favorited *bool, currentUserID *int64, limit, offset int) ([]*models.Article, int64, error)
-	FeedArticles(db *gorm.DB, userID int64, limit, offset int) ([]*models.Article, int64, error)
+	// ListArticles returns a page of the articles that match filter and their total number,
+	// counting each article once however many of its tags match
+	ListArticles(db *gorm.DB, filter ArticleFilter, currentUserID *int64, page ArticlePage) ([]*models.Article, int64, error)
+	SearchArticles(db *gorm.DB, search ArticleSearch) ([]*models.Article, int64, error)
 	FindArticleBySlug(db *gorm.DB, slug string) (*models.Article, error)
+	// FindArticlesByIDs returns the articles that exist among ids, in no particular order
+	FindArticlesByIDs(db *gorm.DB, ids []int64) ([]*models.Article, error)
+	// FindCurrentSlug returns the current slug of the article that has used slug
+	FindCurrentSlug(db *gorm.DB, slug string) (string, error)
 	CreateArticle(db *gorm.DB, article *models.Article) error
+	// UpdateArticle saves an article if its stored version is still article.Version and
+	// then increments article.Version; otherwise it returns ErrVersionConflict
 	UpdateArticle(db *gorm.DB, article *models.Article) error
 	DeleteArticleBySlug(db *gorm.DB, slug string) error
+	// AssignTagsToArticle replaces the tags of an article; no tag names removes them all.
+	// The names must be normalized and distinct.
 	AssignTagsToArticle(db *gorm.DB, articleID int64, tagNames []string) error
+	// ListDueScheduled returns the IDs of up to limit scheduled articles whose publication
+	// time is not after now, the longest overdue first
+	ListDueScheduled(db *gorm.DB, now time.Time, limit int) ([]int64, error)
+	// PublishScheduled publishes a scheduled article, with its publication time as its
+	// creation time, and reports whether it did. It does nothing for an article that is no
+	// longer scheduled, for example because another server published it first.
+	PublishScheduled(db *gorm.DB, articleID int64) (bool, error)
 }
@@ -0,0 +1,100 @@
+package cached
+
+import (
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// CachedArticleRepository caches FindArticleBySlug. Methods it does not override go
+// straight to the wrapped repository.
+type CachedArticleRepository struct {
+	repository.ArticleRepository
+	caches *Caches
+}
+
+func NewCachedArticleRepository(inner repository.ArticleRepository, caches *Caches) *CachedArticleRepository {
+	return &CachedArticleRepository{ArticleRepository: inner, caches: caches}
+}
+
+// FindArticleBySlug finds an article by its current slug, from the cache when possible
+func (r *CachedArticleRepository) FindArticleBySlug(db *gorm.DB, slug string) (*models.Article, error) {
+	if inTransaction(db) {
+		return r.ArticleRepository.FindArticleBySlug(db, slug)
+	}
+	if article, ok := r.caches.articles.Get(slug); ok {
+		return copyArticle(article), nil
+	}
+
+	article, err := r.ArticleRepository.FindArticleBySlug(r.caches.fillDB(db), slug)
+	if err != nil {
+		return nil, err
+	}
+	r.caches.articles.Set(slug, copyArticle(article))
+	return article, nil
+}
+
+// CreateArticle creates an article
+func (r *CachedArticleRepository) CreateArticle(db *gorm.DB, article *models.Article) error {
+	if err := r.ArticleRepository.CreateArticle(db, article); err != nil {
+		return err
+	}
+	slug := article.Slug
+	afterCommit(db, func() { r.caches.articles.Remove(slug) })
+	return nil
+}
+
+// UpdateArticle updates an article, dropping it from the cache under its old and new slug.
+// A status change can add or remove the tags of the article from the tag list.
+func (r *CachedArticleRepository) UpdateArticle(db *gorm.DB, article *models.Article) error {
+	if err := r.ArticleRepository.UpdateArticle(db, article); err != nil {
+		return err
+	}
+	articleID, slug := article.ID, article.Slug
+	afterCommit(db, func() {
+		r.caches.invalidateArticle(articleID)
+		r.caches.articles.Remove(slug)
+		r.caches.tags.Remove(allTagsKey)
+	})
+	return nil
+}
+
+// DeleteArticleBySlug deletes an article by slug, which can leave its tags unused
+func (r *CachedArticleRepository) DeleteArticleBySlug(db *gorm.DB, slug string) error {
+	if err := r.ArticleRepository.DeleteArticleBySlug(db, slug); err != nil {
+		return err
+	}
+	afterCommit(db, func() {
+		r.caches.articles.Remove(slug)
+		r.caches.tags.Remove(allTagsKey)
+	})
+	return nil
+}
+
+// AssignTagsToArticle replaces the tags of an article, which can also create new tags
+func (r *CachedArticleRepository) AssignTagsToArticle(db *gorm.DB, articleID int64, tagNames []string) error {
+	if err := r.ArticleRepository.AssignTagsToArticle(db, articleID, tagNames); err != nil {
+		return err
+	}
+	afterCommit(db, func() {
+		r.caches.invalidateArticle(articleID)
+		r.caches.tags.Remove(allTagsKey)
+	})
+	return nil
+}
+
+// PublishScheduled publishes a scheduled article, dropping it and the tag list from the cache
+func (r *CachedArticleRepository) PublishScheduled(db *gorm.DB, articleID int64) (bool, error) {
+	published, err := r.ArticleRepository.PublishScheduled(db, articleID)
+	if err != nil {
+		return false, err
+	}
+	if published {
+		afterCommit(db, func() {
+			r.caches.invalidateArticle(articleID)
+			r.caches.tags.Remove(allTagsKey)
+		})
+	}
+	return published, nil
+}
@@ -0,0 +1,40 @@
+package cached
+
+import (
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// CachedFavoriteRepository invalidates cached articles whose favorites count changes
+type CachedFavoriteRepository struct {
+	repository.FavoriteRepository
+	caches *Caches
+}
+
+func NewCachedFavoriteRepository(inner repository.FavoriteRepository, caches *Caches) *CachedFavoriteRepository {
+	return &CachedFavoriteRepository{FavoriteRepository: inner, caches: caches}
+}
+
+// AdjustFavoritesCount adds delta to an article's favorites count
+func (r *CachedFavoriteRepository) AdjustFavoritesCount(db *gorm.DB, articleID int64, delta int) error {
+	if err := r.FavoriteRepository.AdjustFavoritesCount(db, articleID, delta); err != nil {
+		return err
+	}
+	afterCommit(db, func() { r.caches.invalidateArticle(articleID) })
+	return nil
+}
+
+// ReconcileFavoritesCounts recomputes the favorites counts, dropping the articles it fixed from the cache
+func (r *CachedFavoriteRepository) ReconcileFavoritesCounts(db *gorm.DB) ([]repository.FavoritesCountDrift, error) {
+	drifts, err := r.FavoriteRepository.ReconcileFavoritesCounts(db)
+	if err != nil {
+		return nil, err
+	}
+	afterCommit(db, func() {
+		for _, drift := range drifts {
+			r.caches.invalidateArticle(drift.ArticleID)
+		}
+	})
+	return drifts, nil
+}
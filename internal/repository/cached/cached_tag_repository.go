@@ -0,0 +1,71 @@
+package cached
+
+import (
+	"slices"
+
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// CachedTagRepository caches the list of all tags
+type CachedTagRepository struct {
+	repository.TagRepository
+	caches *Caches
+}
+
+func NewCachedTagRepository(inner repository.TagRepository, caches *Caches) *CachedTagRepository {
+	return &CachedTagRepository{TagRepository: inner, caches: caches}
+}
+
+// GetAllTags retrieves all unique tags, from the cache when possible
+func (r *CachedTagRepository) GetAllTags(db *gorm.DB) ([]string, error) {
+	if inTransaction(db) {
+		return r.TagRepository.GetAllTags(db)
+	}
+	if tags, ok := r.caches.tags.Get(allTagsKey); ok {
+		return slices.Clone(tags), nil
+	}
+
+	tags, err := r.TagRepository.GetAllTags(r.caches.fillDB(db))
+	if err != nil {
+		return nil, err
+	}
+	r.caches.tags.Set(allTagsKey, slices.Clone(tags))
+	return tags, nil
+}
+
+// RenameTag renames a tag; cached articles may carry the old name
+func (r *CachedTagRepository) RenameTag(db *gorm.DB, from, to string) error {
+	if err := r.TagRepository.RenameTag(db, from, to); err != nil {
+		return err
+	}
+	afterCommit(db, func() {
+		r.caches.articles.Clear()
+		r.caches.tags.Remove(allTagsKey)
+	})
+	return nil
+}
+
+// MergeTags merges one tag into another; cached articles may carry the merged tag
+func (r *CachedTagRepository) MergeTags(db *gorm.DB, from, into string) (int64, error) {
+	merged, err := r.TagRepository.MergeTags(db, from, into)
+	if err != nil {
+		return 0, err
+	}
+	afterCommit(db, func() {
+		r.caches.articles.Clear()
+		r.caches.tags.Remove(allTagsKey)
+	})
+	return merged, nil
+}
+
+// DeleteUnusedTags deletes the tags no article uses, which no cached article carries
+func (r *CachedTagRepository) DeleteUnusedTags(db *gorm.DB) ([]string, error) {
+	names, err := r.TagRepository.DeleteUnusedTags(db)
+	if err != nil {
+		return nil, err
+	}
+	afterCommit(db, func() { r.caches.tags.Remove(allTagsKey) })
+	return names, nil
+}
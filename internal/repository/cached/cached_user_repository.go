@@ -0,0 +1,28 @@
+package cached
+
+import (
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// CachedUserRepository invalidates the cached articles of users whose username changes
+type CachedUserRepository struct {
+	repository.UserRepository
+	caches *Caches
+}
+
+func NewCachedUserRepository(inner repository.UserRepository, caches *Caches) *CachedUserRepository {
+	return &CachedUserRepository{UserRepository: inner, caches: caches}
+}
+
+// UpdateUser updates a user, dropping their articles from the cache
+func (r *CachedUserRepository) UpdateUser(db *gorm.DB, user *models.User) error {
+	if err := r.UserRepository.UpdateUser(db, user); err != nil {
+		return err
+	}
+	userID := user.ID
+	afterCommit(db, func() { r.caches.invalidateAuthor(userID) })
+	return nil
+}
@@ -0,0 +1,91 @@
+// Package cached decorates repositories with in-process read caches that are invalidated
+// by the writes going through the decorators.
+//
+// Once the caches use the primary database (Caches.UsePrimary), entries are invalidated
+// when the transaction of the write commits, so a read in between cannot cache the old
+// row again, and misses are read from the primary rather than from a replica that may
+// not have the write yet. Reads inside a transaction bypass the cache.
+package cached
+
+import (
+	"time"
+
+	"go-gin-realworld-api/internal/cache"
+	"go-gin-realworld-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// allTagsKey is the single key of the tag list cache
+const allTagsKey = "all"
+
+// Caches holds the caches shared by the decorators, since a write through one
+// repository can invalidate what another one cached
+type Caches struct {
+	articles *cache.LRU[string, *models.Article] // by slug
+	tags     *cache.LRU[string, []string]
+	primary  *gorm.DB
+}
+
+// NewCaches creates caches of at most size articles whose entries expire after ttl
+func NewCaches(size int, ttl time.Duration) *Caches {
+	return &Caches{
+		articles: cache.NewLRU[string, *models.Article](size, ttl),
+		tags:     cache.NewLRU[string, []string](1, ttl),
+	}
+}
+
+// UsePrimary makes the caches fill misses from db, the primary database, and defers the
+// invalidations made by writes in a transaction of db until it commits. It must be called
+// before db is used concurrently.
+func (c *Caches) UsePrimary(db *gorm.DB) {
+	pool := commitHookPool{ConnPool: db.ConnPool}
+	db.ConnPool = pool
+	db.Statement.ConnPool = pool
+	c.primary = db
+}
+
+// fillDB returns the database to read a cache miss from: the primary, with the context
+// of db, as a replica may return a row older than the last invalidation
+func (c *Caches) fillDB(db *gorm.DB) *gorm.DB {
+	if c.primary == nil {
+		return db
+	}
+	return c.primary.WithContext(db.Statement.Context)
+}
+
+// Stats returns the statistics of each cache by name
+func (c *Caches) Stats() map[string]cache.Stats {
+	return map[string]cache.Stats{
+		"articles": c.articles.Stats(),
+		"tags":     c.tags.Stats(),
+	}
+}
+
+// invalidateArticle drops the cached article with the given ID, whatever slug it was cached under
+func (c *Caches) invalidateArticle(articleID int64) {
+	c.articles.RemoveFunc(func(_ string, article *models.Article) bool {
+		return article.ID == articleID
+	})
+}
+
+// invalidateAuthor drops the cached articles of an author, which carry their username
+func (c *Caches) invalidateAuthor(authorID int64) {
+	c.articles.RemoveFunc(func(_ string, article *models.Article) bool {
+		return article.AuthorID == authorID
+	})
+}
+
+// inTransaction reports whether db runs inside a transaction, whose reads may see
+// uncommitted writes and must not be cached
+func inTransaction(db *gorm.DB) bool {
+	_, ok := db.Statement.ConnPool.(gorm.TxCommitter)
+	return ok
+}
+
+// copyArticle returns a shallow copy, so callers can modify the fields of the article
+// they get without changing the cached one
+func copyArticle(article *models.Article) *models.Article {
+	cp := *article
+	return &cp
+}
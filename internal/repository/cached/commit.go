@@ -0,0 +1,97 @@
+package cached
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// commitHookPool wraps the connection pool of the primary database so that its
+// transactions can run invalidations once they commit
+type commitHookPool struct {
+	gorm.ConnPool
+}
+
+func (p commitHookPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	var tx gorm.ConnPool
+	switch beginner := p.ConnPool.(type) {
+	case gorm.TxBeginner:
+		sqlTx, err := beginner.BeginTx(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		tx = sqlTx
+	case gorm.ConnPoolBeginner:
+		poolTx, err := beginner.BeginTx(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		tx = poolTx
+	default:
+		return nil, gorm.ErrInvalidTransaction
+	}
+
+	committer, ok := tx.(gorm.TxCommitter)
+	if !ok {
+		return nil, gorm.ErrInvalidTransaction
+	}
+	return &commitHookTx{ConnPool: tx, committer: committer}, nil
+}
+
+// GetDBConn keeps db.DB() working on the wrapped pool
+func (p commitHookPool) GetDBConn() (*sql.DB, error) {
+	switch pool := p.ConnPool.(type) {
+	case *sql.DB:
+		return pool, nil
+	case gorm.GetDBConnector:
+		return pool.GetDBConn()
+	}
+	return nil, gorm.ErrInvalidDB
+}
+
+// commitHookTx is a transaction that runs hooks after it commits and drops them if it
+// rolls back
+type commitHookTx struct {
+	gorm.ConnPool
+	committer gorm.TxCommitter
+
+	mu    sync.Mutex
+	hooks []func()
+}
+
+func (t *commitHookTx) Commit() error {
+	if err := t.committer.Commit(); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	hooks := t.hooks
+	t.hooks = nil
+	t.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+	return nil
+}
+
+func (t *commitHookTx) Rollback() error {
+	t.mu.Lock()
+	t.hooks = nil
+	t.mu.Unlock()
+	return t.committer.Rollback()
+}
+
+// afterCommit runs fn once the transaction db runs in commits, or right away outside of
+// a transaction of the primary. A savepoint rolled back within the transaction keeps the
+// hooks it added, which at worst drop entries that did not change.
+func afterCommit(db *gorm.DB, fn func()) {
+	if tx, ok := db.Statement.ConnPool.(*commitHookTx); ok {
+		tx.mu.Lock()
+		tx.hooks = append(tx.hooks, fn)
+		tx.mu.Unlock()
+		return
+	}
+	fn()
+}
@@ -6,9 +6,25 @@ import (
 	"gorm.io/gorm"
 )
 
+// FavoritesCountDrift is an article whose stored favorites count differs from its favorites
+type FavoritesCountDrift struct {
+	ArticleID int64
+	Slug      string
+	Stored    int
+	Actual    int
+}
+
 type FavoriteRepository interface {
 	AddFavorite(db *gorm.DB, userID, articleID int64) error
-	RemoveFavorite(db *gorm.DB, userID, articleID int64) error
+	// RemoveFavorite reports whether there was a favorite to remove
+	RemoveFavorite(db *gorm.DB, userID, articleID int64) (bool, error)
 	IsFavorited(db *gorm.DB, userID, articleID int64) (bool, error)
-	GetArticleWithFavorites(db *gorm.DB, articleID int64) (*models.Article, error)
+	// FavoritedArticleIDs returns which of the given articles the user has favorited
+	FavoritedArticleIDs(db *gorm.DB, userID int64, articleIDs []int64) ([]int64, error)
+	GetArticle(db *gorm.DB, articleID int64) (*models.Article, error)
+	// AdjustFavoritesCount atomically adds delta to an article's favorites count, which never drops below zero
+	AdjustFavoritesCount(db *gorm.DB, articleID int64, delta int) error
+	// ReconcileFavoritesCounts recomputes the favorites count of every article from the
+	// favorites table and returns the articles whose count was wrong
+	ReconcileFavoritesCounts(db *gorm.DB) ([]FavoritesCountDrift, error)
 }
@@ -10,4 +10,5 @@ type FollowRepository interface {
 	CreateFollow(db *gorm.DB, follow *models.Follow) error
 	DeleteFollow(db *gorm.DB, followerID, followeeID int64) error
 	IsFollowing(db *gorm.DB, followerID, followeeID int64) (bool, error)
+	CountFollowers(db *gorm.DB, userID int64) (int64, error)
 }
@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+var errNoSQL = errors.New("memory: SQL is not supported by the in-memory backend")
+
+// Dialector is a GORM dialector that performs no I/O. It lets services keep
+// calling db.WithContext and db.Transaction while the memory repositories
+// ignore the *gorm.DB they receive, except to tell whether they run in a
+// transaction. Transactions and savepoints of the dialector snapshot its
+// Store and restore the snapshot when they roll back.
+type Dialector struct {
+	store *Store
+}
+
+// Open returns the dialector used with DB_DRIVER=memory, over a new empty Store
+func Open() gorm.Dialector {
+	return Dialector{store: NewStore()}
+}
+
+// StoreOf returns the Store whose transactions a database opened with Open runs, for
+// the memory repositories to share
+func StoreOf(db *gorm.DB) *Store {
+	return db.Dialector.(Dialector).store
+}
+
+func (Dialector) Name() string {
+	return "memory"
+}
+
+func (d Dialector) Initialize(db *gorm.DB) error {
+	db.ConnPool = connPool{store: d.store}
+	return nil
+}
+
+func (Dialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return nil
+}
+
+func (Dialector) DataTypeOf(*schema.Field) string {
+	return ""
+}
+
+func (Dialector) DefaultValueOf(*schema.Field) clause.Expression {
+	return clause.Expr{SQL: "NULL"}
+}
+
+func (Dialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+	writer.WriteByte('?')
+}
+
+func (Dialector) QuoteTo(writer clause.Writer, str string) {
+	writer.WriteString(str)
+}
+
+func (Dialector) Explain(sql string, vars ...interface{}) string {
+	return sql
+}
+
+// SavePoint snapshots the store for a nested transaction, which RollbackTo restores
+func (d Dialector) SavePoint(tx *gorm.DB, name string) error {
+	d.store.savePoint(name)
+	return nil
+}
+
+func (d Dialector) RollbackTo(tx *gorm.DB, name string) error {
+	return d.store.rollbackTo(name)
+}
+
+// connPool rejects every query and hands out transactions of the store
+type connPool struct {
+	store *Store
+}
+
+func (connPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, errNoSQL
+}
+
+func (connPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, errNoSQL
+}
+
+func (connPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errNoSQL
+}
+
+func (connPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
+func (p connPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	p.store.begin()
+	return &txConnPool{connPool: p}, nil
+}
+
+// txConnPool is the connection pool of a transaction, which holds the store's transaction
+// lock until it commits or rolls back
+type txConnPool struct {
+	connPool
+}
+
+func (t *txConnPool) Commit() error {
+	t.store.end(false)
+	return nil
+}
+
+func (t *txConnPool) Rollback() error {
+	t.store.end(true)
+	return nil
+}
@@ -0,0 +1,483 @@
+package memory
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type MemoryArticleRepository struct {
+	store *Store
+}
+
+func NewMemoryArticleRepository(store *Store) *MemoryArticleRepository {
+	return &MemoryArticleRepository{store: store}
+}
+
+// ListArticles lists articles with optional filtering and pagination
+func (r *MemoryArticleRepository) ListArticles(db *gorm.DB, filter repository.ArticleFilter, currentUserID *int64, page repository.ArticlePage) ([]*models.Article, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matches := make([]*models.Article, 0)
+	for _, article := range r.store.articles {
+		if !r.matches(article, filter) {
+			continue
+		}
+
+		// Filter by favorited (whether current user has favorited the article)
+		if filter.Favorited != nil && currentUserID != nil {
+			if r.isFavoritedBy(article.ID, *currentUserID) != *filter.Favorited {
+				continue
+			}
+		}
+
+		matches = append(matches, article)
+	}
+
+	return r.page(matches, page), r.total(matches, page), nil
+}
+
+// SearchArticles finds articles matching a full-text query, most relevant first.
+// Relevance counts the terms an article contains, weighting title over description over body.
+func (r *MemoryArticleRepository) SearchArticles(db *gorm.DB, search repository.ArticleSearch) ([]*models.Article, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matches := make([]*models.Article, 0)
+	scores := make(map[int64]int)
+	for _, article := range r.store.articles {
+		if !r.matches(article, search.Filter()) {
+			continue
+		}
+
+		title, description, body := strings.ToLower(article.Title), strings.ToLower(article.Description), strings.ToLower(article.Body)
+		score := 0
+		for _, term := range search.Terms {
+			if strings.Contains(title, term) {
+				score += 3
+			}
+			if strings.Contains(description, term) {
+				score += 2
+			}
+			if strings.Contains(body, term) {
+				score++
+			}
+		}
+		if score > 0 {
+			matches = append(matches, article)
+			scores[article.ID] = score
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if scores[matches[i].ID] != scores[matches[j].ID] {
+			return scores[matches[i].ID] > scores[matches[j].ID]
+		}
+		return newerThan(articleKey(matches[i]), articleKey(matches[j]))
+	})
+
+	start := min(max(search.Offset, 0), len(matches))
+	end := len(matches)
+	if search.Limit >= 0 && start+search.Limit < end {
+		end = start + search.Limit
+	}
+
+	result := make([]*models.Article, 0, end-start)
+	for _, article := range matches[start:end] {
+		result = append(result, r.store.copyArticle(article))
+	}
+	return result, int64(len(matches)), nil
+}
+
+// FindArticleBySlug finds an article by slug
+func (r *MemoryArticleRepository) FindArticleBySlug(db *gorm.DB, slug string) (*models.Article, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	article := r.store.articleBySlug(slug)
+	if article == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.store.copyArticle(article), nil
+}
+
+// FindArticlesByIDs returns the articles that exist among ids, in no particular order
+func (r *MemoryArticleRepository) FindArticlesByIDs(db *gorm.DB, ids []int64) ([]*models.Article, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	articles := make([]*models.Article, 0, len(ids))
+	for _, id := range ids {
+		if article, ok := r.store.articles[id]; ok {
+			articles = append(articles, r.store.copyArticle(article))
+		}
+	}
+	return articles, nil
+}
+
+// FindCurrentSlug returns the current slug of the article that has used slug
+func (r *MemoryArticleRepository) FindCurrentSlug(db *gorm.DB, slug string) (string, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	article, ok := r.store.articles[r.store.slugOwner(slug)]
+	if !ok {
+		return "", gorm.ErrRecordNotFound
+	}
+	return article.Slug, nil
+}
+
+// CreateArticle creates a new article and records its slug
+func (r *MemoryArticleRepository) CreateArticle(db *gorm.DB, article *models.Article) error {
+	defer r.store.lockWrite(db)()
+
+	if _, ok := r.store.users[article.AuthorID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	if r.store.articleBySlug(article.Slug) != nil || r.store.slugOwner(article.Slug) != 0 {
+		return gorm.ErrDuplicatedKey
+	}
+
+	article.ID = r.store.nextID("articles")
+	article.Version = 1
+	if article.Status == "" {
+		article.Status = models.ArticleStatusPublished
+	}
+	touch(&article.CreatedAt, &article.UpdatedAt)
+	r.store.articles[article.ID] = detachArticle(article)
+	r.recordSlug(article)
+	return nil
+}
+
+// UpdateArticle updates an article, unless another update changed its version first, and
+// records its slug if it changed
+func (r *MemoryArticleRepository) UpdateArticle(db *gorm.DB, article *models.Article) error {
+	if article.ID == 0 {
+		return r.CreateArticle(db, article)
+	}
+
+	defer r.store.lockWrite(db)()
+
+	if existing := r.store.articleBySlug(article.Slug); existing != nil && existing.ID != article.ID {
+		return gorm.ErrDuplicatedKey
+	}
+	if owner := r.store.slugOwner(article.Slug); owner != 0 && owner != article.ID {
+		return gorm.ErrDuplicatedKey
+	}
+	existing, ok := r.store.articles[article.ID]
+	if !ok || existing.Version != article.Version {
+		return repository.ErrVersionConflict
+	}
+
+	article.UpdatedAt = time.Now()
+	article.Version++
+	touch(&article.CreatedAt, nil)
+	stored := detachArticle(article)
+	// The favorites count only changes through AdjustFavoritesCount
+	stored.FavoritesCount = existing.FavoritesCount
+	r.store.articles[article.ID] = stored
+	r.recordSlug(article)
+	return nil
+}
+
+// ListDueScheduled returns the IDs of the scheduled articles that are due
+func (r *MemoryArticleRepository) ListDueScheduled(db *gorm.DB, now time.Time, limit int) ([]int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	due := make([]*models.Article, 0)
+	for _, article := range r.store.articles {
+		if article.Status == models.ArticleStatusScheduled && article.PublishedAt != nil && !article.PublishedAt.After(now) {
+			due = append(due, article)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].PublishedAt.Equal(*due[j].PublishedAt) {
+			return due[i].PublishedAt.Before(*due[j].PublishedAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+
+	ids := make([]int64, 0, min(len(due), limit))
+	for _, article := range pageRows(due, repository.ArticlePage{Limit: limit}) {
+		ids = append(ids, article.ID)
+	}
+	return ids, nil
+}
+
+// PublishScheduled publishes a scheduled article
+func (r *MemoryArticleRepository) PublishScheduled(db *gorm.DB, articleID int64) (bool, error) {
+	defer r.store.lockWrite(db)()
+
+	article, ok := r.store.articles[articleID]
+	if !ok || article.Status != models.ArticleStatusScheduled {
+		return false, nil
+	}
+	article.Status = models.ArticleStatusPublished
+	if article.PublishedAt != nil {
+		article.CreatedAt = *article.PublishedAt
+	}
+	article.UpdatedAt = time.Now()
+	article.Version++
+	return true, nil
+}
+
+// DeleteArticleBySlug deletes an article by slug, cascading to its comments, tags, favorites, slug history and timeline entries
+func (r *MemoryArticleRepository) DeleteArticleBySlug(db *gorm.DB, slug string) error {
+	defer r.store.lockWrite(db)()
+
+	article := r.store.articleBySlug(slug)
+	if article == nil {
+		return nil
+	}
+
+	delete(r.store.articles, article.ID)
+	for id, comment := range r.store.comments {
+		if comment.ArticleID == article.ID {
+			delete(r.store.comments, id)
+		}
+	}
+	for id, at := range r.store.articleTags {
+		if at.ArticleID == article.ID {
+			delete(r.store.articleTags, id)
+		}
+	}
+	for id, fav := range r.store.favorites {
+		if fav.ArticleID == article.ID {
+			delete(r.store.favorites, id)
+		}
+	}
+	for id, history := range r.store.articleSlugs {
+		if history.ArticleID == article.ID {
+			delete(r.store.articleSlugs, id)
+		}
+	}
+	for id, entry := range r.store.timelineEntries {
+		if entry.ArticleID == article.ID {
+			delete(r.store.timelineEntries, id)
+		}
+	}
+	for _, ranking := range r.store.trendingArticles {
+		delete(ranking, article.ID)
+	}
+	return nil
+}
+
+// AssignTagsToArticle replaces the tags of an article with the given tag names; no tag
+// names removes them all
+func (r *MemoryArticleRepository) AssignTagsToArticle(db *gorm.DB, articleID int64, tagNames []string) error {
+	defer r.store.lockWrite(db)()
+
+	if _, ok := r.store.articles[articleID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+
+	// Delete existing article tags
+	for id, at := range r.store.articleTags {
+		if at.ArticleID == articleID {
+			delete(r.store.articleTags, id)
+		}
+	}
+
+	// Map existing tag names for quick lookup
+	existingTagMap := make(map[string]*models.Tag)
+	for _, tag := range r.store.tags {
+		existingTagMap[tag.Name] = tag
+	}
+
+	for _, tagName := range tagNames {
+		tag, exists := existingTagMap[tagName]
+		if !exists {
+			tag = &models.Tag{ID: r.store.nextID("tags"), Name: tagName}
+			touch(&tag.CreatedAt, nil)
+			r.store.tags[tag.ID] = tag
+			existingTagMap[tagName] = tag
+		}
+
+		at := &models.ArticleTag{ID: r.store.nextID("article_tags"), ArticleID: articleID, TagID: tag.ID}
+		touch(&at.CreatedAt, nil)
+		r.store.articleTags[at.ID] = at
+	}
+
+	return nil
+}
+
+// recordSlug adds the article's slug to its history if missing (caller must hold the write lock)
+func (r *MemoryArticleRepository) recordSlug(article *models.Article) {
+	if r.store.slugOwner(article.Slug) != 0 {
+		return
+	}
+	history := &models.ArticleSlug{ID: r.store.nextID("article_slugs"), ArticleID: article.ID, Slug: article.Slug}
+	touch(&history.CreatedAt, nil)
+	r.store.articleSlugs[history.ID] = history
+}
+
+// hasTag reports whether an article is tagged with the given name (caller must hold the lock)
+func (r *MemoryArticleRepository) hasTag(articleID int64, tagName string) bool {
+	for _, at := range r.store.articleTags {
+		if at.ArticleID != articleID {
+			continue
+		}
+		if tag, ok := r.store.tags[at.TagID]; ok && tag.Name == tagName {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether an article matches the tags and author of a filter
+// (caller must hold the lock)
+func (r *MemoryArticleRepository) matches(article *models.Article, filter repository.ArticleFilter) bool {
+	if len(filter.Statuses) > 0 {
+		if !slices.Contains(filter.Statuses, article.Status) {
+			return false
+		}
+	} else if article.Status != models.ArticleStatusPublished {
+		return false
+	}
+
+	if len(filter.Tags) > 0 {
+		tagged := 0
+		for _, name := range filter.Tags {
+			if r.hasTag(article.ID, name) {
+				tagged++
+			}
+		}
+		if tagged == 0 || (filter.TagMode != repository.TagModeAny && tagged < len(filter.Tags)) {
+			return false
+		}
+	}
+	for _, name := range filter.ExcludeTags {
+		if r.hasTag(article.ID, name) {
+			return false
+		}
+	}
+
+	if filter.Author != "" {
+		if user, ok := r.store.users[article.AuthorID]; !ok || user.Username != filter.Author {
+			return false
+		}
+	}
+	if filter.AuthorID != 0 && article.AuthorID != filter.AuthorID {
+		return false
+	}
+
+	if !filter.CreatedAfter.IsZero() && article.CreatedAt.Before(filter.CreatedAfter) {
+		return false
+	}
+	return filter.CreatedBefore.IsZero() || article.CreatedAt.Before(filter.CreatedBefore)
+}
+
+// isFavoritedBy reports whether a user favorited an article (caller must hold the lock)
+func (r *MemoryArticleRepository) isFavoritedBy(articleID, userID int64) bool {
+	for _, fav := range r.store.favorites {
+		if fav.ArticleID == articleID && fav.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// page sorts articles newest first and applies the cursor or LIMIT/OFFSET of a page
+// (caller must hold the lock)
+func (r *MemoryArticleRepository) page(articles []*models.Article, page repository.ArticlePage) []*models.Article {
+	var paged []*models.Article
+	if before := r.store.sortOrder(page.Sort); before != nil {
+		sort.Slice(articles, func(i, j int) bool { return before(articles[i], articles[j]) })
+		paged = pageRows(articles, page)
+	} else {
+		paged = pageNewestFirst(articles, page, articleKey)
+	}
+	result := make([]*models.Article, 0, len(paged))
+	for _, article := range paged {
+		result = append(result, r.store.copyArticle(article))
+	}
+	return result
+}
+
+// pageNewestFirst sorts rows newest first by their (created_at, id) key and applies the
+// cursor or LIMIT/OFFSET of a page. A negative limit means no limit, matching GORM.
+func pageNewestFirst[T any](rows []T, page repository.ArticlePage, key func(T) repository.ArticleCursor) []T {
+	sort.Slice(rows, func(i, j int) bool {
+		return newerThan(key(rows[i]), key(rows[j]))
+	})
+
+	start, end := 0, len(rows)
+	switch {
+	case page.After != nil:
+		// First row older than the cursor
+		start = sort.Search(len(rows), func(i int) bool {
+			return !newerThan(key(rows[i]), *page.After) && !atCursor(key(rows[i]), *page.After)
+		})
+		if page.Limit >= 0 && start+page.Limit < end {
+			end = start + page.Limit
+		}
+	case page.Before != nil:
+		// Rows newer than the cursor, keeping the ones closest to it
+		end = sort.Search(len(rows), func(i int) bool {
+			return !newerThan(key(rows[i]), *page.Before)
+		})
+		if page.Limit >= 0 && end-page.Limit > start {
+			start = end - page.Limit
+		}
+	default:
+		return pageRows(rows, page)
+	}
+	return rows[start:end]
+}
+
+// total is the number of matching articles, or 0 when the page skips the count
+func (r *MemoryArticleRepository) total(articles []*models.Article, page repository.ArticlePage) int64 {
+	if page.SkipCount {
+		return 0
+	}
+	return int64(len(articles))
+}
+
+// pageRows applies the LIMIT/OFFSET of a page to sorted rows; a negative limit means no limit
+func pageRows[T any](rows []T, page repository.ArticlePage) []T {
+	start := min(max(page.Offset, 0), len(rows))
+	end := len(rows)
+	if page.Limit >= 0 && start+page.Limit < end {
+		end = start + page.Limit
+	}
+	return rows[start:end]
+}
+
+// newerThan reports whether key a comes before key b in newest-first order
+func newerThan(a, b repository.ArticleCursor) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID > b.ID
+}
+
+func atCursor(key, cursor repository.ArticleCursor) bool {
+	return key.CreatedAt.Equal(cursor.CreatedAt) && key.ID == cursor.ID
+}
+
+func articleKey(article *models.Article) repository.ArticleCursor {
+	return repository.ArticleCursor{CreatedAt: article.CreatedAt, ID: article.ID}
+}
+
+// detachArticle returns a copy of an article without associations for storage
+func detachArticle(article *models.Article) *models.Article {
+	cp := *article
+	cp.Author = nil
+	cp.Comments = nil
+	cp.ArticleTags = nil
+	cp.Favorites = nil
+	cp.Slugs = nil
+	if article.PublishedAt != nil {
+		publishedAt := *article.PublishedAt
+		cp.PublishedAt = &publishedAt
+	}
+	return &cp
+}
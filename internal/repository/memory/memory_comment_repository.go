@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"sort"
+
+	"go-gin-realworld-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type MemoryCommentRepository struct {
+	store *Store
+}
+
+func NewMemoryCommentRepository(store *Store) *MemoryCommentRepository {
+	return &MemoryCommentRepository{store: store}
+}
+
+// CreateComment creates a new comment
+func (r *MemoryCommentRepository) CreateComment(db *gorm.DB, comment *models.Comment) error {
+	defer r.store.lockWrite(db)()
+
+	_, articleExists := r.store.articles[comment.ArticleID]
+	_, authorExists := r.store.users[comment.AuthorID]
+	if !articleExists || !authorExists {
+		return gorm.ErrForeignKeyViolated
+	}
+
+	comment.ID = r.store.nextID("comments")
+	touch(&comment.CreatedAt, &comment.UpdatedAt)
+	cp := *comment
+	cp.Article = nil
+	cp.Author = nil
+	r.store.comments[comment.ID] = &cp
+	return nil
+}
+
+// GetCommentsByArticleID gets all comments for an article
+func (r *MemoryCommentRepository) GetCommentsByArticleID(db *gorm.DB, articleID int64) ([]*models.Comment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	comments := make([]*models.Comment, 0)
+	for _, comment := range r.store.comments {
+		if comment.ArticleID == articleID {
+			cp := *comment
+			cp.Author = copyUser(r.store.users[comment.AuthorID])
+			comments = append(comments, &cp)
+		}
+	}
+
+	sort.Slice(comments, func(i, j int) bool {
+		if !comments[i].CreatedAt.Equal(comments[j].CreatedAt) {
+			return comments[i].CreatedAt.After(comments[j].CreatedAt)
+		}
+		return comments[i].ID > comments[j].ID
+	})
+	return comments, nil
+}
+
+// GetCommentByID gets a comment by ID with author and article preloaded
+func (r *MemoryCommentRepository) GetCommentByID(db *gorm.DB, id int64) (*models.Comment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	comment, ok := r.store.comments[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	cp := *comment
+	cp.Author = copyUser(r.store.users[comment.AuthorID])
+	if article, ok := r.store.articles[comment.ArticleID]; ok {
+		cp.Article = detachArticle(article)
+	}
+	return &cp, nil
+}
+
+// DeleteComment deletes a comment by ID
+func (r *MemoryCommentRepository) DeleteComment(db *gorm.DB, id int64) error {
+	defer r.store.lockWrite(db)()
+
+	delete(r.store.comments, id)
+	return nil
+}
@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type MemoryFavoriteRepository struct {
+	store *Store
+}
+
+func NewMemoryFavoriteRepository(store *Store) *MemoryFavoriteRepository {
+	return &MemoryFavoriteRepository{store: store}
+}
+
+// AddFavorite adds an article to user's favorites
+func (r *MemoryFavoriteRepository) AddFavorite(db *gorm.DB, userID, articleID int64) error {
+	defer r.store.lockWrite(db)()
+
+	_, userExists := r.store.users[userID]
+	_, articleExists := r.store.articles[articleID]
+	if !userExists || !articleExists {
+		return gorm.ErrForeignKeyViolated
+	}
+	if r.find(userID, articleID) != nil {
+		return gorm.ErrDuplicatedKey
+	}
+
+	favorite := &models.Favorite{
+		ID:        r.store.nextID("favorites"),
+		UserID:    userID,
+		ArticleID: articleID,
+	}
+	touch(&favorite.CreatedAt, nil)
+	r.store.favorites[favorite.ID] = favorite
+	return nil
+}
+
+// RemoveFavorite removes an article from user's favorites and reports whether it was there
+func (r *MemoryFavoriteRepository) RemoveFavorite(db *gorm.DB, userID, articleID int64) (bool, error) {
+	defer r.store.lockWrite(db)()
+
+	favorite := r.find(userID, articleID)
+	if favorite == nil {
+		return false, nil
+	}
+	delete(r.store.favorites, favorite.ID)
+	return true, nil
+}
+
+// IsFavorited checks if user has favorited an article
+func (r *MemoryFavoriteRepository) IsFavorited(db *gorm.DB, userID, articleID int64) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.find(userID, articleID) != nil, nil
+}
+
+// FavoritedArticleIDs returns which of the given articles the user has favorited
+func (r *MemoryFavoriteRepository) FavoritedArticleIDs(db *gorm.DB, userID int64, articleIDs []int64) ([]int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ids := make([]int64, 0)
+	for _, articleID := range articleIDs {
+		if r.find(userID, articleID) != nil {
+			ids = append(ids, articleID)
+		}
+	}
+	return ids, nil
+}
+
+// GetArticle gets an article with its author and tags
+func (r *MemoryFavoriteRepository) GetArticle(db *gorm.DB, articleID int64) (*models.Article, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	article, ok := r.store.articles[articleID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.store.copyArticle(article), nil
+}
+
+// AdjustFavoritesCount adds delta to an article's favorites count unless it would go negative
+func (r *MemoryFavoriteRepository) AdjustFavoritesCount(db *gorm.DB, articleID int64, delta int) error {
+	defer r.store.lockWrite(db)()
+
+	if article, ok := r.store.articles[articleID]; ok && article.FavoritesCount+delta >= 0 {
+		article.FavoritesCount += delta
+	}
+	return nil
+}
+
+// ReconcileFavoritesCounts recomputes the favorites count of every article from the favorites
+func (r *MemoryFavoriteRepository) ReconcileFavoritesCounts(db *gorm.DB) ([]repository.FavoritesCountDrift, error) {
+	defer r.store.lockWrite(db)()
+
+	counts := make(map[int64]int)
+	for _, favorite := range r.store.favorites {
+		counts[favorite.ArticleID]++
+	}
+
+	drifts := make([]repository.FavoritesCountDrift, 0)
+	for _, article := range sortedByID(r.store.articles) {
+		if actual := counts[article.ID]; article.FavoritesCount != actual {
+			drifts = append(drifts, repository.FavoritesCountDrift{
+				ArticleID: article.ID,
+				Slug:      article.Slug,
+				Stored:    article.FavoritesCount,
+				Actual:    actual,
+			})
+			article.FavoritesCount = actual
+		}
+	}
+	return drifts, nil
+}
+
+// find returns the stored favorite of a user for an article (caller must hold the lock)
+func (r *MemoryFavoriteRepository) find(userID, articleID int64) *models.Favorite {
+	for _, favorite := range r.store.favorites {
+		if favorite.UserID == userID && favorite.ArticleID == articleID {
+			return favorite
+		}
+	}
+	return nil
+}
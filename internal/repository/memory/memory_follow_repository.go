@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"go-gin-realworld-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type MemoryFollowRepository struct {
+	store *Store
+}
+
+func NewMemoryFollowRepository(store *Store) *MemoryFollowRepository {
+	return &MemoryFollowRepository{store: store}
+}
+
+// CreateFollow creates a follow relationship
+func (r *MemoryFollowRepository) CreateFollow(db *gorm.DB, follow *models.Follow) error {
+	defer r.store.lockWrite(db)()
+
+	_, followerExists := r.store.users[follow.FollowerID]
+	_, followeeExists := r.store.users[follow.FolloweeID]
+	if !followerExists || !followeeExists {
+		return gorm.ErrForeignKeyViolated
+	}
+	if r.find(follow.FollowerID, follow.FolloweeID) != nil {
+		return gorm.ErrDuplicatedKey
+	}
+
+	follow.ID = r.store.nextID("follows")
+	touch(&follow.CreatedAt, nil)
+	cp := *follow
+	cp.Follower = nil
+	cp.Followee = nil
+	r.store.follows[follow.ID] = &cp
+	return nil
+}
+
+// DeleteFollow deletes a follow relationship
+func (r *MemoryFollowRepository) DeleteFollow(db *gorm.DB, followerID, followeeID int64) error {
+	defer r.store.lockWrite(db)()
+
+	if follow := r.find(followerID, followeeID); follow != nil {
+		delete(r.store.follows, follow.ID)
+	}
+	return nil
+}
+
+// IsFollowing checks if a user follows another user
+func (r *MemoryFollowRepository) IsFollowing(db *gorm.DB, followerID, followeeID int64) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.find(followerID, followeeID) != nil, nil
+}
+
+// CountFollowers counts the followers of a user
+func (r *MemoryFollowRepository) CountFollowers(db *gorm.DB, userID int64) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var count int64
+	for _, follow := range r.store.follows {
+		if follow.FolloweeID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// find returns the stored follow between two users (caller must hold the lock)
+func (r *MemoryFollowRepository) find(followerID, followeeID int64) *models.Follow {
+	for _, follow := range r.store.follows {
+		if follow.FollowerID == followerID && follow.FolloweeID == followeeID {
+			return follow
+		}
+	}
+	return nil
+}
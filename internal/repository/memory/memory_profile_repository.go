@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"time"
+
+	"go-gin-realworld-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type MemoryProfileRepository struct {
+	store *Store
+}
+
+func NewMemoryProfileRepository(store *Store) *MemoryProfileRepository {
+	return &MemoryProfileRepository{store: store}
+}
+
+// CreateProfile creates a new profile in the store
+func (r *MemoryProfileRepository) CreateProfile(db *gorm.DB, profile *models.Profile) error {
+	defer r.store.lockWrite(db)()
+
+	return r.insert(profile)
+}
+
+// FindProfileByUserID finds a profile by user ID
+func (r *MemoryProfileRepository) FindProfileByUserID(db *gorm.DB, userID int64) (*models.Profile, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, profile := range r.store.profiles {
+		if profile.UserID == userID {
+			return copyProfile(profile), nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// UpdateProfile updates a profile in the store (inserts it when it has no ID, like GORM Save)
+func (r *MemoryProfileRepository) UpdateProfile(db *gorm.DB, profile *models.Profile) error {
+	defer r.store.lockWrite(db)()
+
+	if profile.ID == 0 {
+		return r.insert(profile)
+	}
+
+	for _, existing := range r.store.profiles {
+		if existing.ID != profile.ID && existing.UserID == profile.UserID {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+
+	profile.UpdatedAt = time.Now()
+	touch(&profile.CreatedAt, nil)
+	r.store.profiles[profile.ID] = copyProfile(profile)
+	return nil
+}
+
+// insert stores a new profile, enforcing the user foreign key and unique user_id (caller must hold the write lock)
+func (r *MemoryProfileRepository) insert(profile *models.Profile) error {
+	if _, ok := r.store.users[profile.UserID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	for _, existing := range r.store.profiles {
+		if existing.UserID == profile.UserID {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+
+	profile.ID = r.store.nextID("profiles")
+	touch(&profile.CreatedAt, &profile.UpdatedAt)
+	r.store.profiles[profile.ID] = copyProfile(profile)
+	return nil
+}
+
+// copyProfile returns a detached copy of a profile without associations
+func copyProfile(profile *models.Profile) *models.Profile {
+	cp := *profile
+	cp.User = nil
+	return &cp
+}
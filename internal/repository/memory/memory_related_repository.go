@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"sort"
+
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type MemoryRelatedRepository struct {
+	store *Store
+}
+
+func NewMemoryRelatedRepository(store *Store) *MemoryRelatedRepository {
+	return &MemoryRelatedRepository{store: store}
+}
+
+// ListTagOverlaps returns the articles sharing the most tags with an article
+func (r *MemoryRelatedRepository) ListTagOverlaps(db *gorm.DB, articleID int64, excludeAuthorID *int64, limit int) ([]repository.RelatedArticle, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	tags := make(map[int64]bool)
+	for _, at := range r.store.articleTags {
+		if at.ArticleID == articleID {
+			tags[at.TagID] = true
+		}
+	}
+
+	shared := make(map[int64]int64)
+	for _, at := range r.store.articleTags {
+		if tags[at.TagID] {
+			shared[at.ArticleID]++
+		}
+	}
+	return r.rankRelated(shared, articleID, excludeAuthorID, limit), nil
+}
+
+// ListCoFavorites returns the articles most often favorited by the last limit users who
+// favorited an article
+func (r *MemoryRelatedRepository) ListCoFavorites(db *gorm.DB, articleID int64, excludeAuthorID *int64, limit int) ([]repository.RelatedArticle, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	favoriters := make([]*models.Favorite, 0)
+	for _, favorite := range r.store.favorites {
+		if favorite.ArticleID == articleID {
+			favoriters = append(favoriters, favorite)
+		}
+	}
+	sort.Slice(favoriters, func(i, j int) bool { return favoriters[i].ID > favoriters[j].ID })
+	users := make(map[int64]bool)
+	for _, favorite := range pageRows(favoriters, repository.ArticlePage{Limit: limit}) {
+		users[favorite.UserID] = true
+	}
+
+	shared := make(map[int64]int64)
+	for _, favorite := range r.store.favorites {
+		if users[favorite.UserID] {
+			shared[favorite.ArticleID]++
+		}
+	}
+	return r.rankRelated(shared, articleID, excludeAuthorID, limit), nil
+}
+
+// rankRelated orders the counts of shared tags or users, leaving out the source article,
+// unpublished articles and the excluded author's articles (caller must hold the lock)
+func (r *MemoryRelatedRepository) rankRelated(shared map[int64]int64, articleID int64, excludeAuthorID *int64, limit int) []repository.RelatedArticle {
+	related := make([]repository.RelatedArticle, 0, len(shared))
+	for id, count := range shared {
+		article, ok := r.store.articles[id]
+		if id == articleID || !ok || article.Status != models.ArticleStatusPublished || (excludeAuthorID != nil && article.AuthorID == *excludeAuthorID) {
+			continue
+		}
+		related = append(related, repository.RelatedArticle{ArticleID: id, Shared: count})
+	}
+	sort.Slice(related, func(i, j int) bool {
+		if related[i].Shared != related[j].Shared {
+			return related[i].Shared > related[j].Shared
+		}
+		return related[i].ArticleID > related[j].ArticleID
+	})
+	return pageRows(related, repository.ArticlePage{Limit: limit})
+}
@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type MemoryTagRepository struct {
+	store *Store
+}
+
+func NewMemoryTagRepository(store *Store) *MemoryTagRepository {
+	return &MemoryTagRepository{store: store}
+}
+
+// GetAllTags retrieves the tags of published articles from the store
+func (r *MemoryTagRepository) GetAllTags(db *gorm.DB) ([]string, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	used := make(map[int64]bool)
+	for _, at := range r.store.articleTags {
+		if article, ok := r.store.articles[at.ArticleID]; ok && article.Status == models.ArticleStatusPublished {
+			used[at.TagID] = true
+		}
+	}
+	tags := make([]string, 0)
+	for _, tag := range sortedByID(r.store.tags) {
+		if used[tag.ID] {
+			tags = append(tags, tag.Name)
+		}
+	}
+	return tags, nil
+}
+
+// ListTagCounts returns every tag with the number of articles that use it
+func (r *MemoryTagRepository) ListTagCounts(db *gorm.DB, order repository.TagSort, limit int) ([]repository.TagCount, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	articles := make(map[int64]int64)
+	for _, at := range r.store.articleTags {
+		if article, ok := r.store.articles[at.ArticleID]; ok && article.Status == models.ArticleStatusPublished {
+			articles[at.TagID]++
+		}
+	}
+	counts := make([]repository.TagCount, 0, len(r.store.tags))
+	for _, tag := range r.store.tags {
+		counts = append(counts, repository.TagCount{Name: tag.Name, Articles: articles[tag.ID]})
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if order == repository.TagSortPopular && counts[i].Articles != counts[j].Articles {
+			return counts[i].Articles > counts[j].Articles
+		}
+		return counts[i].Name < counts[j].Name
+	})
+	if limit > 0 && limit < len(counts) {
+		counts = counts[:limit]
+	}
+	return counts, nil
+}
+
+// SuggestTags returns the most used tags that start with a prefix
+func (r *MemoryTagRepository) SuggestTags(db *gorm.DB, prefix string, limit int) ([]string, error) {
+	counts, err := r.ListTagCounts(db, repository.TagSortPopular, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, limit)
+	for _, count := range counts {
+		if len(names) == limit {
+			break
+		}
+		if strings.HasPrefix(strings.ToLower(count.Name), prefix) {
+			names = append(names, count.Name)
+		}
+	}
+	return names, nil
+}
+
+// RenameTag renames a tag
+func (r *MemoryTagRepository) RenameTag(db *gorm.DB, from, to string) error {
+	defer r.store.lockWrite(db)()
+
+	tag := r.store.tagByName(from)
+	if tag == nil {
+		return gorm.ErrRecordNotFound
+	}
+	if other := r.store.tagByName(to); other != nil && other != tag {
+		return gorm.ErrDuplicatedKey
+	}
+	tag.Name = to
+	return nil
+}
+
+// MergeTags moves the articles of one tag to another and deletes the first
+func (r *MemoryTagRepository) MergeTags(db *gorm.DB, from, into string) (int64, error) {
+	defer r.store.lockWrite(db)()
+
+	source, target := r.store.tagByName(from), r.store.tagByName(into)
+	if source == nil || target == nil {
+		return 0, gorm.ErrRecordNotFound
+	}
+
+	tagged := make(map[int64]bool)
+	for _, at := range r.store.articleTags {
+		if at.TagID == target.ID {
+			tagged[at.ArticleID] = true
+		}
+	}
+
+	var merged int64
+	for id, at := range r.store.articleTags {
+		if at.TagID != source.ID {
+			continue
+		}
+		if tagged[at.ArticleID] {
+			delete(r.store.articleTags, id)
+		} else {
+			at.TagID = target.ID
+		}
+		merged++
+	}
+	delete(r.store.tags, source.ID)
+	return merged, nil
+}
+
+// DeleteUnusedTags deletes the tags no article uses
+func (r *MemoryTagRepository) DeleteUnusedTags(db *gorm.DB) ([]string, error) {
+	defer r.store.lockWrite(db)()
+
+	used := make(map[int64]bool)
+	for _, at := range r.store.articleTags {
+		used[at.TagID] = true
+	}
+
+	names := make([]string, 0)
+	for id, tag := range r.store.tags {
+		if !used[id] {
+			names = append(names, tag.Name)
+			delete(r.store.tags, id)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,204 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type MemoryTimelineRepository struct {
+	store *Store
+}
+
+func NewMemoryTimelineRepository(store *Store) *MemoryTimelineRepository {
+	return &MemoryTimelineRepository{store: store}
+}
+
+// FanOutArticle adds an article to the timeline of every follower of its author
+func (r *MemoryTimelineRepository) FanOutArticle(db *gorm.DB, article *models.Article) error {
+	defer r.store.lockWrite(db)()
+
+	if _, ok := r.store.articles[article.ID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	for _, follow := range sortedByID(r.store.follows) {
+		if follow.FolloweeID == article.AuthorID {
+			r.add(follow.FollowerID, article)
+		}
+	}
+	return nil
+}
+
+// BackfillTimeline adds the fanned-out published articles of an author to a new follower's timeline
+func (r *MemoryTimelineRepository) BackfillTimeline(db *gorm.DB, userID, authorID int64) error {
+	defer r.store.lockWrite(db)()
+
+	if _, ok := r.store.users[userID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	for _, article := range sortedByID(r.store.articles) {
+		if article.AuthorID == authorID && !article.FanOutOnRead && article.Status == models.ArticleStatusPublished {
+			r.add(userID, article)
+		}
+	}
+	return nil
+}
+
+// PruneTimeline removes the articles of an author from a user's timeline
+func (r *MemoryTimelineRepository) PruneTimeline(db *gorm.DB, userID, authorID int64) error {
+	defer r.store.lockWrite(db)()
+
+	for id, entry := range r.store.timelineEntries {
+		if entry.UserID == userID && entry.AuthorID == authorID {
+			delete(r.store.timelineEntries, id)
+		}
+	}
+	return nil
+}
+
+// RemoveArticle removes an article from every timeline
+func (r *MemoryTimelineRepository) RemoveArticle(db *gorm.DB, articleID int64) error {
+	defer r.store.lockWrite(db)()
+
+	for id, entry := range r.store.timelineEntries {
+		if entry.ArticleID == articleID {
+			delete(r.store.timelineEntries, id)
+		}
+	}
+	return nil
+}
+
+// ListTimeline returns a page of a user's timeline entries that match the filter, newest
+// first, and their total
+func (r *MemoryTimelineRepository) ListTimeline(db *gorm.DB, userID int64, filter repository.FeedFilter, page repository.ArticlePage) ([]*models.TimelineEntry, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matches := make([]*models.TimelineEntry, 0)
+	for _, entry := range r.store.timelineEntries {
+		if entry.UserID == userID && createdWithin(entry.CreatedAt, filter) {
+			cp := *entry
+			matches = append(matches, &cp)
+		}
+	}
+
+	return pageNewestFirst(matches, page, entryKey), entriesTotal(matches, page), nil
+}
+
+// ListFanOutOnRead returns a page of the published articles with FanOutOnRead set by authors the
+// user follows that match the filter, as timeline entries, newest first, and their total
+func (r *MemoryTimelineRepository) ListFanOutOnRead(db *gorm.DB, userID int64, filter repository.FeedFilter, page repository.ArticlePage) ([]*models.TimelineEntry, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	followees := r.followees(userID)
+	matches := make([]*models.TimelineEntry, 0)
+	for _, article := range r.store.articles {
+		if article.FanOutOnRead && followees[article.AuthorID] && article.Status == models.ArticleStatusPublished && createdWithin(article.CreatedAt, filter) {
+			matches = append(matches, newEntry(userID, article))
+		}
+	}
+
+	return pageNewestFirst(matches, page, entryKey), entriesTotal(matches, page), nil
+}
+
+// ListFeed returns a page of a user's timeline entries and articles read on demand that match
+// the filter, in the order of page.Sort, and their total
+func (r *MemoryTimelineRepository) ListFeed(db *gorm.DB, userID int64, filter repository.FeedFilter, page repository.ArticlePage) ([]*models.TimelineEntry, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	inTimeline := make(map[int64]bool)
+	for _, entry := range r.store.timelineEntries {
+		if entry.UserID == userID {
+			inTimeline[entry.ArticleID] = true
+		}
+	}
+	followees := r.followees(userID)
+
+	articles := make([]*models.Article, 0)
+	for _, article := range r.store.articles {
+		if article.Status != models.ArticleStatusPublished || !createdWithin(article.CreatedAt, filter) {
+			continue
+		}
+		if inTimeline[article.ID] || (article.FanOutOnRead && followees[article.AuthorID]) {
+			articles = append(articles, article)
+		}
+	}
+
+	var paged []*models.Article
+	if before := r.store.sortOrder(page.Sort); before != nil {
+		sort.Slice(articles, func(i, j int) bool { return before(articles[i], articles[j]) })
+		paged = pageRows(articles, page)
+	} else {
+		paged = pageNewestFirst(articles, page, articleKey)
+	}
+	entries := make([]*models.TimelineEntry, 0, len(paged))
+	for _, article := range paged {
+		entries = append(entries, newEntry(userID, article))
+	}
+
+	total := int64(len(articles))
+	if page.SkipCount {
+		total = 0
+	}
+	return entries, total, nil
+}
+
+// followees returns the set of users a user follows (caller must hold the lock)
+func (r *MemoryTimelineRepository) followees(userID int64) map[int64]bool {
+	followees := make(map[int64]bool)
+	for _, follow := range r.store.follows {
+		if follow.FollowerID == userID {
+			followees[follow.FolloweeID] = true
+		}
+	}
+	return followees
+}
+
+// newEntry returns an unsaved timeline entry of an article for a user
+func newEntry(userID int64, article *models.Article) *models.TimelineEntry {
+	return &models.TimelineEntry{
+		UserID:    userID,
+		ArticleID: article.ID,
+		AuthorID:  article.AuthorID,
+		CreatedAt: article.CreatedAt,
+	}
+}
+
+// createdWithin reports whether a creation time is within the range of a feed filter
+func createdWithin(createdAt time.Time, filter repository.FeedFilter) bool {
+	if !filter.CreatedAfter.IsZero() && createdAt.Before(filter.CreatedAfter) {
+		return false
+	}
+	return filter.CreatedBefore.IsZero() || createdAt.Before(filter.CreatedBefore)
+}
+
+// add puts an article in a user's timeline unless it is already there (caller must hold the write lock)
+func (r *MemoryTimelineRepository) add(userID int64, article *models.Article) {
+	for _, entry := range r.store.timelineEntries {
+		if entry.UserID == userID && entry.ArticleID == article.ID {
+			return
+		}
+	}
+	entry := newEntry(userID, article)
+	entry.ID = r.store.nextID("timeline_entries")
+	r.store.timelineEntries[entry.ID] = entry
+}
+
+// entryKey orders timeline entries like the articles they point to
+func entryKey(entry *models.TimelineEntry) repository.ArticleCursor {
+	return repository.ArticleCursor{CreatedAt: entry.CreatedAt, ID: entry.ArticleID}
+}
+
+// entriesTotal is the number of matching entries, or 0 when the page skips the count
+func entriesTotal(entries []*models.TimelineEntry, page repository.ArticlePage) int64 {
+	if page.SkipCount {
+		return 0
+	}
+	return int64(len(entries))
+}
@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type MemoryTrendingRepository struct {
+	store *Store
+}
+
+func NewMemoryTrendingRepository(store *Store) *MemoryTrendingRepository {
+	return &MemoryTrendingRepository{store: store}
+}
+
+// CountFavoritesByBand counts the favorites of each article by age band
+func (r *MemoryTrendingRepository) CountFavoritesByBand(db *gorm.DB, bounds []time.Time) ([]repository.ActivityCount, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts := newBandCounts(bounds)
+	for _, favorite := range r.store.favorites {
+		counts.add(favorite.ArticleID, favorite.CreatedAt)
+	}
+	return counts.list(), nil
+}
+
+// CountCommentsByBand counts the comments of each article by age band
+func (r *MemoryTrendingRepository) CountCommentsByBand(db *gorm.DB, bounds []time.Time) ([]repository.ActivityCount, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts := newBandCounts(bounds)
+	for _, comment := range r.store.comments {
+		counts.add(comment.ArticleID, comment.CreatedAt)
+	}
+	return counts.list(), nil
+}
+
+// ClaimTrendingRefresh moves the time of the last refresh forward unless it is after since
+func (r *MemoryTrendingRepository) ClaimTrendingRefresh(db *gorm.DB, now, since time.Time) (bool, error) {
+	defer r.store.lockWrite(db)()
+
+	if r.store.trendingRefreshedAt.After(since) {
+		return false, nil
+	}
+	r.store.trendingRefreshedAt = now
+	return true, nil
+}
+
+// bandCounts counts activity by article and age band
+type bandCounts struct {
+	bounds []time.Time
+	counts map[repository.ActivityCount]int64
+}
+
+func newBandCounts(bounds []time.Time) *bandCounts {
+	return &bandCounts{bounds: bounds, counts: make(map[repository.ActivityCount]int64)}
+}
+
+// add counts activity made at createdAt in the first band whose bound it is not before
+func (c *bandCounts) add(articleID int64, createdAt time.Time) {
+	for band, bound := range c.bounds {
+		if !createdAt.Before(bound) {
+			c.counts[repository.ActivityCount{ArticleID: articleID, Band: band}]++
+			return
+		}
+	}
+}
+
+func (c *bandCounts) list() []repository.ActivityCount {
+	counts := make([]repository.ActivityCount, 0, len(c.counts))
+	for key, count := range c.counts {
+		key.Count = count
+		counts = append(counts, key)
+	}
+	return counts
+}
+
+// ReplaceTrendingScores replaces the ranking of a window, skipping articles that no
+// longer exist
+func (r *MemoryTrendingRepository) ReplaceTrendingScores(db *gorm.DB, window string, scores []repository.TrendingScore, computedAt time.Time) error {
+	defer r.store.lockWrite(db)()
+
+	ranking := make(map[int64]*models.TrendingArticle, len(scores))
+	for _, score := range scores {
+		if _, ok := r.store.articles[score.ArticleID]; ok {
+			ranking[score.ArticleID] = &models.TrendingArticle{Span: window, ArticleID: score.ArticleID, Score: score.Score, ComputedAt: computedAt}
+		}
+	}
+	r.store.trendingArticles[window] = ranking
+	return nil
+}
+
+// ListTrending returns a page of the published articles ranked for a window
+func (r *MemoryTrendingRepository) ListTrending(db *gorm.DB, window string, limit, offset int) ([]int64, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ranked := make([]*models.TrendingArticle, 0, len(r.store.trendingArticles[window]))
+	for _, row := range r.store.trendingArticles[window] {
+		if article, ok := r.store.articles[row.ArticleID]; ok && article.Status == models.ArticleStatusPublished {
+			ranked = append(ranked, row)
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].ArticleID > ranked[j].ArticleID
+	})
+
+	ids := make([]int64, 0, limit)
+	for _, row := range pageRows(ranked, repository.ArticlePage{Limit: limit, Offset: offset}) {
+		ids = append(ids, row.ArticleID)
+	}
+	return ids, int64(len(ranked)), nil
+}
@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"time"
+
+	"go-gin-realworld-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type MemoryUserRepository struct {
+	store *Store
+}
+
+func NewMemoryUserRepository(store *Store) *MemoryUserRepository {
+	return &MemoryUserRepository{store: store}
+}
+
+// CreateUser creates a new user in the store
+func (r *MemoryUserRepository) CreateUser(db *gorm.DB, user *models.User) error {
+	defer r.store.lockWrite(db)()
+
+	if r.isTaken(user) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	user.ID = r.store.nextID("users")
+	touch(&user.CreatedAt, &user.UpdatedAt)
+	r.store.users[user.ID] = copyUser(user)
+	return nil
+}
+
+// FindUserByEmail finds a user by email
+func (r *MemoryUserRepository) FindUserByEmail(db *gorm.DB, email string) (*models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, user := range sortedByID(r.store.users) {
+		if user.Email == email {
+			return copyUser(user), nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// FindUserByID finds a user by ID
+func (r *MemoryUserRepository) FindUserByID(db *gorm.DB, id int64) (*models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	user, ok := r.store.users[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return copyUser(user), nil
+}
+
+// FindUserByUsername finds a user by username with optional profile preload
+func (r *MemoryUserRepository) FindUserByUsername(db *gorm.DB, username string, withProfile ...bool) (*models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	stored := r.store.userByUsername(username)
+	if stored == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	user := copyUser(stored)
+
+	// Check if preload profile flag is set (default: false)
+	if len(withProfile) > 0 && withProfile[0] {
+		for _, profile := range r.store.profiles {
+			if profile.UserID == user.ID {
+				profileCopy := *profile
+				profileCopy.User = nil
+				user.Profile = &profileCopy
+				break
+			}
+		}
+	}
+
+	return user, nil
+}
+
+// UpdateUser updates a user in the store (inserts it when it has no ID, like GORM Save)
+func (r *MemoryUserRepository) UpdateUser(db *gorm.DB, user *models.User) error {
+	if user.ID == 0 {
+		return r.CreateUser(db, user)
+	}
+
+	defer r.store.lockWrite(db)()
+
+	if r.isTaken(user) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	user.UpdatedAt = time.Now()
+	touch(&user.CreatedAt, nil)
+	r.store.users[user.ID] = copyUser(user)
+	return nil
+}
+
+// isTaken reports whether another user already owns the username or email (caller must hold the lock)
+func (r *MemoryUserRepository) isTaken(user *models.User) bool {
+	for _, existing := range r.store.users {
+		if existing.ID == user.ID {
+			continue
+		}
+		if existing.Username == user.Username || existing.Email == user.Email {
+			return true
+		}
+	}
+	return false
+}
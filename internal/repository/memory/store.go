@@ -0,0 +1,316 @@
+package memory
+
+import (
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// Store holds every table of the in-memory backend behind a single lock.
+// All memory repositories created from the same Store share its data.
+//
+// Transactions run one at a time: a transaction holds txMu from begin to end
+// and snapshots the tables, which rolling back restores. Writes outside of a
+// transaction wait for it to end, so a rollback never undoes them; reads do
+// not wait, and may see the writes of a transaction that has not ended yet.
+type Store struct {
+	mu sync.RWMutex
+
+	txMu sync.Mutex
+	// rollback is the snapshot of the running transaction, and savepoints those of its
+	// savepoints by name
+	rollback   *Store
+	savepoints map[string]*Store
+
+	lastID map[string]int64
+
+	users        map[int64]*models.User
+	profiles     map[int64]*models.Profile
+	follows      map[int64]*models.Follow
+	articles     map[int64]*models.Article
+	articleSlugs map[int64]*models.ArticleSlug
+	comments     map[int64]*models.Comment
+	favorites    map[int64]*models.Favorite
+	tags         map[int64]*models.Tag
+	articleTags  map[int64]*models.ArticleTag
+
+	timelineEntries map[int64]*models.TimelineEntry
+	// trendingArticles holds the trending ranking of each window, by article ID
+	trendingArticles map[string]map[int64]*models.TrendingArticle
+	// trendingRefreshedAt is when the trending rankings were last claimed for a refresh
+	trendingRefreshedAt time.Time
+}
+
+func NewStore() *Store {
+	return &Store{
+		lastID:       make(map[string]int64),
+		users:        make(map[int64]*models.User),
+		profiles:     make(map[int64]*models.Profile),
+		follows:      make(map[int64]*models.Follow),
+		articles:     make(map[int64]*models.Article),
+		articleSlugs: make(map[int64]*models.ArticleSlug),
+		comments:     make(map[int64]*models.Comment),
+		favorites:    make(map[int64]*models.Favorite),
+		tags:         make(map[int64]*models.Tag),
+		articleTags:  make(map[int64]*models.ArticleTag),
+
+		timelineEntries:  make(map[int64]*models.TimelineEntry),
+		trendingArticles: make(map[string]map[int64]*models.TrendingArticle),
+	}
+}
+
+// begin starts a transaction, waiting for the running one to end
+func (s *Store) begin() {
+	s.txMu.Lock()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.rollback = s.snapshot()
+	s.savepoints = make(map[string]*Store)
+}
+
+// end ends the running transaction, restoring the tables as they were when it began if
+// it rolls back
+func (s *Store) end(rollback bool) {
+	s.mu.Lock()
+	if rollback {
+		s.restore(s.rollback)
+	}
+	s.rollback, s.savepoints = nil, nil
+	s.mu.Unlock()
+	s.txMu.Unlock()
+}
+
+// savePoint snapshots the tables for a savepoint of the running transaction
+func (s *Store) savePoint(name string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.savepoints[name] = s.snapshot()
+}
+
+// rollbackTo restores the tables as they were at a savepoint of the running transaction
+func (s *Store) rollbackTo(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot, ok := s.savepoints[name]
+	if !ok {
+		return gorm.ErrInvalidTransaction
+	}
+	s.restore(snapshot)
+	return nil
+}
+
+// lockWrite takes the write lock for a write made through db and returns its unlock. A
+// write outside of a transaction first waits for the running one to end.
+func (s *Store) lockWrite(db *gorm.DB) func() {
+	if db != nil {
+		if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok {
+			s.mu.Lock()
+			return s.mu.Unlock
+		}
+	}
+	s.txMu.Lock()
+	s.mu.Lock()
+	return func() {
+		s.mu.Unlock()
+		s.txMu.Unlock()
+	}
+}
+
+// snapshot copies the tables and their rows (caller must hold the lock). Rows are copied
+// one level deep, as repositories replace the pointers of a row rather than write
+// through them.
+func (s *Store) snapshot() *Store {
+	trending := make(map[string]map[int64]*models.TrendingArticle, len(s.trendingArticles))
+	for window, ranking := range s.trendingArticles {
+		trending[window] = copyTable(ranking)
+	}
+	return &Store{
+		lastID:              maps.Clone(s.lastID),
+		users:               copyTable(s.users),
+		profiles:            copyTable(s.profiles),
+		follows:             copyTable(s.follows),
+		articles:            copyTable(s.articles),
+		articleSlugs:        copyTable(s.articleSlugs),
+		comments:            copyTable(s.comments),
+		favorites:           copyTable(s.favorites),
+		tags:                copyTable(s.tags),
+		articleTags:         copyTable(s.articleTags),
+		timelineEntries:     copyTable(s.timelineEntries),
+		trendingArticles:    trending,
+		trendingRefreshedAt: s.trendingRefreshedAt,
+	}
+}
+
+// restore puts back the tables of a snapshot (caller must hold the write lock). The
+// snapshot is copied again, as it may be restored more than once.
+func (s *Store) restore(snapshot *Store) {
+	tables := snapshot.snapshot()
+	s.lastID = tables.lastID
+	s.users = tables.users
+	s.profiles = tables.profiles
+	s.follows = tables.follows
+	s.articles = tables.articles
+	s.articleSlugs = tables.articleSlugs
+	s.comments = tables.comments
+	s.favorites = tables.favorites
+	s.tags = tables.tags
+	s.articleTags = tables.articleTags
+	s.timelineEntries = tables.timelineEntries
+	s.trendingArticles = tables.trendingArticles
+	s.trendingRefreshedAt = tables.trendingRefreshedAt
+}
+
+// copyTable copies a table and each of its rows
+func copyTable[T any](table map[int64]*T) map[int64]*T {
+	cp := make(map[int64]*T, len(table))
+	for id, row := range table {
+		rowCopy := *row
+		cp[id] = &rowCopy
+	}
+	return cp
+}
+
+// nextID returns the next auto-increment ID for a table (caller must hold the write lock)
+func (s *Store) nextID(table string) int64 {
+	s.lastID[table]++
+	return s.lastID[table]
+}
+
+// touch fills autoCreateTime/autoUpdateTime columns like GORM does
+func touch(createdAt, updatedAt *time.Time) {
+	now := time.Now()
+	if createdAt != nil && createdAt.IsZero() {
+		*createdAt = now
+	}
+	if updatedAt != nil && updatedAt.IsZero() {
+		*updatedAt = now
+	}
+}
+
+// userByUsername finds a stored user by username (caller must hold the lock)
+func (s *Store) userByUsername(username string) *models.User {
+	for _, user := range s.users {
+		if user.Username == username {
+			return user
+		}
+	}
+	return nil
+}
+
+// articleBySlug finds a stored article by slug (caller must hold the lock)
+func (s *Store) articleBySlug(slug string) *models.Article {
+	for _, article := range s.articles {
+		if article.Slug == slug {
+			return article
+		}
+	}
+	return nil
+}
+
+// tagByName finds a stored tag by name (caller must hold the lock)
+func (s *Store) tagByName(name string) *models.Tag {
+	for _, tag := range s.tags {
+		if tag.Name == name {
+			return tag
+		}
+	}
+	return nil
+}
+
+// slugOwner returns the ID of the article that has used a slug, or 0 (caller must hold the lock)
+func (s *Store) slugOwner(slug string) int64 {
+	for _, history := range s.articleSlugs {
+		if history.Slug == slug {
+			return history.ArticleID
+		}
+	}
+	return 0
+}
+
+// copyUser returns a detached copy of a stored user without associations
+func copyUser(user *models.User) *models.User {
+	if user == nil {
+		return nil
+	}
+	cp := *user
+	cp.Profile = nil
+	return &cp
+}
+
+// copyArticle returns a detached copy of a stored article with Author and
+// ArticleTags.Tag preloaded (caller must hold the lock)
+func (s *Store) copyArticle(article *models.Article) *models.Article {
+	cp := *article
+	cp.Author = copyUser(s.users[article.AuthorID])
+	cp.Comments = nil
+	cp.Favorites = nil
+
+	cp.ArticleTags = make([]*models.ArticleTag, 0)
+	for _, at := range sortedByID(s.articleTags) {
+		if at.ArticleID != article.ID {
+			continue
+		}
+		atCopy := *at
+		atCopy.Article = nil
+		if tag, ok := s.tags[at.TagID]; ok {
+			tagCopy := *tag
+			tagCopy.Articles = nil
+			atCopy.Tag = &tagCopy
+		}
+		cp.ArticleTags = append(cp.ArticleTags, &atCopy)
+	}
+
+	return &cp
+}
+
+// sortedByID returns the rows of a table in primary key order
+func sortedByID[T any](table map[int64]*T) []*T {
+	ids := make([]int64, 0, len(table))
+	for id := range table {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	rows := make([]*T, 0, len(ids))
+	for _, id := range ids {
+		rows = append(rows, table[id])
+	}
+	return rows
+}
+
+// sortOrder returns whether article a comes before article b in the order of a sort other
+// than newest first, or nil for newest first (caller must hold the lock)
+func (s *Store) sortOrder(order repository.ArticleSort) func(a, b *models.Article) bool {
+	// byThenID orders by the values of key, ties by descending ID
+	byThenID := func(key func(*models.Article) int64) func(a, b *models.Article) bool {
+		return func(a, b *models.Article) bool {
+			if ka, kb := key(a), key(b); ka != kb {
+				return ka > kb
+			}
+			return a.ID > b.ID
+		}
+	}
+
+	switch order {
+	case repository.ArticleSortOldest:
+		return func(a, b *models.Article) bool { return newerThan(articleKey(b), articleKey(a)) }
+	case repository.ArticleSortMostFavorited:
+		return byThenID(func(article *models.Article) int64 { return int64(article.FavoritesCount) })
+	case repository.ArticleSortMostCommented:
+		comments := make(map[int64]int64)
+		for _, comment := range s.comments {
+			comments[comment.ArticleID]++
+		}
+		return byThenID(func(article *models.Article) int64 { return comments[article.ID] })
+	case repository.ArticleSortRecentlyUpdated:
+		return byThenID(func(article *models.Article) int64 { return article.UpdatedAt.UnixNano() })
+	default:
+		return nil
+	}
+}
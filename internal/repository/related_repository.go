@@ -0,0 +1,22 @@
+package repository
+
+import "gorm.io/gorm"
+
+// RelatedArticle is an article related to another one, with how much they have in common:
+// the number of tags they share, or of users who favorited both
+type RelatedArticle struct {
+	ArticleID int64 `gorm:"column:article_id"`
+	Shared    int64 `gorm:"column:shared"`
+}
+
+// RelatedRepository finds the candidates for the related articles of an article. Both
+// methods return published articles only, leave out the article itself and, when
+// excludeAuthorID is set, the articles by that author, and return at most limit
+// articles, most in common first and ties to the newer article.
+type RelatedRepository interface {
+	// ListTagOverlaps returns the articles sharing tags with an article
+	ListTagOverlaps(db *gorm.DB, articleID int64, excludeAuthorID *int64, limit int) ([]RelatedArticle, error)
+	// ListCoFavorites returns the articles favorited by the last limit users who favorited
+	// an article, so that a popular article costs no more than any other
+	ListCoFavorites(db *gorm.DB, articleID int64, excludeAuthorID *int64, limit int) ([]RelatedArticle, error)
+}
@@ -0,0 +1,19 @@
+package sqlrepo
+
+import (
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm/clause"
+)
+
+// fulltextMatch uses the idx_articles_fulltext FULLTEXT index; in a WHERE clause it
+// selects matching rows and in ORDER BY it yields their relevance
+const fulltextMatch = "MATCH (articles.title, articles.description, articles.body) AGAINST (? IN NATURAL LANGUAGE MODE)"
+
+// MySqlFullText searches articles through MySQL's FULLTEXT index
+type MySqlFullText struct{}
+
+func (MySqlFullText) Match(search repository.ArticleSearch) (clause.Expr, clause.Expr, bool) {
+	match := clause.Expr{SQL: fulltextMatch, Vars: []interface{}{search.Query}}
+	return match, match, true
+}
@@ -0,0 +1,26 @@
+package sqlrepo
+
+import (
+	"strings"
+
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm/clause"
+)
+
+// searchDocument must match the expression of the idx_articles_fulltext GIN index.
+// Title matches weigh more than description matches, which weigh more than body matches.
+const searchDocument = "(setweight(to_tsvector('english', articles.title), 'A') || " +
+	"setweight(to_tsvector('english', articles.description), 'B') || " +
+	"setweight(to_tsvector('english', articles.body), 'C'))"
+
+// PostgresFullText searches articles through PostgreSQL's text search and GIN index
+type PostgresFullText struct{}
+
+func (PostgresFullText) Match(search repository.ArticleSearch) (clause.Expr, clause.Expr, bool) {
+	// Match any of the terms, like MySQL's natural language mode
+	tsquery := strings.Join(search.Terms, " | ")
+	where := clause.Expr{SQL: searchDocument + " @@ to_tsquery('english', ?)", Vars: []interface{}{tsquery}}
+	relevance := clause.Expr{SQL: "ts_rank(" + searchDocument + ", to_tsquery('english', ?))", Vars: []interface{}{tsquery}}
+	return where, relevance, true
+}
@@ -0,0 +1,35 @@
+package sqlrepo
+
+import (
+	"strings"
+
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm/clause"
+)
+
+// SqliteFullText searches articles in SQLite, which has no full-text index here, so every
+// article is scanned
+type SqliteFullText struct{}
+
+func (SqliteFullText) Match(search repository.ArticleSearch) (clause.Expr, clause.Expr, bool) {
+	if len(search.Terms) == 0 {
+		return clause.Expr{}, clause.Expr{}, false
+	}
+	relevance := relevanceExpr(search.Terms)
+	return clause.Expr{SQL: relevance.SQL + " > 0", Vars: relevance.Vars}, relevance, true
+}
+
+// relevanceExpr scores an article by the terms it contains, weighting title matches
+// over description matches over body matches
+func relevanceExpr(terms []string) clause.Expr {
+	parts := make([]string, 0, len(terms))
+	vars := make([]interface{}, 0, len(terms)*3)
+	for _, term := range terms {
+		parts = append(parts, "(instr(lower(articles.title), ?) > 0) * 3 + "+
+			"(instr(lower(articles.description), ?) > 0) * 2 + "+
+			"(instr(lower(articles.body), ?) > 0)")
+		vars = append(vars, term, term, term)
+	}
+	return clause.Expr{SQL: "(" + strings.Join(parts, " + ") + ")", Vars: vars}
+}
@@ -0,0 +1,366 @@
+package sqlrepo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+	"go-gin-realworld-api/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// SqlArticleRepository stores articles in any of the SQL databases; only full-text search
+// differs between them
+type SqlArticleRepository struct {
+	fullText FullText
+}
+
+func NewSqlArticleRepository(fullText FullText) *SqlArticleRepository {
+	return &SqlArticleRepository{fullText: fullText}
+}
+
+// ListArticles lists articles with optional filtering and pagination
+func (r *SqlArticleRepository) ListArticles(db *gorm.DB, filter repository.ArticleFilter, currentUserID *int64, page repository.ArticlePage) ([]*models.Article, int64, error) {
+	var articles []*models.Article
+	var total int64
+
+	query := filterArticles(db, filter)
+
+	// Filter by favorited (whether current user has favorited the article)
+	if filter.Favorited != nil && currentUserID != nil {
+		if *filter.Favorited {
+			// Get articles favorited by current user
+			query = query.
+				Joins("JOIN favorites ON favorites.article_id = articles.id").
+				Where("favorites.user_id = ?", *currentUserID)
+		} else {
+			// Get articles NOT favorited by current user
+			query = query.
+				Where("articles.id NOT IN (SELECT article_id FROM favorites WHERE user_id = ?)", *currentUserID)
+		}
+	}
+
+	// Get total count before pagination
+	if !page.SkipCount {
+		if err := query.Model(&models.Article{}).Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// Apply sorting and pagination
+	if err := paginateArticles(query, page).
+		Preload("Author").
+		Preload("ArticleTags.Tag").
+		Find(&articles).Error; err != nil {
+		return nil, 0, err
+	}
+	if page.Before != nil {
+		slices.Reverse(articles)
+	}
+
+	return articles, total, nil
+}
+
+// taggedArticles selects the IDs of the articles with any of the tags named in ?
+const taggedArticles = "SELECT article_tags.article_id FROM article_tags JOIN tags ON tags.id = article_tags.tag_id WHERE tags.name IN ?"
+
+// filterArticles restricts a query to the articles that match the tags, author, dates and
+// statuses of a filter. Tags are matched in subqueries rather than joins, so no article is
+// repeated.
+func filterArticles(query *gorm.DB, filter repository.ArticleFilter) *gorm.DB {
+	// Only published articles are listed unless other statuses are asked for
+	if len(filter.Statuses) > 0 {
+		query = query.Where("articles.status IN ?", filter.Statuses)
+	} else {
+		query = query.Where("articles.status = ?", models.ArticleStatusPublished)
+	}
+
+	// Filter by tags: an article with all the tags has one matching row for each
+	if len(filter.Tags) > 0 {
+		if filter.TagMode == repository.TagModeAny {
+			query = query.Where("articles.id IN ("+taggedArticles+")", filter.Tags)
+		} else {
+			query = query.Where("articles.id IN ("+taggedArticles+" GROUP BY article_tags.article_id HAVING COUNT(*) = ?)", filter.Tags, len(filter.Tags))
+		}
+	}
+	if len(filter.ExcludeTags) > 0 {
+		query = query.Where("articles.id NOT IN ("+taggedArticles+")", filter.ExcludeTags)
+	}
+
+	// Filter by author
+	if filter.Author != "" {
+		query = query.
+			Joins("JOIN users AS author_user ON author_user.id = articles.author_id").
+			Where("author_user.username = ?", filter.Author)
+	}
+	if filter.AuthorID != 0 {
+		query = query.Where("articles.author_id = ?", filter.AuthorID)
+	}
+
+	// Filter by creation date
+	if !filter.CreatedAfter.IsZero() {
+		query = query.Where("articles.created_at >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query = query.Where("articles.created_at < ?", filter.CreatedBefore)
+	}
+
+	return query
+}
+
+// articleSortOrders are the ORDER BY clauses of the sorts other than newest first.
+// Comments are counted per article, which idx_comments_article_id keeps cheap.
+var articleSortOrders = map[repository.ArticleSort]string{
+	repository.ArticleSortOldest:          "articles.created_at ASC, articles.id ASC",
+	repository.ArticleSortMostFavorited:   "articles.favorites_count DESC, articles.id DESC",
+	repository.ArticleSortMostCommented:   "(SELECT COUNT(*) FROM comments WHERE comments.article_id = articles.id) DESC, articles.id DESC",
+	repository.ArticleSortRecentlyUpdated: "articles.updated_at DESC, articles.id DESC",
+}
+
+// paginateArticles orders articles by the sort of a page, newest first by default, and
+// applies its cursor or offset. Before pages are read oldest first so that the rows
+// closest to the cursor are kept; callers reverse them.
+func paginateArticles(query *gorm.DB, page repository.ArticlePage) *gorm.DB {
+	if order, ok := articleSortOrders[page.Sort]; ok {
+		return query.Order(order).Limit(page.Limit).Offset(page.Offset)
+	}
+	return paginateNewestFirst(query, page, "articles.created_at", "articles.id")
+}
+
+// paginateNewestFirst is paginateArticles for rows keyed by other (created_at, id) columns
+func paginateNewestFirst(query *gorm.DB, page repository.ArticlePage, createdAtColumn, idColumn string) *gorm.DB {
+	switch {
+	case page.After != nil:
+		return query.
+			Where(fmt.Sprintf("(%[1]s < ? OR (%[1]s = ? AND %[2]s < ?))", createdAtColumn, idColumn), page.After.CreatedAt, page.After.CreatedAt, page.After.ID).
+			Order(fmt.Sprintf("%s DESC, %s DESC", createdAtColumn, idColumn)).
+			Limit(page.Limit)
+	case page.Before != nil:
+		return query.
+			Where(fmt.Sprintf("(%[1]s > ? OR (%[1]s = ? AND %[2]s > ?))", createdAtColumn, idColumn), page.Before.CreatedAt, page.Before.CreatedAt, page.Before.ID).
+			Order(fmt.Sprintf("%s ASC, %s ASC", createdAtColumn, idColumn)).
+			Limit(page.Limit)
+	default:
+		return query.
+			Order(fmt.Sprintf("%s DESC, %s DESC", createdAtColumn, idColumn)).
+			Limit(page.Limit).
+			Offset(page.Offset)
+	}
+}
+
+// FindArticleBySlug finds an article by slug
+func (r *SqlArticleRepository) FindArticleBySlug(db *gorm.DB, slug string) (*models.Article, error) {
+	var article *models.Article
+	if err := db.
+		Preload("Author").
+		Preload("ArticleTags.Tag").
+		Where("slug = ?", slug).
+		First(&article).Error; err != nil {
+		return nil, err
+	}
+	return article, nil
+}
+
+// FindArticlesByIDs returns the articles that exist among ids, in no particular order
+func (r *SqlArticleRepository) FindArticlesByIDs(db *gorm.DB, ids []int64) ([]*models.Article, error) {
+	var articles []*models.Article
+	if len(ids) == 0 {
+		return articles, nil
+	}
+	if err := db.
+		Preload("Author").
+		Preload("ArticleTags.Tag").
+		Where("articles.id IN ?", ids).
+		Find(&articles).Error; err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
+
+// FindCurrentSlug returns the current slug of the article that has used slug
+func (r *SqlArticleRepository) FindCurrentSlug(db *gorm.DB, slug string) (string, error) {
+	var slugs []string
+	if err := db.Table("article_slugs").
+		Joins("JOIN articles ON articles.id = article_slugs.article_id").
+		Where("article_slugs.slug = ?", slug).
+		Limit(1).
+		Pluck("articles.slug", &slugs).Error; err != nil {
+		return "", err
+	}
+	if len(slugs) == 0 {
+		return "", gorm.ErrRecordNotFound
+	}
+	return slugs[0], nil
+}
+
+// CreateArticle creates a new article at version 1, published unless it has a status, and
+// records its slug
+func (r *SqlArticleRepository) CreateArticle(db *gorm.DB, article *models.Article) error {
+	article.Version = 1
+	if article.Status == "" {
+		article.Status = models.ArticleStatusPublished
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(article).Error; err != nil {
+			return err
+		}
+		return recordSlug(tx, article)
+	})
+}
+
+// UpdateArticle writes the editable columns of an article, including its status and
+// dates, if nobody else has updated it since it was read, and records its slug if it
+// changed. The favorites count is left alone; it only changes through
+// FavoriteRepository.AdjustFavoritesCount.
+func (r *SqlArticleRepository) UpdateArticle(db *gorm.DB, article *models.Article) error {
+	article.UpdatedAt = time.Now()
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Article{}).
+			Where("id = ? AND version = ?", article.ID, article.Version).
+			Updates(map[string]interface{}{
+				"slug":         article.Slug,
+				"title":        article.Title,
+				"description":  article.Description,
+				"body":         article.Body,
+				"status":       article.Status,
+				"published_at": article.PublishedAt,
+				"created_at":   article.CreatedAt,
+				"updated_at":   article.UpdatedAt,
+				"version":      gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrVersionConflict
+		}
+		if err := recordSlug(tx, article); err != nil {
+			return err
+		}
+		article.Version++
+		return nil
+	})
+}
+
+// recordSlug adds the article's slug to its history. A slug once used by
+// another article is rejected with gorm.ErrDuplicatedKey.
+func recordSlug(tx *gorm.DB, article *models.Article) error {
+	var existing models.ArticleSlug
+	err := tx.Where("slug = ?", article.Slug).Take(&existing).Error
+	if err == nil {
+		if existing.ArticleID == article.ID {
+			return nil
+		}
+		return gorm.ErrDuplicatedKey
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return tx.Create(&models.ArticleSlug{ArticleID: article.ID, Slug: article.Slug}).Error
+}
+
+// DeleteArticleBySlug deletes an article by slug
+func (r *SqlArticleRepository) DeleteArticleBySlug(db *gorm.DB, slug string) error {
+	if err := db.Where("slug = ?", slug).Delete(&models.Article{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// AssignTagsToArticle replaces the tags of an article with the given tag names; no tag
+// names removes them all
+func (r *SqlArticleRepository) AssignTagsToArticle(db *gorm.DB, articleID int64, tagNames []string) error {
+	// Delete existing article tags
+	if err := db.Where("article_id = ?", articleID).Delete(&models.ArticleTag{}).Error; err != nil {
+		return err
+	}
+	if len(tagNames) == 0 {
+		return nil
+	}
+
+	// Find existing tags by name
+	var existingTags []*models.Tag
+	if err := db.Where("name IN ?", tagNames).Find(&existingTags).Error; err != nil {
+		return err
+	}
+
+	// Map existing tags by normalized name: a case-insensitive collation, like MySQL's,
+	// also finds a tag stored before names were normalized, such as "Go" for "go". A tag
+	// stored under the exact name wins over such a legacy one.
+	existingTagMap := make(map[string]*models.Tag)
+	for _, tag := range existingTags {
+		key := utils.NormalizeTag(tag.Name)
+		if _, exists := existingTagMap[key]; !exists || tag.Name == key {
+			existingTagMap[key] = tag
+		}
+	}
+
+	// Identify tags that need to be created
+	var tagsToCreate []*models.Tag
+	for _, tagName := range tagNames {
+		if _, exists := existingTagMap[utils.NormalizeTag(tagName)]; !exists {
+			tagsToCreate = append(tagsToCreate, &models.Tag{Name: tagName})
+		}
+	}
+
+	// Create new tags in bulk
+	if len(tagsToCreate) > 0 {
+		if err := db.CreateInBatches(tagsToCreate, 100).Error; err != nil {
+			return err
+		}
+		// Add newly created tags to the map
+		for _, tag := range tagsToCreate {
+			existingTagMap[utils.NormalizeTag(tag.Name)] = tag
+		}
+	}
+
+	// Create article tags in bulk
+	var articleTags []*models.ArticleTag
+	for _, tagName := range tagNames {
+		tag := existingTagMap[utils.NormalizeTag(tagName)]
+		articleTags = append(articleTags, &models.ArticleTag{
+			ArticleID: articleID,
+			TagID:     tag.ID,
+		})
+	}
+
+	if err := db.CreateInBatches(articleTags, 100).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListDueScheduled returns the IDs of the scheduled articles that are due
+func (r *SqlArticleRepository) ListDueScheduled(db *gorm.DB, now time.Time, limit int) ([]int64, error) {
+	ids := make([]int64, 0)
+	if err := db.Model(&models.Article{}).
+		Where("status = ? AND published_at <= ?", models.ArticleStatusScheduled, now).
+		Order("published_at, id").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// PublishScheduled publishes a scheduled article. The status check makes it safe to run
+// from several servers at once: only the first update of the row matches.
+func (r *SqlArticleRepository) PublishScheduled(db *gorm.DB, articleID int64) (bool, error) {
+	result := db.Model(&models.Article{}).
+		Where("id = ? AND status = ?", articleID, models.ArticleStatusScheduled).
+		Updates(map[string]interface{}{
+			"status":     models.ArticleStatusPublished,
+			"created_at": gorm.Expr("published_at"),
+			"updated_at": time.Now(),
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
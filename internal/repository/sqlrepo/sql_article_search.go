@@ -0,0 +1,49 @@
+package sqlrepo
+
+import (
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FullText is the database-specific part of article search
+type FullText interface {
+	// Match returns the condition selecting the articles that match a search and the
+	// expression ranking them, higher first. ok is false when no article can match.
+	Match(search repository.ArticleSearch) (where, relevance clause.Expr, ok bool)
+}
+
+// SearchArticles finds articles matching a full-text query, most relevant first
+func (r *SqlArticleRepository) SearchArticles(db *gorm.DB, search repository.ArticleSearch) ([]*models.Article, int64, error) {
+	var articles []*models.Article
+	var total int64
+
+	where, relevance, ok := r.fullText.Match(search)
+	if !ok {
+		return articles, 0, nil
+	}
+
+	query := filterArticles(db, search.Filter()).
+		Where(where)
+
+	if err := query.Model(&models.Article{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:  relevance.SQL + " DESC, articles.created_at DESC, articles.id DESC",
+			Vars: relevance.Vars,
+		}}).
+		Limit(search.Limit).
+		Offset(search.Offset).
+		Preload("Author").
+		Preload("ArticleTags.Tag").
+		Find(&articles).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return articles, total, nil
+}
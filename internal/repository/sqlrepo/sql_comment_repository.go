@@ -1,4 +1,4 @@
-package mysql
+package sqlrepo
 
 import (
 	"go-gin-realworld-api/internal/models"
@@ -6,15 +6,15 @@ import (
 	"gorm.io/gorm"
 )
 
-type MySqlCommentRepository struct {
+type SqlCommentRepository struct {
 }
 
-func NewMySqlCommentRepository() *MySqlCommentRepository {
-	return &MySqlCommentRepository{}
+func NewSqlCommentRepository() *SqlCommentRepository {
+	return &SqlCommentRepository{}
 }
 
 // CreateComment creates a new comment
-func (r *MySqlCommentRepository) CreateComment(db *gorm.DB, comment *models.Comment) error {
+func (r *SqlCommentRepository) CreateComment(db *gorm.DB, comment *models.Comment) error {
 	if err := db.Create(comment).Error; err != nil {
 		return err
 	}
@@ -22,7 +22,7 @@ func (r *MySqlCommentRepository) CreateComment(db *gorm.DB, comment *models.Comm
 }
 
 // GetCommentsByArticleID gets all comments for an article
-func (r *MySqlCommentRepository) GetCommentsByArticleID(db *gorm.DB, articleID int64) ([]*models.Comment, error) {
+func (r *SqlCommentRepository) GetCommentsByArticleID(db *gorm.DB, articleID int64) ([]*models.Comment, error) {
 	var comments []*models.Comment
 	if err := db.
 		Where("article_id = ?", articleID).
@@ -35,7 +35,7 @@ func (r *MySqlCommentRepository) GetCommentsByArticleID(db *gorm.DB, articleID i
 }
 
 // GetCommentByID gets a comment by ID with author and article preloaded
-func (r *MySqlCommentRepository) GetCommentByID(db *gorm.DB, id int64) (*models.Comment, error) {
+func (r *SqlCommentRepository) GetCommentByID(db *gorm.DB, id int64) (*models.Comment, error) {
 	var comment *models.Comment
 	if err := db.
 		Preload("Author").
@@ -48,7 +48,7 @@ func (r *MySqlCommentRepository) GetCommentByID(db *gorm.DB, id int64) (*models.
 }
 
 // DeleteComment deletes a comment by ID
-func (r *MySqlCommentRepository) DeleteComment(db *gorm.DB, id int64) error {
+func (r *SqlCommentRepository) DeleteComment(db *gorm.DB, id int64) error {
 	if err := db.Delete(&models.Comment{}, id).Error; err != nil {
 		return err
 	}
@@ -0,0 +1,109 @@
+package sqlrepo
+
+import (
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type SqlFavoriteRepository struct {
+}
+
+func NewSqlFavoriteRepository() *SqlFavoriteRepository {
+	return &SqlFavoriteRepository{}
+}
+
+// AddFavorite adds an article to user's favorites
+func (r *SqlFavoriteRepository) AddFavorite(db *gorm.DB, userID, articleID int64) error {
+	favorite := &models.Favorite{
+		UserID:    userID,
+		ArticleID: articleID,
+	}
+	return db.Create(favorite).Error
+}
+
+// RemoveFavorite removes an article from user's favorites and reports whether it was there
+func (r *SqlFavoriteRepository) RemoveFavorite(db *gorm.DB, userID, articleID int64) (bool, error) {
+	result := db.Where("user_id = ? AND article_id = ?", userID, articleID).Delete(&models.Favorite{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// IsFavorited checks if user has favorited an article
+func (r *SqlFavoriteRepository) IsFavorited(db *gorm.DB, userID, articleID int64) (bool, error) {
+	var count int64
+	err := db.Model(&models.Favorite{}).Where("user_id = ? AND article_id = ?", userID, articleID).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// FavoritedArticleIDs returns which of the given articles the user has favorited
+func (r *SqlFavoriteRepository) FavoritedArticleIDs(db *gorm.DB, userID int64, articleIDs []int64) ([]int64, error) {
+	ids := make([]int64, 0)
+	if len(articleIDs) == 0 {
+		return ids, nil
+	}
+	err := db.Model(&models.Favorite{}).
+		Where("user_id = ? AND article_id IN ?", userID, articleIDs).
+		Pluck("article_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetArticle gets an article with its author and tags
+func (r *SqlFavoriteRepository) GetArticle(db *gorm.DB, articleID int64) (*models.Article, error) {
+	var article *models.Article
+	err := db.
+		Preload("Author").
+		Preload("ArticleTags.Tag").
+		Where("id = ?", articleID).
+		First(&article).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return article, nil
+}
+
+// AdjustFavoritesCount adds delta to the favorites count in a single UPDATE, so concurrent
+// changes are not lost. It neither touches updated_at nor lets the count go negative.
+func (r *SqlFavoriteRepository) AdjustFavoritesCount(db *gorm.DB, articleID int64, delta int) error {
+	return db.Model(&models.Article{}).
+		Where("id = ? AND COALESCE(favorites_count, 0) + ? >= 0", articleID, delta).
+		UpdateColumn("favorites_count", gorm.Expr("COALESCE(favorites_count, 0) + ?", delta)).Error
+}
+
+// ReconcileFavoritesCounts recomputes the favorites count of every article from the favorites table
+func (r *SqlFavoriteRepository) ReconcileFavoritesCounts(db *gorm.DB) ([]repository.FavoritesCountDrift, error) {
+	drifts := make([]repository.FavoritesCountDrift, 0)
+	err := db.Table("articles").
+		Select("articles.id AS article_id, articles.slug, COALESCE(articles.favorites_count, 0) AS stored, COUNT(favorites.id) AS actual").
+		Joins("LEFT JOIN favorites ON favorites.article_id = articles.id").
+		Group("articles.id, articles.slug, articles.favorites_count").
+		Having("COALESCE(articles.favorites_count, 0) <> COUNT(favorites.id)").
+		Order("articles.id").
+		Scan(&drifts).Error
+	if err != nil || len(drifts) == 0 {
+		return drifts, err
+	}
+
+	ids := make([]int64, 0, len(drifts))
+	for _, drift := range drifts {
+		ids = append(ids, drift.ArticleID)
+	}
+	// Count again in the UPDATE so favorites added since the scan are included
+	err = db.Model(&models.Article{}).
+		Where("id IN ?", ids).
+		UpdateColumn("favorites_count", gorm.Expr("(SELECT COUNT(*) FROM favorites WHERE favorites.article_id = articles.id)")).Error
+	if err != nil {
+		return nil, err
+	}
+	return drifts, nil
+}
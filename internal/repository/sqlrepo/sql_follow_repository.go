@@ -0,0 +1,48 @@
+package sqlrepo
+
+import (
+	"go-gin-realworld-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type SqlFollowRepository struct {
+}
+
+func NewSqlFollowRepository() *SqlFollowRepository {
+	return &SqlFollowRepository{}
+}
+
+// CreateFollow creates a follow relationship
+func (r *SqlFollowRepository) CreateFollow(db *gorm.DB, follow *models.Follow) error {
+	if err := db.Create(follow).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteFollow deletes a follow relationship
+func (r *SqlFollowRepository) DeleteFollow(db *gorm.DB, followerID, followeeID int64) error {
+	if err := db.Where("follower_id = ? AND followee_id = ?", followerID, followeeID).Delete(&models.Follow{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// IsFollowing checks if a user follows another user
+func (r *SqlFollowRepository) IsFollowing(db *gorm.DB, followerID, followeeID int64) (bool, error) {
+	var count int64
+	if err := db.Model(&models.Follow{}).Where("follower_id = ? AND followee_id = ?", followerID, followeeID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CountFollowers counts the followers of a user
+func (r *SqlFollowRepository) CountFollowers(db *gorm.DB, userID int64) (int64, error) {
+	var count int64
+	if err := db.Model(&models.Follow{}).Where("followee_id = ?", userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -1,4 +1,4 @@
-package mysql
+package sqlrepo
 
 import (
 	"go-gin-realworld-api/internal/models"
@@ -6,15 +6,15 @@ import (
 	"gorm.io/gorm"
 )
 
-type MySqlProfileRepository struct {
+type SqlProfileRepository struct {
 }
 
-func NewMySqlProfileRepository() *MySqlProfileRepository {
-	return &MySqlProfileRepository{}
+func NewSqlProfileRepository() *SqlProfileRepository {
+	return &SqlProfileRepository{}
 }
 
 // CreateProfile creates a new profile in the database
-func (r *MySqlProfileRepository) CreateProfile(db *gorm.DB, profile *models.Profile) error {
+func (r *SqlProfileRepository) CreateProfile(db *gorm.DB, profile *models.Profile) error {
 	if err := db.Create(profile).Error; err != nil {
 		return err
 	}
@@ -22,7 +22,7 @@ func (r *MySqlProfileRepository) CreateProfile(db *gorm.DB, profile *models.Prof
 }
 
 // FindProfileByUserID finds a profile by user ID
-func (r *MySqlProfileRepository) FindProfileByUserID(db *gorm.DB, userID int64) (*models.Profile, error) {
+func (r *SqlProfileRepository) FindProfileByUserID(db *gorm.DB, userID int64) (*models.Profile, error) {
 	var profile *models.Profile
 	if err := db.Where("user_id = ?", userID).First(&profile).Error; err != nil {
 		return nil, err
@@ -31,7 +31,7 @@ func (r *MySqlProfileRepository) FindProfileByUserID(db *gorm.DB, userID int64)
 }
 
 // UpdateProfile updates a profile in the database
-func (r *MySqlProfileRepository) UpdateProfile(db *gorm.DB, profile *models.Profile) error {
+func (r *SqlProfileRepository) UpdateProfile(db *gorm.DB, profile *models.Profile) error {
 	if err := db.Save(profile).Error; err != nil {
 		return err
 	}
@@ -0,0 +1,59 @@
+package sqlrepo
+
+import (
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type SqlRelatedRepository struct {
+}
+
+func NewSqlRelatedRepository() *SqlRelatedRepository {
+	return &SqlRelatedRepository{}
+}
+
+// ListTagOverlaps returns the articles sharing the most tags with an article
+func (r *SqlRelatedRepository) ListTagOverlaps(db *gorm.DB, articleID int64, excludeAuthorID *int64, limit int) ([]repository.RelatedArticle, error) {
+	query := db.Table("article_tags AS source").
+		Joins("JOIN article_tags AS related ON related.tag_id = source.tag_id").
+		Where("source.article_id = ? AND related.article_id <> ?", articleID, articleID)
+	return r.listRelated(query, excludeAuthorID, limit)
+}
+
+// ListCoFavorites returns the articles most often favorited by the last limit users who
+// favorited an article. They are picked in a derived table rather than an IN subquery,
+// which MySQL does not allow to have a LIMIT.
+func (r *SqlRelatedRepository) ListCoFavorites(db *gorm.DB, articleID int64, excludeAuthorID *int64, limit int) ([]repository.RelatedArticle, error) {
+	favoriters := db.Table("favorites").
+		Select("user_id").
+		Where("article_id = ?", articleID).
+		Order("id DESC").
+		Limit(limit)
+	query := db.Table("(?) AS source", favoriters).
+		Joins("JOIN favorites AS related ON related.user_id = source.user_id").
+		Where("related.article_id <> ?", articleID)
+	return r.listRelated(query, excludeAuthorID, limit)
+}
+
+// listRelated counts the rows of query, a join of the source article's rows to related
+// ones, per related published article
+func (r *SqlRelatedRepository) listRelated(query *gorm.DB, excludeAuthorID *int64, limit int) ([]repository.RelatedArticle, error) {
+	query = query.Joins("JOIN articles ON articles.id = related.article_id").
+		Where("articles.status = ?", models.ArticleStatusPublished)
+	if excludeAuthorID != nil {
+		query = query.Where("articles.author_id <> ?", *excludeAuthorID)
+	}
+
+	related := make([]repository.RelatedArticle, 0)
+	if err := query.
+		Select("related.article_id AS article_id, COUNT(*) AS shared").
+		Group("related.article_id").
+		Order("shared DESC, related.article_id DESC").
+		Limit(limit).
+		Scan(&related).Error; err != nil {
+		return nil, err
+	}
+	return related, nil
+}
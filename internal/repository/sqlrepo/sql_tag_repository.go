@@ -0,0 +1,135 @@
+package sqlrepo
+
+import (
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type SqlTagRepository struct {
+}
+
+func NewSqlTagRepository() *SqlTagRepository {
+	return &SqlTagRepository{}
+}
+
+// GetAllTags retrieves the tags of published articles from the database
+func (r *SqlTagRepository) GetAllTags(db *gorm.DB) ([]string, error) {
+	var tags []string
+	if err := db.
+		Model(&models.Tag{}).
+		Distinct().
+		Joins("JOIN article_tags ON article_tags.tag_id = tags.id").
+		Joins("JOIN articles ON articles.id = article_tags.article_id AND articles.status = ?", models.ArticleStatusPublished).
+		Pluck("tags.name", &tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// ListTagCounts returns every tag with the number of articles that use it
+func (r *SqlTagRepository) ListTagCounts(db *gorm.DB, sort repository.TagSort, limit int) ([]repository.TagCount, error) {
+	query := db.Model(&models.Tag{}).
+		Select("tags.name, COUNT(articles.id) AS articles").
+		Joins("LEFT JOIN article_tags ON article_tags.tag_id = tags.id").
+		Joins("LEFT JOIN articles ON articles.id = article_tags.article_id AND articles.status = ?", models.ArticleStatusPublished).
+		Group("tags.id, tags.name")
+	if sort == repository.TagSortPopular {
+		query = query.Order("articles DESC")
+	}
+	query = query.Order("tags.name")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	counts := make([]repository.TagCount, 0)
+	if err := query.Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// SuggestTags returns the most used tags that start with a prefix
+func (r *SqlTagRepository) SuggestTags(db *gorm.DB, prefix string, limit int) ([]string, error) {
+	names := make([]string, 0)
+	err := db.Model(&models.Tag{}).
+		Select("tags.name").
+		Joins("LEFT JOIN article_tags ON article_tags.tag_id = tags.id").
+		Joins("LEFT JOIN articles ON articles.id = article_tags.article_id AND articles.status = ?", models.ArticleStatusPublished).
+		Where("LOWER(tags.name) LIKE ? ESCAPE '!'", repository.EscapeLike(prefix)+"%").
+		Group("tags.id, tags.name").
+		Order("COUNT(articles.id) DESC").
+		Order("tags.name").
+		Limit(limit).
+		Pluck("tags.name", &names).Error
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// RenameTag renames a tag
+func (r *SqlTagRepository) RenameTag(db *gorm.DB, from, to string) error {
+	result := db.Model(&models.Tag{}).Where("name = ?", from).Update("name", to)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// MergeTags moves the articles of one tag to another and deletes the first
+func (r *SqlTagRepository) MergeTags(db *gorm.DB, from, into string) (int64, error) {
+	var merged int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var source, target models.Tag
+		if err := tx.Where("name = ?", from).Take(&source).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("name = ?", into).Take(&target).Error; err != nil {
+			return err
+		}
+
+		// Moving the tag of an article that already has the target would violate
+		// idx_article_tags, so drop it instead. MySQL cannot select from the table it
+		// deletes from, hence the derived table.
+		tagged := tx.Table("(?) AS tagged", tx.Model(&models.ArticleTag{}).Select("article_id").Where("tag_id = ?", target.ID)).
+			Select("article_id")
+		dropped := tx.Where("tag_id = ? AND article_id IN (?)", source.ID, tagged).Delete(&models.ArticleTag{})
+		if dropped.Error != nil {
+			return dropped.Error
+		}
+		moved := tx.Model(&models.ArticleTag{}).Where("tag_id = ?", source.ID).Update("tag_id", target.ID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		merged = dropped.RowsAffected + moved.RowsAffected
+
+		return tx.Delete(&source).Error
+	})
+	return merged, err
+}
+
+// DeleteUnusedTags deletes the tags no article uses
+func (r *SqlTagRepository) DeleteUnusedTags(db *gorm.DB) ([]string, error) {
+	// Checked again on delete, in case a tag got an article in between
+	const unused = "NOT EXISTS (SELECT 1 FROM article_tags WHERE article_tags.tag_id = tags.id)"
+
+	names := make([]string, 0)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Tag{}).Where(unused).Order("name").Pluck("name", &names).Error; err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			return nil
+		}
+		return tx.Where("name IN ?", names).Where(unused).Delete(&models.Tag{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
@@ -0,0 +1,178 @@
+package sqlrepo
+
+import (
+	"slices"
+
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// timelineBatchSize is how many timeline entries are inserted per statement
+const timelineBatchSize = 500
+
+type SqlTimelineRepository struct {
+}
+
+func NewSqlTimelineRepository() *SqlTimelineRepository {
+	return &SqlTimelineRepository{}
+}
+
+// FanOutArticle adds an article to the timeline of every follower of its author
+func (r *SqlTimelineRepository) FanOutArticle(db *gorm.DB, article *models.Article) error {
+	var followerIDs []int64
+	if err := db.Model(&models.Follow{}).Where("followee_id = ?", article.AuthorID).Pluck("follower_id", &followerIDs).Error; err != nil {
+		return err
+	}
+
+	entries := make([]*models.TimelineEntry, 0, len(followerIDs))
+	for _, followerID := range followerIDs {
+		entries = append(entries, &models.TimelineEntry{
+			UserID:    followerID,
+			ArticleID: article.ID,
+			AuthorID:  article.AuthorID,
+			CreatedAt: article.CreatedAt,
+		})
+	}
+	return insertTimelineEntries(db, entries)
+}
+
+// BackfillTimeline adds the fanned-out published articles of an author to a new follower's timeline
+func (r *SqlTimelineRepository) BackfillTimeline(db *gorm.DB, userID, authorID int64) error {
+	var articles []*models.Article
+	if err := db.Select("id", "author_id", "created_at").
+		Where("author_id = ? AND fan_out_on_read = ? AND status = ?", authorID, false, models.ArticleStatusPublished).
+		Find(&articles).Error; err != nil {
+		return err
+	}
+
+	entries := make([]*models.TimelineEntry, 0, len(articles))
+	for _, article := range articles {
+		entries = append(entries, &models.TimelineEntry{
+			UserID:    userID,
+			ArticleID: article.ID,
+			AuthorID:  article.AuthorID,
+			CreatedAt: article.CreatedAt,
+		})
+	}
+	return insertTimelineEntries(db, entries)
+}
+
+// insertTimelineEntries inserts entries in batches, skipping the ones already present
+func insertTimelineEntries(db *gorm.DB, entries []*models.TimelineEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(entries, timelineBatchSize).Error
+}
+
+// PruneTimeline removes the articles of an author from a user's timeline
+func (r *SqlTimelineRepository) PruneTimeline(db *gorm.DB, userID, authorID int64) error {
+	return db.Where("user_id = ? AND author_id = ?", userID, authorID).Delete(&models.TimelineEntry{}).Error
+}
+
+// RemoveArticle removes an article from every timeline
+func (r *SqlTimelineRepository) RemoveArticle(db *gorm.DB, articleID int64) error {
+	return db.Where("article_id = ?", articleID).Delete(&models.TimelineEntry{}).Error
+}
+
+// ListTimeline returns a page of a user's timeline entries that match the filter, newest
+// first, and their total
+func (r *SqlTimelineRepository) ListTimeline(db *gorm.DB, userID int64, filter repository.FeedFilter, page repository.ArticlePage) ([]*models.TimelineEntry, int64, error) {
+	var entries []*models.TimelineEntry
+	var total int64
+
+	query := db.Model(&models.TimelineEntry{}).Where("timeline_entries.user_id = ?", userID)
+	query = filterCreatedAt(query, filter, "timeline_entries.created_at")
+
+	if !page.SkipCount {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if err := paginateNewestFirst(query, page, "timeline_entries.created_at", "timeline_entries.article_id").
+		Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+	if page.Before != nil {
+		slices.Reverse(entries)
+	}
+
+	return entries, total, nil
+}
+
+// ListFanOutOnRead returns a page of the published articles with FanOutOnRead set by authors the
+// user follows that match the filter, as timeline entries, newest first, and their total
+func (r *SqlTimelineRepository) ListFanOutOnRead(db *gorm.DB, userID int64, filter repository.FeedFilter, page repository.ArticlePage) ([]*models.TimelineEntry, int64, error) {
+	var entries []*models.TimelineEntry
+	var total int64
+
+	query := db.Table("articles").
+		Joins("JOIN follows ON follows.followee_id = articles.author_id").
+		Where("follows.follower_id = ? AND articles.fan_out_on_read = ? AND articles.status = ?", userID, true, models.ArticleStatusPublished)
+	query = filterCreatedAt(query, filter, "articles.created_at")
+
+	if !page.SkipCount {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if err := paginateArticles(query, page).
+		Select("follows.follower_id AS user_id, articles.id AS article_id, articles.author_id, articles.created_at").
+		Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+	if page.Before != nil {
+		slices.Reverse(entries)
+	}
+
+	return entries, total, nil
+}
+
+// ListFeed returns a page of a user's timeline entries and articles read on demand that match
+// the filter, in the order of page.Sort, and their total. Both sources are matched in
+// subqueries, so the articles can be sorted and paged by offset in one query.
+func (r *SqlTimelineRepository) ListFeed(db *gorm.DB, userID int64, filter repository.FeedFilter, page repository.ArticlePage) ([]*models.TimelineEntry, int64, error) {
+	var entries []*models.TimelineEntry
+	var total int64
+
+	timeline := db.Model(&models.TimelineEntry{}).Select("article_id").Where("user_id = ?", userID)
+	onRead := db.Table("follows").Select("followee_id").Where("follower_id = ?", userID)
+	query := db.Table("articles").
+		Where("articles.status = ?", models.ArticleStatusPublished).
+		Where("articles.id IN (?) OR (articles.fan_out_on_read = ? AND articles.author_id IN (?))", timeline, true, onRead)
+	query = filterCreatedAt(query, filter, "articles.created_at")
+
+	if !page.SkipCount {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if err := paginateArticles(query, page).
+		Select("articles.id AS article_id, articles.author_id, articles.created_at").
+		Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+	for _, entry := range entries {
+		entry.UserID = userID
+	}
+
+	return entries, total, nil
+}
+
+// filterCreatedAt restricts a query to the rows whose creation time, in column, is within
+// the range of a feed filter
+func filterCreatedAt(query *gorm.DB, filter repository.FeedFilter, column string) *gorm.DB {
+	if !filter.CreatedAfter.IsZero() {
+		query = query.Where(column+" >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query = query.Where(column+" < ?", filter.CreatedBefore)
+	}
+	return query
+}
@@ -0,0 +1,116 @@
+package sqlrepo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// trendingRefreshID is the single row of trending_refresh, inserted by its migration
+const trendingRefreshID = 1
+
+type SqlTrendingRepository struct {
+}
+
+func NewSqlTrendingRepository() *SqlTrendingRepository {
+	return &SqlTrendingRepository{}
+}
+
+// CountFavoritesByBand counts the favorites of each article by age band
+func (r *SqlTrendingRepository) CountFavoritesByBand(db *gorm.DB, bounds []time.Time) ([]repository.ActivityCount, error) {
+	return countByBand(db.Model(&models.Favorite{}), bounds)
+}
+
+// CountCommentsByBand counts the comments of each article by age band
+func (r *SqlTrendingRepository) CountCommentsByBand(db *gorm.DB, bounds []time.Time) ([]repository.ActivityCount, error) {
+	return countByBand(db.Model(&models.Comment{}), bounds)
+}
+
+// countByBand groups the rows of a table with article_id and created_at columns by article
+// and age band. The band is a CASE over plain comparisons with the bounds, which every
+// database indexes and compares like the created_at values it stores.
+func countByBand(query *gorm.DB, bounds []time.Time) ([]repository.ActivityCount, error) {
+	counts := make([]repository.ActivityCount, 0)
+	if len(bounds) == 0 {
+		return counts, nil
+	}
+
+	var band strings.Builder
+	band.WriteString("CASE")
+	vars := make([]interface{}, 0, len(bounds))
+	for i, bound := range bounds {
+		fmt.Fprintf(&band, " WHEN created_at >= ? THEN %d", i)
+		vars = append(vars, bound)
+	}
+	band.WriteString(" END")
+
+	if err := query.
+		Select("article_id, "+band.String()+" AS band, COUNT(*) AS count", vars...).
+		Where("created_at >= ?", bounds[len(bounds)-1]).
+		Group("article_id, band").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// ClaimTrendingRefresh moves the trending_refresh row forward. The refreshed_at check makes
+// it safe to run from several servers at once: only the first update of the row matches.
+func (r *SqlTrendingRepository) ClaimTrendingRefresh(db *gorm.DB, now, since time.Time) (bool, error) {
+	result := db.Model(&models.TrendingRefresh{}).
+		Where("id = ? AND refreshed_at <= ?", trendingRefreshID, since).
+		Update("refreshed_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ReplaceTrendingScores replaces the ranking of a window in one transaction, so readers
+// see either the old ranking or the new one
+func (r *SqlTrendingRepository) ReplaceTrendingScores(db *gorm.DB, window string, scores []repository.TrendingScore, computedAt time.Time) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("span = ?", window).Delete(&models.TrendingArticle{}).Error; err != nil {
+			return err
+		}
+		if len(scores) == 0 {
+			return nil
+		}
+
+		rows := make([]*models.TrendingArticle, 0, len(scores))
+		for _, score := range scores {
+			rows = append(rows, &models.TrendingArticle{Span: window, ArticleID: score.ArticleID, Score: score.Score, ComputedAt: computedAt})
+		}
+		return tx.CreateInBatches(rows, 500).Error
+	})
+}
+
+// ListTrending returns a page of the published articles ranked for a window
+func (r *SqlTrendingRepository) ListTrending(db *gorm.DB, window string, limit, offset int) ([]int64, int64, error) {
+	// An article unpublished since the last refresh keeps its score until the next one
+	ranking := func() *gorm.DB {
+		return db.Model(&models.TrendingArticle{}).
+			Joins("JOIN articles ON articles.id = trending_articles.article_id").
+			Where("trending_articles.span = ? AND articles.status = ?", window, models.ArticleStatusPublished)
+	}
+
+	var total int64
+	if err := ranking().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]int64, 0)
+	if err := ranking().
+		Order("trending_articles.score DESC, trending_articles.article_id DESC").
+		Limit(limit).
+		Offset(offset).
+		Pluck("trending_articles.article_id", &ids).Error; err != nil {
+		return nil, 0, err
+	}
+	return ids, total, nil
+}
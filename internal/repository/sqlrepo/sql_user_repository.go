@@ -1,4 +1,4 @@
-package mysql
+package sqlrepo
 
 import (
 	"go-gin-realworld-api/internal/models"
@@ -6,15 +6,15 @@ import (
 	"gorm.io/gorm"
 )
 
-type MySqlUserRepository struct {
+type SqlUserRepository struct {
 }
 
-func NewMySqlUserRepository() *MySqlUserRepository {
-	return &MySqlUserRepository{}
+func NewSqlUserRepository() *SqlUserRepository {
+	return &SqlUserRepository{}
 }
 
 // CreateUser creates a new user in the database
-func (r *MySqlUserRepository) CreateUser(db *gorm.DB, user *models.User) error {
+func (r *SqlUserRepository) CreateUser(db *gorm.DB, user *models.User) error {
 	if err := db.Create(user).Error; err != nil {
 		return err
 	}
@@ -22,7 +22,7 @@ func (r *MySqlUserRepository) CreateUser(db *gorm.DB, user *models.User) error {
 }
 
 // FindUserByEmail finds a user by email
-func (r *MySqlUserRepository) FindUserByEmail(db *gorm.DB, email string) (*models.User, error) {
+func (r *SqlUserRepository) FindUserByEmail(db *gorm.DB, email string) (*models.User, error) {
 	var user *models.User
 	if err := db.Where("email = ?", email).First(&user).Error; err != nil {
 		return nil, err
@@ -31,7 +31,7 @@ func (r *MySqlUserRepository) FindUserByEmail(db *gorm.DB, email string) (*model
 }
 
 // FindUserByID finds a user by ID
-func (r *MySqlUserRepository) FindUserByID(db *gorm.DB, id int64) (*models.User, error) {
+func (r *SqlUserRepository) FindUserByID(db *gorm.DB, id int64) (*models.User, error) {
 	var user *models.User
 	if err := db.Where("id = ?", id).First(&user).Error; err != nil {
 		return nil, err
@@ -40,7 +40,7 @@ func (r *MySqlUserRepository) FindUserByID(db *gorm.DB, id int64) (*models.User,
 }
 
 // FindUserByUsername finds a user by username with optional profile preload
-func (r *MySqlUserRepository) FindUserByUsername(db *gorm.DB, username string, withProfile ...bool) (*models.User, error) {
+func (r *SqlUserRepository) FindUserByUsername(db *gorm.DB, username string, withProfile ...bool) (*models.User, error) {
 	var user *models.User
 	query := db
 
@@ -56,7 +56,7 @@ func (r *MySqlUserRepository) FindUserByUsername(db *gorm.DB, username string, w
 }
 
 // UpdateUser updates a user in the database
-func (r *MySqlUserRepository) UpdateUser(db *gorm.DB, user *models.User) error {
+func (r *SqlUserRepository) UpdateUser(db *gorm.DB, user *models.User) error {
 	if err := db.Save(user).Error; err != nil {
 		return err
 	}
@@ -1,9 +1,45 @@
 package repository
 
 import (
+	"strings"
+
 	"gorm.io/gorm"
 )
 
+// TagSort orders the tags returned by ListTagCounts
+type TagSort string
+
+const (
+	TagSortName    TagSort = "name"    // alphabetically
+	TagSortPopular TagSort = "popular" // most used first, ties alphabetically
+)
+
+// TagCount is a tag with the number of articles that use it
+type TagCount struct {
+	Name     string `gorm:"column:name"`
+	Articles int64  `gorm:"column:articles"`
+}
+
 type TagRepository interface {
+	// GetAllTags returns the names of the tags used by at least one published article
 	GetAllTags(db *gorm.DB) ([]string, error)
+	// ListTagCounts returns every tag, unused ones included, with its number of published
+	// articles. A limit <= 0 returns all tags.
+	ListTagCounts(db *gorm.DB, sort TagSort, limit int) ([]TagCount, error)
+	// SuggestTags returns up to limit tags whose lowercased name starts with prefix, most
+	// used first, ties alphabetically. prefix is matched literally, wildcards included.
+	SuggestTags(db *gorm.DB, prefix string, limit int) ([]string, error)
+	// RenameTag renames a tag. A missing tag is gorm.ErrRecordNotFound and a name that
+	// is taken gorm.ErrDuplicatedKey.
+	RenameTag(db *gorm.DB, from, to string) error
+	// MergeTags moves the articles of tag from to tag into and deletes from. An article
+	// that has both keeps only into. It returns the number of articles that had from.
+	MergeTags(db *gorm.DB, from, into string) (int64, error)
+	// DeleteUnusedTags deletes the tags no article uses and returns their names
+	DeleteUnusedTags(db *gorm.DB) ([]string, error)
+}
+
+// EscapeLike escapes the wildcards of a LIKE pattern, for use with ESCAPE '!'
+func EscapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
 }
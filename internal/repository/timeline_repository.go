@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"time"
+
+	"go-gin-realworld-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// TimelineRepository maintains the materialized personal feeds, which hold published
+// articles only. Entries of a deleted article or user are removed by cascade.
+type TimelineRepository interface {
+	// FanOutArticle adds an article to the timeline of every follower of its author
+	FanOutArticle(db *gorm.DB, article *models.Article) error
+	// BackfillTimeline adds the fanned-out published articles of an author to a new
+	// follower's timeline
+	BackfillTimeline(db *gorm.DB, userID, authorID int64) error
+	// PruneTimeline removes the articles of an author from a user's timeline
+	PruneTimeline(db *gorm.DB, userID, authorID int64) error
+	// RemoveArticle removes an article that is no longer published from every timeline
+	RemoveArticle(db *gorm.DB, articleID int64) error
+	// ListTimeline returns a page of a user's timeline entries that match the filter, newest
+	// first, and their total
+	ListTimeline(db *gorm.DB, userID int64, filter FeedFilter, page ArticlePage) ([]*models.TimelineEntry, int64, error)
+	// ListFanOutOnRead returns the same page for the published articles with FanOutOnRead
+	// set by authors the user follows, which have no timeline entries
+	ListFanOutOnRead(db *gorm.DB, userID int64, filter FeedFilter, page ArticlePage) ([]*models.TimelineEntry, int64, error)
+	// ListFeed returns a page of a user's whole feed, timeline entries and articles read on
+	// demand alike, in the order of page.Sort, and its total. Newest-first pages are read
+	// with ListTimeline and ListFanOutOnRead instead, which follow the timeline index.
+	ListFeed(db *gorm.DB, userID int64, filter FeedFilter, page ArticlePage) ([]*models.TimelineEntry, int64, error)
+}
+
+// FeedFilter restricts a feed to the articles created within a range. CreatedAfter is
+// inclusive and CreatedBefore exclusive, like in ArticleFilter.
+type FeedFilter struct {
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
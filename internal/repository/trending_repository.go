@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ActivityCount is the number of favorites or comments of an article made within an age
+// band, the band being an index into the bounds it was counted with
+type ActivityCount struct {
+	ArticleID int64 `gorm:"column:article_id"`
+	Band      int   `gorm:"column:band"`
+	Count     int64 `gorm:"column:count"`
+}
+
+// TrendingScore is the score of an article in a trending ranking
+type TrendingScore struct {
+	ArticleID int64
+	Score     float64
+}
+
+// TrendingRepository reads the activity trending scores are computed from and stores the
+// precomputed rankings, one per window. Scores of a deleted article are removed by cascade.
+type TrendingRepository interface {
+	// CountFavoritesByBand counts the favorites of each article by age band, in no
+	// particular order. Band i holds those made at or after bounds[i] and before
+	// bounds[i-1]; bounds go back in time and favorites before the last are not counted.
+	CountFavoritesByBand(db *gorm.DB, bounds []time.Time) ([]ActivityCount, error)
+	// CountCommentsByBand counts the comments of each article by age band, like
+	// CountFavoritesByBand
+	CountCommentsByBand(db *gorm.DB, bounds []time.Time) ([]ActivityCount, error)
+	// ClaimTrendingRefresh records a refresh at now unless one was recorded after since,
+	// and reports whether it did. Of servers claiming at the same time, only one succeeds.
+	ClaimTrendingRefresh(db *gorm.DB, now, since time.Time) (bool, error)
+	// ReplaceTrendingScores replaces the ranking of a window with scores
+	ReplaceTrendingScores(db *gorm.DB, window string, scores []TrendingScore, computedAt time.Time) error
+	// ListTrending returns a page of the IDs of the published articles ranked for a window,
+	// highest score first and ties to the newer article, and the number of them
+	ListTrending(db *gorm.DB, window string, limit, offset int) ([]int64, int64, error)
+}
@@ -11,6 +11,7 @@ import (
 func SetupRoutes(router *gin.Engine, appContainer *bootstrap.AppContainer) {
 	// Health check endpoint
 	router.GET("/health", handlers.HealthCheck)
+	router.GET("/health/cache", appContainer.CacheHandler.GetCacheStats) // Hit/miss statistics of the in-process caches
 
 	// API v1 routes
 	api := router.Group("/api")
@@ -26,8 +27,10 @@ func SetupRoutes(router *gin.Engine, appContainer *bootstrap.AppContainer) {
 		user := api.Group("/user")
 		user.Use(middleware.JWTAuthMiddleware())
 		{
-			user.GET("", appContainer.UserHandler.GetCurrentUser) // Get current user
-			user.PUT("", appContainer.UserHandler.UpdateUser)     // Update current user
+			user.GET("", appContainer.UserHandler.GetCurrentUser)       // Get current user
+			user.PUT("", appContainer.UserHandler.UpdateUser)           // Update current user
+			user.PATCH("", appContainer.UserHandler.PatchUser)          // Merge-patch current user
+			user.GET("/drafts", appContainer.ArticleHandler.ListDrafts) // Unpublished articles of the current user
 		}
 		// Profile routes
 		profiles := api.Group("/profiles")
@@ -38,18 +41,24 @@ func SetupRoutes(router *gin.Engine, appContainer *bootstrap.AppContainer) {
 		} // Article routes
 		articles := api.Group("/articles")
 		{
-			articles.GET("", middleware.JWTOptionalAuthMiddleware(), appContainer.ArticleHandler.ListArticles)     // List articles
-			articles.GET("/feed", middleware.JWTAuthMiddleware(), appContainer.ArticleHandler.FeedArticles)        // Get feed (auth required)
-			articles.GET("/:slug", middleware.JWTOptionalAuthMiddleware(), appContainer.ArticleHandler.GetArticle) // Get article by slug
-			articles.POST("", middleware.JWTAuthMiddleware(), appContainer.ArticleHandler.CreateArticle)           // Create article (auth required)
-			articles.PUT("/:slug", middleware.JWTAuthMiddleware(), appContainer.ArticleHandler.UpdateArticle)      // Update article (auth required)
-			articles.DELETE("/:slug", middleware.JWTAuthMiddleware(), appContainer.ArticleHandler.DeleteArticle)   // Delete article (auth required)
+			articles.GET("", middleware.JWTOptionalAuthMiddleware(), appContainer.ArticleHandler.ListArticles)               // List articles
+			articles.GET("/feed", middleware.JWTAuthMiddleware(), appContainer.ArticleHandler.FeedArticles)                  // Get feed (auth required)
+			articles.GET("/search", middleware.JWTOptionalAuthMiddleware(), appContainer.ArticleHandler.SearchArticles)      // Full-text search
+			articles.GET("/trending", middleware.JWTOptionalAuthMiddleware(), appContainer.TrendingHandler.TrendingArticles) // Trending articles
+			articles.GET("/:slug", middleware.JWTOptionalAuthMiddleware(), appContainer.ArticleHandler.GetArticle)           // Get article by slug
+			articles.POST("", middleware.JWTAuthMiddleware(), appContainer.ArticleHandler.CreateArticle)                     // Create article (auth required)
+			articles.PUT("/:slug", middleware.JWTAuthMiddleware(), appContainer.ArticleHandler.UpdateArticle)                // Update article (auth required)
+			articles.PATCH("/:slug", middleware.JWTAuthMiddleware(), appContainer.ArticleHandler.PatchArticle)               // Merge-patch article (auth required)
+			articles.DELETE("/:slug", middleware.JWTAuthMiddleware(), appContainer.ArticleHandler.DeleteArticle)             // Delete article (auth required)
 
 			// Comments
 			articles.POST("/:slug/comments", middleware.JWTAuthMiddleware(), appContainer.CommentHandler.CreateComment)       // Add comment (auth required)
 			articles.GET("/:slug/comments", middleware.JWTOptionalAuthMiddleware(), appContainer.CommentHandler.GetComments)  // Get comments (optional auth)
 			articles.DELETE("/:slug/comments/:id", middleware.JWTAuthMiddleware(), appContainer.CommentHandler.DeleteComment) // Delete comment (auth required)
 
+			// Related articles
+			articles.GET("/:slug/related", middleware.JWTOptionalAuthMiddleware(), appContainer.RelatedHandler.RelatedArticles) // Related articles (optional auth)
+
 			// Favorites
 			articles.POST("/:slug/favorite", middleware.JWTAuthMiddleware(), appContainer.FavoriteHandler.FavoriteArticle)     // Favorite (auth required)
 			articles.DELETE("/:slug/favorite", middleware.JWTAuthMiddleware(), appContainer.FavoriteHandler.UnfavoriteArticle) // Unfavorite (auth required)
@@ -57,5 +66,6 @@ func SetupRoutes(router *gin.Engine, appContainer *bootstrap.AppContainer) {
 
 		// Tags
 		api.GET("/tags", appContainer.TagHandler.GetTags)
+		api.GET("/tags/suggest", appContainer.TagHandler.SuggestTags)
 	}
 }
@@ -0,0 +1,38 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopwords are common English words that carry no meaning for search
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"but": true, "by": true, "for": true, "from": true, "has": true, "have": true,
+	"he": true, "her": true, "his": true, "i": true, "if": true, "in": true, "into": true,
+	"is": true, "it": true, "its": true, "my": true, "no": true, "not": true, "of": true,
+	"on": true, "or": true, "our": true, "she": true, "so": true, "such": true, "that": true,
+	"the": true, "their": true, "then": true, "there": true, "these": true, "they": true,
+	"this": true, "to": true, "was": true, "we": true, "were": true, "will": true,
+	"with": true, "you": true, "your": true,
+}
+
+// Tokenize splits text into lowercased words of letters and digits
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// Analyze turns text into index terms: its words without stopwords, stemmed
+func Analyze(text string) []string {
+	words := Tokenize(text)
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		if stopwords[word] {
+			continue
+		}
+		terms = append(terms, Stem(word))
+	}
+	return terms
+}
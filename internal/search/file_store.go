@@ -0,0 +1,174 @@
+package search
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Files of a persisted index
+const (
+	snapshotFile = "snapshot.json"
+	journalFile  = "journal.jsonl"
+)
+
+// Journal operations
+const (
+	opIndex  = "index"
+	opDelete = "delete"
+)
+
+// journalEntry is one line of the journal: a document indexed or deleted after the snapshot
+type journalEntry struct {
+	Op  string    `json:"op"`
+	Doc *Document `json:"doc,omitempty"`
+	ID  int64     `json:"id,omitempty"`
+}
+
+// fileStore persists the documents of an index. Writes are not fsynced: the database
+// remains the source of truth and a lost change is repaired by rebuilding the index.
+type fileStore struct {
+	dir     string
+	journal *os.File
+}
+
+// openFileStore loads the documents persisted in dir and compacts the journal into the snapshot
+func openFileStore(dir string) (*fileStore, []Document, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, nil, err
+	}
+
+	docs, err := readSnapshot(filepath.Join(dir, snapshotFile))
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := replayJournal(filepath.Join(dir, journalFile), docs); err != nil {
+		return nil, nil, err
+	}
+
+	store := &fileStore{dir: dir}
+	list := make([]Document, 0, len(docs))
+	for _, doc := range docs {
+		list = append(list, doc)
+	}
+	if err := store.reset(list); err != nil {
+		return nil, nil, err
+	}
+	return store, list, nil
+}
+
+// append writes an entry to the journal
+func (s *fileStore) append(entry journalEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = s.journal.Write(append(line, '\n'))
+	return err
+}
+
+// reset writes a new snapshot and starts an empty journal
+func (s *fileStore) reset(docs []Document) error {
+	if err := writeSnapshot(s.dir, docs); err != nil {
+		return err
+	}
+
+	if s.journal != nil {
+		if err := s.journal.Close(); err != nil {
+			return err
+		}
+	}
+	journal, err := os.OpenFile(filepath.Join(s.dir, journalFile), os.O_CREATE|os.O_TRUNC|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	s.journal = journal
+	return nil
+}
+
+func (s *fileStore) close() error {
+	return s.journal.Close()
+}
+
+// readSnapshot returns the documents of a snapshot by ID; a missing snapshot is empty
+func readSnapshot(path string) (map[int64]Document, error) {
+	docs := make(map[int64]Document)
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return docs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var list []Document
+	if err := json.NewDecoder(file).Decode(&list); err != nil {
+		return nil, fmt.Errorf("read search index snapshot: %w", err)
+	}
+	for _, doc := range list {
+		docs[doc.ID] = doc
+	}
+	return docs, nil
+}
+
+// replayJournal applies the journal to docs. A partial last line, left by a crash
+// during a write, is ignored.
+func replayJournal(path string, docs map[int64]Document) error {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for lineNo := 1; ; lineNo++ {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var entry journalEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return fmt.Errorf("read search index journal line %d: %w", lineNo, err)
+		}
+		switch {
+		case entry.Op == opIndex && entry.Doc != nil:
+			docs[entry.Doc.ID] = *entry.Doc
+		case entry.Op == opDelete:
+			delete(docs, entry.ID)
+		default:
+			return fmt.Errorf("read search index journal line %d: unknown operation %q", lineNo, entry.Op)
+		}
+	}
+}
+
+// writeSnapshot atomically replaces the snapshot in dir
+func writeSnapshot(dir string, docs []Document) error {
+	tmp, err := os.CreateTemp(dir, snapshotFile+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := json.NewEncoder(tmp).Encode(docs); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, snapshotFile))
+}
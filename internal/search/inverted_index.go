@@ -0,0 +1,260 @@
+package search
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// BM25 parameters: k1 limits how much repeated terms add, b how much long documents are penalized
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Field weights: a word in the title counts as much as three in the body
+const (
+	titleWeight       = 3
+	descriptionWeight = 2
+	bodyWeight        = 1
+)
+
+// InvertedIndex is an in-process SearchIndex that ranks documents with BM25.
+// When opened on a directory it persists its documents there (see OpenInvertedIndex).
+type InvertedIndex struct {
+	mu          sync.RWMutex
+	docs        map[int64]*indexedDoc
+	postings    map[string]map[int64]float64 // term -> document ID -> weighted term frequency
+	totalLength float64
+	store       *fileStore // nil when the index lives in memory only
+	closed      bool
+}
+
+type indexedDoc struct {
+	doc    Document
+	terms  map[string]float64
+	length float64
+}
+
+// NewInvertedIndex creates an empty index that is not persisted
+func NewInvertedIndex() *InvertedIndex {
+	return &InvertedIndex{
+		docs:     make(map[int64]*indexedDoc),
+		postings: make(map[string]map[int64]float64),
+	}
+}
+
+// OpenInvertedIndex opens the index persisted in dir, creating the directory if needed.
+// Documents are kept in a snapshot plus a journal of later changes; the journal is
+// folded into the snapshot here and on Reset.
+func OpenInvertedIndex(dir string) (*InvertedIndex, error) {
+	store, docs, err := openFileStore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := NewInvertedIndex()
+	for _, doc := range docs {
+		idx.add(doc)
+	}
+	idx.store = store
+	return idx, nil
+}
+
+// Index adds a document or replaces the one with the same ID
+func (idx *InvertedIndex) Index(doc Document) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if idx.closed {
+		return ErrIndexClosed
+	}
+	if idx.store != nil {
+		if err := idx.store.append(journalEntry{Op: opIndex, Doc: &doc}); err != nil {
+			return err
+		}
+	}
+	idx.remove(doc.ID)
+	idx.add(doc)
+	return nil
+}
+
+// Delete removes a document; deleting a missing document is not an error
+func (idx *InvertedIndex) Delete(id int64) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if idx.closed {
+		return ErrIndexClosed
+	}
+	if _, ok := idx.docs[id]; !ok {
+		return nil
+	}
+	if idx.store != nil {
+		if err := idx.store.append(journalEntry{Op: opDelete, ID: id}); err != nil {
+			return err
+		}
+	}
+	idx.remove(id)
+	return nil
+}
+
+// Search returns a page of hits, most relevant first, and the total number of hits.
+// A Limit <= 0 returns every hit after Offset.
+func (idx *InvertedIndex) Search(query Query) ([]Hit, int, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	if idx.closed {
+		return nil, 0, ErrIndexClosed
+	}
+
+	terms := Analyze(query.Text)
+	slices.Sort(terms)
+	terms = slices.Compact(terms)
+	if len(terms) == 0 || len(idx.docs) == 0 {
+		return []Hit{}, 0, nil
+	}
+
+	n := float64(len(idx.docs))
+	avgLength := idx.totalLength / n
+	scores := make(map[int64]float64)
+	for _, term := range terms {
+		postings := idx.postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+
+		df := float64(len(postings))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, tf := range postings {
+			doc := idx.docs[id]
+			if !doc.matches(query) {
+				continue
+			}
+			norm := 1 - bm25B + bm25B*doc.length/avgLength
+			scores[id] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{ID: id, Score: score})
+	}
+	// Ties go to the newer (higher ID) document
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID > hits[j].ID
+	})
+
+	total := len(hits)
+	start := min(max(query.Offset, 0), total)
+	end := total
+	if query.Limit > 0 && start+query.Limit < end {
+		end = start + query.Limit
+	}
+	return hits[start:end], total, nil
+}
+
+// Reset replaces the whole contents of the index
+func (idx *InvertedIndex) Reset(docs []Document) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if idx.closed {
+		return ErrIndexClosed
+	}
+	if idx.store != nil {
+		if err := idx.store.reset(docs); err != nil {
+			return err
+		}
+	}
+
+	idx.docs = make(map[int64]*indexedDoc, len(docs))
+	idx.postings = make(map[string]map[int64]float64)
+	idx.totalLength = 0
+	for _, doc := range docs {
+		idx.remove(doc.ID)
+		idx.add(doc)
+	}
+	return nil
+}
+
+// Close closes the journal; the index cannot be used afterwards
+func (idx *InvertedIndex) Close() error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if idx.closed {
+		return nil
+	}
+	idx.closed = true
+	if idx.store != nil {
+		return idx.store.close()
+	}
+	return nil
+}
+
+// Len returns the number of indexed documents
+func (idx *InvertedIndex) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.docs)
+}
+
+// add indexes a document that is not in the index (caller must hold the write lock)
+func (idx *InvertedIndex) add(doc Document) {
+	entry := &indexedDoc{doc: doc, terms: make(map[string]float64)}
+	for _, field := range []struct {
+		text   string
+		weight float64
+	}{
+		{doc.Title, titleWeight},
+		{doc.Description, descriptionWeight},
+		{doc.Body, bodyWeight},
+	} {
+		for _, term := range Analyze(field.text) {
+			entry.terms[term] += field.weight
+			entry.length += field.weight
+		}
+	}
+
+	idx.docs[doc.ID] = entry
+	idx.totalLength += entry.length
+	for term, tf := range entry.terms {
+		postings, ok := idx.postings[term]
+		if !ok {
+			postings = make(map[int64]float64)
+			idx.postings[term] = postings
+		}
+		postings[doc.ID] = tf
+	}
+}
+
+// remove drops a document from the index if present (caller must hold the write lock)
+func (idx *InvertedIndex) remove(id int64) {
+	entry, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+
+	for term := range entry.terms {
+		delete(idx.postings[term], id)
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	idx.totalLength -= entry.length
+	delete(idx.docs, id)
+}
+
+// matches applies the tag and author filters of a query
+func (d *indexedDoc) matches(query Query) bool {
+	if query.Author != "" && d.doc.Author != query.Author {
+		return false
+	}
+	return query.Tag == "" || slices.Contains(d.doc.Tags, query.Tag)
+}
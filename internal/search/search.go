@@ -0,0 +1,45 @@
+// Package search provides article search that needs no database-specific features.
+package search
+
+import "errors"
+
+var ErrIndexClosed = errors.New("search index is closed")
+
+// Document is the searchable content of an article
+type Document struct {
+	ID          int64    `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Body        string   `json:"body"`
+	Author      string   `json:"author"`
+	Tags        []string `json:"tags"`
+}
+
+// Query selects documents matching any word of Text, optionally restricted to a tag and an author
+type Query struct {
+	Text   string
+	Tag    string
+	Author string
+	Limit  int
+	Offset int
+}
+
+// Hit is a matching document and its relevance score
+type Hit struct {
+	ID    int64
+	Score float64
+}
+
+// SearchIndex keeps a searchable copy of the articles
+type SearchIndex interface {
+	// Index adds a document or replaces the one with the same ID
+	Index(doc Document) error
+	// Delete removes a document; deleting a missing document is not an error
+	Delete(id int64) error
+	// Search returns a page of hits, most relevant first, and the total number of hits
+	Search(query Query) ([]Hit, int, error)
+	// Reset replaces the whole contents of the index
+	Reset(docs []Document) error
+	// Close releases the resources of the index
+	Close() error
+}
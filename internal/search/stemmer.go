@@ -0,0 +1,226 @@
+package search
+
+import "bytes"
+
+// Stem reduces an English word to its stem with the Porter stemming algorithm, so that
+// "connected", "connecting" and "connections" all become "connect". Words that are not
+// plain lowercase ASCII, or that are shorter than three letters, are returned unchanged.
+func Stem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+
+	s := &stemmer{b: []byte(word)}
+	s.step1a()
+	s.step1b()
+	s.step1c()
+	s.step2()
+	s.step3()
+	s.step4()
+	s.step5()
+	return string(s.b)
+}
+
+// suffixRule replaces a suffix when the measure of the remaining stem is high enough
+type suffixRule struct {
+	suffix      string
+	replacement string
+}
+
+// Longer suffixes come before shorter ones that they end with
+var step2Rules = []suffixRule{
+	{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"},
+	{"izer", "ize"}, {"bli", "ble"}, {"alli", "al"}, {"entli", "ent"}, {"eli", "e"},
+	{"ousli", "ous"}, {"ization", "ize"}, {"ation", "ate"}, {"ator", "ate"},
+	{"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"}, {"ousness", "ous"},
+	{"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"}, {"logi", "log"},
+}
+
+var step3Rules = []suffixRule{
+	{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"},
+	{"ical", "ic"}, {"ful", ""}, {"ness", ""},
+}
+
+var step4Suffixes = []string{
+	"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment", "ent",
+	"ion", "ou", "ism", "ate", "iti", "ous", "ive", "ize",
+}
+
+type stemmer struct {
+	b []byte
+}
+
+// isConsonant reports whether b[i] is a consonant; y is one unless it follows a consonant
+func (s *stemmer) isConsonant(i int) bool {
+	switch s.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !s.isConsonant(i-1)
+	}
+	return true
+}
+
+// measure returns m in the form [C](VC)^m[V] of b[:end]
+func (s *stemmer) measure(end int) int {
+	m, i := 0, 0
+	for i < end && s.isConsonant(i) {
+		i++
+	}
+	for i < end {
+		for i < end && !s.isConsonant(i) {
+			i++
+		}
+		if i >= end {
+			break
+		}
+		for i < end && s.isConsonant(i) {
+			i++
+		}
+		m++
+	}
+	return m
+}
+
+// hasVowel reports whether b[:end] contains a vowel
+func (s *stemmer) hasVowel(end int) bool {
+	for i := 0; i < end; i++ {
+		if !s.isConsonant(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// endsDoubleConsonant reports whether b[:end] ends with two identical consonants
+func (s *stemmer) endsDoubleConsonant(end int) bool {
+	return end >= 2 && s.b[end-1] == s.b[end-2] && s.isConsonant(end-1)
+}
+
+// endsCVC reports whether b[:end] ends consonant-vowel-consonant, the last not w, x or y
+func (s *stemmer) endsCVC(end int) bool {
+	if end < 3 || !s.isConsonant(end-3) || s.isConsonant(end-2) || !s.isConsonant(end-1) {
+		return false
+	}
+	last := s.b[end-1]
+	return last != 'w' && last != 'x' && last != 'y'
+}
+
+func (s *stemmer) hasSuffix(suffix string) bool {
+	return bytes.HasSuffix(s.b, []byte(suffix))
+}
+
+// stemEnd is the length of the word without the suffix
+func (s *stemmer) stemEnd(suffix string) int {
+	return len(s.b) - len(suffix)
+}
+
+func (s *stemmer) replace(suffix, replacement string) {
+	s.b = append(s.b[:s.stemEnd(suffix)], replacement...)
+}
+
+// applyRules replaces the first matching suffix if the remaining stem has a measure above minMeasure
+func (s *stemmer) applyRules(rules []suffixRule, minMeasure int) {
+	for _, rule := range rules {
+		if s.hasSuffix(rule.suffix) {
+			if s.measure(s.stemEnd(rule.suffix)) > minMeasure {
+				s.replace(rule.suffix, rule.replacement)
+			}
+			return
+		}
+	}
+}
+
+// step1a removes plurals: caresses -> caress, ponies -> poni, cats -> cat
+func (s *stemmer) step1a() {
+	switch {
+	case s.hasSuffix("sses"):
+		s.replace("sses", "ss")
+	case s.hasSuffix("ies"):
+		s.replace("ies", "i")
+	case s.hasSuffix("ss"):
+	case s.hasSuffix("s"):
+		s.replace("s", "")
+	}
+}
+
+// step1b removes -ed and -ing: agreed -> agree, motoring -> motor, hopping -> hop
+func (s *stemmer) step1b() {
+	removed := false
+	switch {
+	case s.hasSuffix("eed"):
+		if s.measure(s.stemEnd("eed")) > 0 {
+			s.replace("eed", "ee")
+		}
+	case s.hasSuffix("ed") && s.hasVowel(s.stemEnd("ed")):
+		s.replace("ed", "")
+		removed = true
+	case s.hasSuffix("ing") && s.hasVowel(s.stemEnd("ing")):
+		s.replace("ing", "")
+		removed = true
+	}
+	if !removed {
+		return
+	}
+
+	end := len(s.b)
+	switch {
+	case s.hasSuffix("at"), s.hasSuffix("bl"), s.hasSuffix("iz"):
+		s.b = append(s.b, 'e')
+	case s.endsDoubleConsonant(end):
+		if last := s.b[end-1]; last != 'l' && last != 's' && last != 'z' {
+			s.b = s.b[:end-1]
+		}
+	case s.measure(end) == 1 && s.endsCVC(end):
+		s.b = append(s.b, 'e')
+	}
+}
+
+// step1c turns a terminal y into i when there is another vowel: happy -> happi
+func (s *stemmer) step1c() {
+	if s.hasSuffix("y") && s.hasVowel(s.stemEnd("y")) {
+		s.b[len(s.b)-1] = 'i'
+	}
+}
+
+// step2 maps double suffixes to single ones: relational -> relate
+func (s *stemmer) step2() {
+	s.applyRules(step2Rules, 0)
+}
+
+// step3 handles -ic-, -full, -ness etc.: electrical -> electric
+func (s *stemmer) step3() {
+	s.applyRules(step3Rules, 0)
+}
+
+// step4 removes the remaining suffixes of longer stems: adjustable -> adjust
+func (s *stemmer) step4() {
+	for _, suffix := range step4Suffixes {
+		if !s.hasSuffix(suffix) {
+			continue
+		}
+		end := s.stemEnd(suffix)
+		if s.measure(end) > 1 && (suffix != "ion" || (end > 0 && (s.b[end-1] == 's' || s.b[end-1] == 't'))) {
+			s.b = s.b[:end]
+		}
+		return
+	}
+}
+
+// step5 removes a final e and reduces a final double l: probate -> probat, controll -> control
+func (s *stemmer) step5() {
+	if s.hasSuffix("e") {
+		end := s.stemEnd("e")
+		if m := s.measure(end); m > 1 || (m == 1 && !s.endsCVC(end)) {
+			s.b = s.b[:end]
+		}
+	}
+	if end := len(s.b); s.measure(end) > 1 && s.endsDoubleConsonant(end) && s.b[end-1] == 'l' {
+		s.b = s.b[:end-1]
+	}
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go-gin-realworld-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// publishBatchSize is how many due articles PublishDueArticles reads at a time
+const publishBatchSize = 100
+
+// PublishDueArticles publishes the scheduled articles whose publication time has come and
+// returns how many it published. Schedules live in the database, so articles that fell due
+// while no server was running are published on the next run. Each article is published in
+// its own transaction, together with its fan-out, by an update that only matches a
+// scheduled article, so servers running this at the same time never publish one twice.
+func (s *ArticleService) PublishDueArticles(ctx context.Context) (int, error) {
+	db := s.db.WithContext(ctx)
+	published := 0
+	for {
+		ids, err := s.articleRepo.ListDueScheduled(db, time.Now(), publishBatchSize)
+		if err != nil {
+			return published, err
+		}
+		for _, id := range ids {
+			ok, err := s.publishScheduled(db, id)
+			if err != nil {
+				return published, err
+			}
+			if ok {
+				published++
+			}
+		}
+		// A published article is no longer scheduled, so the next batch holds new ones
+		if len(ids) < publishBatchSize {
+			return published, nil
+		}
+	}
+}
+
+// publishScheduled publishes a scheduled article and reports whether this call did
+func (s *ArticleService) publishScheduled(db *gorm.DB, articleID int64) (bool, error) {
+	var article *models.Article
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		published, err := s.articleRepo.PublishScheduled(tx, articleID)
+		if err != nil || !published {
+			return err
+		}
+
+		articles, err := s.articleRepo.FindArticlesByIDs(tx, []int64{articleID})
+		if err != nil || len(articles) == 0 {
+			return err
+		}
+		article = articles[0]
+		if !article.FanOutOnRead {
+			return s.timelineRepo.FanOutArticle(tx, article)
+		}
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	if article == nil {
+		return false, nil
+	}
+	s.updateSearchIndex(article)
+	return true, nil
+}
+
+// RunScheduler publishes the due articles now and then every interval until ctx is done.
+// A failed run is logged and retried on the next tick.
+func (s *ArticleService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := s.PublishDueArticles(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to publish scheduled articles: %v", err)
+		} else if n > 0 {
+			log.Printf("Published %d scheduled articles", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,45 +1,214 @@
 package services
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"go-gin-realworld-api/internal/dtos"
+	appErrors "go-gin-realworld-api/internal/errors"
 	"go-gin-realworld-api/internal/models"
 	"go-gin-realworld-api/internal/repository"
+	"go-gin-realworld-api/internal/search"
 	"go-gin-realworld-api/internal/utils"
 
 	"gorm.io/gorm"
 )
 
+// maxSlugAttempts bounds how many candidate slugs are tried before giving up with ErrSlugConflict
+const maxSlugAttempts = 8
+
+// searchSnippetLength is the approximate length in bytes of the excerpt shown with a search result
+const searchSnippetLength = 160
+
+// reindexBatchSize is how many articles RebuildSearchIndex reads at a time
+const reindexBatchSize = 500
+
+// defaultFanOutMaxFollowers is the follower count above which an author's new articles
+// are no longer copied to every follower's timeline
+const defaultFanOutMaxFollowers = 10000
+
 type ArticleService struct {
-	db          *gorm.DB
-	articleRepo repository.ArticleRepository
+	db                 *gorm.DB
+	readDB             *gorm.DB // replica pool for reads that tolerate lag
+	articleRepo        repository.ArticleRepository
+	favoriteRepo       repository.FavoriteRepository
+	followRepo         repository.FollowRepository
+	timelineRepo       repository.TimelineRepository
+	searchIndex        search.SearchIndex // nil to search with the database
+	fanOutMaxFollowers int64
 }
 
-func NewArticleService(db *gorm.DB, articleRepo repository.ArticleRepository) *ArticleService {
+func NewArticleService(db, readDB *gorm.DB, articleRepo repository.ArticleRepository, favoriteRepo repository.FavoriteRepository, followRepo repository.FollowRepository, timelineRepo repository.TimelineRepository, searchIndex search.SearchIndex) *ArticleService {
 	return &ArticleService{
-		db:          db,
-		articleRepo: articleRepo,
+		db:                 db,
+		readDB:             readDB,
+		articleRepo:        articleRepo,
+		favoriteRepo:       favoriteRepo,
+		followRepo:         followRepo,
+		timelineRepo:       timelineRepo,
+		searchIndex:        searchIndex,
+		fanOutMaxFollowers: defaultFanOutMaxFollowers,
 	}
 }
 
+// SetFanOutMaxFollowers sets the follower count above which new articles are read into
+// feeds on demand instead of being copied to every follower's timeline
+func (s *ArticleService) SetFanOutMaxFollowers(n int64) {
+	s.fanOutMaxFollowers = n
+}
+
 // ListArticles lists articles with optional filtering and pagination
 func (s *ArticleService) ListArticles(ctx context.Context, query *dtos.ListArticlesQuery, currentUserID *int64) (*dtos.ArticlesListResponse, error) {
+	db := s.readDB.WithContext(ctx)
+	page, err := newSortedArticlePage(query.Sort, query.Limit, query.Offset, query.Cursor, query.SkipCount)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDateRange(query.CreatedAfter, query.CreatedBefore); err != nil {
+		return nil, err
+	}
+
+	filter := repository.ArticleFilter{
+		Tags:          normalizeTagFilter(query.Tags),
+		TagMode:       repository.TagMode(query.TagMode),
+		ExcludeTags:   normalizeTagFilter(query.ExcludeTags),
+		Author:        query.Author,
+		Favorited:     query.Favorited,
+		CreatedAfter:  query.CreatedAfter,
+		CreatedBefore: query.CreatedBefore,
+	}
+
+	// Get articles from repository
+	articles, total, err := s.articleRepo.ListArticles(db, filter, currentUserID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.articlesToListResponse(db, articles, total, page, currentUserID)
+}
+
+// ListDrafts lists a user's unpublished articles, most recently updated first: drafts and
+// scheduled articles, or only those with query.Status. It reads from the primary, since
+// authors expect to see the draft they just saved.
+func (s *ArticleService) ListDrafts(ctx context.Context, userID int64, query *dtos.ListDraftsQuery) (*dtos.ArticlesListResponse, error) {
 	db := s.db.WithContext(ctx)
+	page, err := newArticlePage(query.Limit, query.Offset, "", false)
+	if err != nil {
+		return nil, err
+	}
+	page.Sort = repository.ArticleSortRecentlyUpdated
+
+	filter := repository.ArticleFilter{
+		AuthorID: userID,
+		Statuses: []string{models.ArticleStatusDraft, models.ArticleStatusScheduled},
+	}
+	if query.Status != "" {
+		filter.Statuses = []string{query.Status}
+	}
+
+	articles, total, err := s.articleRepo.ListArticles(db, filter, &userID, page)
+	if err != nil {
+		return nil, err
+	}
+	return s.articlesToListResponse(db, articles, total, page, &userID)
+}
+
+// normalizeTagFilter normalizes the tag names of a filter, dropping empty names and duplicates
+func normalizeTagFilter(names []string) []string {
+	var tags []string
+	for _, name := range names {
+		if tag := utils.NormalizeTag(name); tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// newSortedArticlePage is newArticlePage for a sort, which must be newest first for the
+// cursor to be allowed
+func newSortedArticlePage(sort string, limit, offset int, cursor string, skipCount bool) (repository.ArticlePage, error) {
+	order := repository.ArticleSort(sort)
+	if order != "" && order != repository.ArticleSortNewest && cursor != "" {
+		return repository.ArticlePage{}, appErrors.ErrCursorWithSort
+	}
+	page, err := newArticlePage(limit, offset, cursor, skipCount)
+	if err != nil {
+		return page, err
+	}
+	page.Sort = order
+	return page, nil
+}
+
+// checkDateRange checks that a creation date range, whose ends are optional, is not empty
+func checkDateRange(createdAfter, createdBefore time.Time) error {
+	if !createdAfter.IsZero() && !createdBefore.IsZero() && !createdBefore.After(createdAfter) {
+		return appErrors.ErrInvalidDateRange
+	}
+	return nil
+}
+
+// newArticlePage validates pagination parameters and decodes the cursor, which
+// takes precedence over the offset. One extra article is requested to find out
+// whether another page follows.
+func newArticlePage(limit, offset int, cursor string, skipCount bool) (repository.ArticlePage, error) {
+	limit, offset = normalizeLimitOffset(limit, offset)
+
+	page := repository.ArticlePage{Limit: limit + 1, Offset: offset, SkipCount: skipCount}
+	if cursor == "" {
+		return page, nil
+	}
+
+	decoded, err := utils.DecodeCursor(cursor)
+	if err != nil {
+		return page, err
+	}
+	position := &repository.ArticleCursor{CreatedAt: decoded.CreatedAt, ID: decoded.ID}
+	if decoded.Backward {
+		page.Before = position
+	} else {
+		page.After = position
+	}
+	page.Offset = 0
+	return page, nil
+}
+
+// normalizeLimitOffset applies the default and maximum page size
+func normalizeLimitOffset(limit, offset int) (int, int) {
 	// Set defaults for limit and offset
-	if query.Limit <= 0 {
-		query.Limit = 20
+	if limit <= 0 {
+		limit = 20
 	}
-	if query.Limit > 100 {
-		query.Limit = 100
+	if limit > 100 {
+		limit = 100
 	}
-	if query.Offset < 0 {
-		query.Offset = 0
+	if offset < 0 {
+		offset = 0
 	}
+	return limit, offset
+}
 
-	// Get articles from repository
-	articles, total, err := s.articleRepo.ListArticles(db, query.Tag, query.Author, query.Favorited, currentUserID, query.Limit, query.Offset)
+// articlesToListResponse converts a page of articles to the list response. It drops the
+// extra article requested by newArticlePage and sets the cursors of the neighbouring pages.
+func (s *ArticleService) articlesToListResponse(db *gorm.DB, articles []*models.Article, total int64, page repository.ArticlePage, currentUserID *int64) (*dtos.ArticlesListResponse, error) {
+	limit := page.Limit - 1
+	hasMore := len(articles) > limit
+	if hasMore {
+		if page.Before != nil {
+			// Before pages are newest first, so the extra article is the first one
+			articles = articles[len(articles)-limit:]
+		} else {
+			articles = articles[:limit]
+		}
+	}
+
+	favorited, err := s.favoritedArticles(db, articles, currentUserID)
 	if err != nil {
 		return nil, err
 	}
@@ -47,21 +216,64 @@ func (s *ArticleService) ListArticles(ctx context.Context, query *dtos.ListArtic
 	// Convert articles to response DTOs
 	articleResponses := make([]dtos.ArticleResponse, 0)
 	for _, article := range articles {
-		resp, err := s.articleToResponse(article, currentUserID)
+		resp, err := articleToResponse(article, favorited[article.ID])
 		if err != nil {
 			return nil, err
 		}
 		articleResponses = append(articleResponses, resp)
 	}
 
-	return &dtos.ArticlesListResponse{
-		Articles:      articleResponses,
-		ArticlesCount: int(total),
-	}, nil
+	response := &dtos.ArticlesListResponse{
+		Articles: articleResponses,
+	}
+	if !page.SkipCount {
+		count := int(total)
+		response.ArticlesCount = &count
+	}
+
+	// Other sorts page by offset only
+	isNewestFirst := page.Sort == "" || page.Sort == repository.ArticleSortNewest
+	if len(articles) > 0 && isNewestFirst {
+		first, last := articles[0], articles[len(articles)-1]
+
+		// Older articles exist past the extra one, and always when paging back from a cursor
+		if hasMore || page.Before != nil {
+			response.NextCursor = utils.EncodeCursor(utils.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+		}
+		// Newer articles exist unless this is the first page
+		if (page.Before != nil && hasMore) || page.After != nil || (page.Before == nil && page.Offset > 0) {
+			response.PrevCursor = utils.EncodeCursor(utils.Cursor{CreatedAt: first.CreatedAt, ID: first.ID, Backward: true})
+		}
+	}
+
+	return response, nil
+}
+
+// favoritedArticles returns the set of articles the current user has favorited, found
+// with one query for the whole page. Anonymous requests skip the query.
+func (s *ArticleService) favoritedArticles(db *gorm.DB, articles []*models.Article, currentUserID *int64) (map[int64]bool, error) {
+	if currentUserID == nil || len(articles) == 0 {
+		return nil, nil
+	}
+
+	articleIDs := make([]int64, 0, len(articles))
+	for _, article := range articles {
+		articleIDs = append(articleIDs, article.ID)
+	}
+	ids, err := s.favoriteRepo.FavoritedArticleIDs(db, *currentUserID, articleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	favorited := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		favorited[id] = true
+	}
+	return favorited, nil
 }
 
 // articleToResponse converts a model Article to ArticleResponse DTO
-func (s *ArticleService) articleToResponse(article *models.Article, currentUserID *int64) (dtos.ArticleResponse, error) {
+func articleToResponse(article *models.Article, favorited bool) (dtos.ArticleResponse, error) {
 	// Convert tags from preloaded ArticleTags
 	tagList := make([]string, 0)
 	if article.ArticleTags != nil {
@@ -72,17 +284,6 @@ func (s *ArticleService) articleToResponse(article *models.Article, currentUserI
 		}
 	}
 
-	// Check if current user favorited this article
-	favorited := false
-	if currentUserID != nil {
-		for _, fav := range article.Favorites {
-			if fav.UserID == *currentUserID {
-				favorited = true
-				break
-			}
-		}
-	}
-
 	return dtos.ArticleResponse{
 		Slug:           article.Slug,
 		Title:          article.Title,
@@ -93,55 +294,338 @@ func (s *ArticleService) articleToResponse(article *models.Article, currentUserI
 		UpdatedAt:      article.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		Favorited:      favorited,
 		FavoritesCount: article.FavoritesCount,
+		Version:        article.Version,
+		Status:         article.Status,
+		PublishedAt:    formatPublishedAt(article.PublishedAt),
 		Author: dtos.ArticleAuthorResponse{
 			Username: article.Author.Username,
 		},
 	}, nil
 }
 
+// formatPublishedAt formats a publication time like the other timestamps, or returns "" for none
+func formatPublishedAt(publishedAt *time.Time) string {
+	if publishedAt == nil {
+		return ""
+	}
+	return publishedAt.Format("2006-01-02T15:04:05Z07:00")
+}
+
 // GetFeedArticles gets articles from followed users
-func (s *ArticleService) GetFeedArticles(ctx context.Context, userID int64, limit, offset int) (*dtos.ArticlesListResponse, error) {
-	db := s.db.WithContext(ctx)
-	// Validate pagination parameters
-	if limit <= 0 {
-		limit = 20
+func (s *ArticleService) GetFeedArticles(ctx context.Context, userID int64, query *dtos.FeedArticlesQuery) (*dtos.ArticlesListResponse, error) {
+	db := s.readDB.WithContext(ctx)
+	page, err := newSortedArticlePage(query.Sort, query.Limit, query.Offset, query.Cursor, query.SkipCount)
+	if err != nil {
+		return nil, err
 	}
-	if limit > 100 {
-		limit = 100
+	if err := checkDateRange(query.CreatedAfter, query.CreatedBefore); err != nil {
+		return nil, err
 	}
-	if offset < 0 {
-		offset = 0
+	filter := repository.FeedFilter{CreatedAfter: query.CreatedAfter, CreatedBefore: query.CreatedBefore}
+
+	var entries []*models.TimelineEntry
+	var total int64
+	if page.Sort == "" || page.Sort == repository.ArticleSortNewest {
+		entries, total, err = s.feedEntries(db, userID, filter, page)
+	} else {
+		entries, total, err = s.timelineRepo.ListFeed(db, userID, filter, page)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	articles, total, err := s.articleRepo.FeedArticles(db, userID, limit, offset)
+	ids := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ArticleID)
+	}
+	articles, err := s.findArticlesInOrder(db, ids)
 	if err != nil {
 		return nil, err
 	}
 
-	articleResponses := make([]dtos.ArticleResponse, 0)
+	return s.articlesToListResponse(db, articles, total, page, &userID)
+}
+
+// feedEntries reads a page of the user's timeline merged with the articles of followed
+// authors that were not fanned out. Both sources are read up to the end of the page,
+// so an offset page reads Offset+Limit entries from each before merging.
+func (s *ArticleService) feedEntries(db *gorm.DB, userID int64, filter repository.FeedFilter, page repository.ArticlePage) ([]*models.TimelineEntry, int64, error) {
+	sourcePage := page
+	if page.After == nil && page.Before == nil {
+		sourcePage.Limit = page.Offset + page.Limit
+		sourcePage.Offset = 0
+	}
+
+	timeline, timelineTotal, err := s.timelineRepo.ListTimeline(db, userID, filter, sourcePage)
+	if err != nil {
+		return nil, 0, err
+	}
+	onRead, onReadTotal, err := s.timelineRepo.ListFanOutOnRead(db, userID, filter, sourcePage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := append(timeline, onRead...)
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].CreatedAt.After(entries[j].CreatedAt)
+		}
+		return entries[i].ArticleID > entries[j].ArticleID
+	})
+
+	start, end := 0, len(entries)
+	switch {
+	case page.Before != nil:
+		// Keep the entries closest to the cursor
+		start = max(end-page.Limit, 0)
+	case page.After != nil:
+		end = min(page.Limit, end)
+	default:
+		start = min(page.Offset, end)
+		end = min(start+page.Limit, end)
+	}
+	return entries[start:end], timelineTotal + onReadTotal, nil
+}
+
+// SearchArticles finds articles whose title, description or body match the query, most
+// relevant first, with highlighted excerpts of the matches
+func (s *ArticleService) SearchArticles(ctx context.Context, query *dtos.SearchArticlesQuery, currentUserID *int64) (*dtos.ArticleSearchResponse, error) {
+	terms := utils.SearchTerms(query.Q)
+	if len(terms) == 0 {
+		return nil, appErrors.ErrEmptySearchQuery
+	}
+	limit, offset := normalizeLimitOffset(query.Limit, query.Offset)
+	db := s.readDB.WithContext(ctx)
+
+	var articles []*models.Article
+	var total int64
+	var err error
+	if s.searchIndex != nil {
+		articles, total, err = s.searchWithIndex(db, search.Query{
+			Text:   query.Q,
+			Tag:    utils.NormalizeTag(query.Tag),
+			Author: query.Author,
+			Limit:  limit,
+			Offset: offset,
+		})
+	} else {
+		articles, total, err = s.articleRepo.SearchArticles(db, repository.ArticleSearch{
+			Query:  strings.TrimSpace(query.Q),
+			Terms:  terms,
+			Tag:    utils.NormalizeTag(query.Tag),
+			Author: query.Author,
+			Limit:  limit,
+			Offset: offset,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	favorited, err := s.favoritedArticles(db, articles, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Highlight the stems too, which the search index matches on
+	highlightTerms := append(terms, search.Analyze(query.Q)...)
+	results := make([]dtos.ArticleSearchResult, 0, len(articles))
 	for _, article := range articles {
-		resp, err := s.articleToResponse(article, &userID)
+		resp, err := articleToResponse(article, favorited[article.ID])
 		if err != nil {
 			return nil, err
 		}
-		articleResponses = append(articleResponses, resp)
+		results = append(results, dtos.ArticleSearchResult{
+			ArticleResponse: resp,
+			Highlight:       highlightArticle(article, highlightTerms),
+		})
 	}
 
-	return &dtos.ArticlesListResponse{
-		Articles:      articleResponses,
+	return &dtos.ArticleSearchResponse{
+		Articles:      results,
 		ArticlesCount: int(total),
 	}, nil
 }
 
+// searchWithIndex looks up a page of hits in the search index and loads their articles in
+// ranking order. Hits for articles deleted without updating the index are skipped.
+func (s *ArticleService) searchWithIndex(db *gorm.DB, query search.Query) ([]*models.Article, int64, error) {
+	hits, total, err := s.searchIndex.Search(query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]int64, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+	articles, err := s.findArticlesInOrder(db, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	return articles, int64(total), nil
+}
+
+// findArticlesInOrder loads the articles with the given IDs in that order, skipping the
+// ones that no longer exist
+func (s *ArticleService) findArticlesInOrder(db *gorm.DB, ids []int64) ([]*models.Article, error) {
+	found, err := s.articleRepo.FindArticlesByIDs(db, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]*models.Article, len(found))
+	for _, article := range found {
+		byID[article.ID] = article
+	}
+	articles := make([]*models.Article, 0, len(found))
+	for _, id := range ids {
+		if article, ok := byID[id]; ok {
+			articles = append(articles, article)
+		}
+	}
+	return articles, nil
+}
+
+// RebuildSearchIndex re-indexes every published article from the repository and returns
+// how many were indexed. It is a no-op without a search index.
+func (s *ArticleService) RebuildSearchIndex(ctx context.Context) (int, error) {
+	if s.searchIndex == nil {
+		return 0, nil
+	}
+
+	docs, err := s.searchDocuments(s.db.WithContext(ctx), repository.ArticleFilter{})
+	if err != nil {
+		return 0, err
+	}
+	return len(docs), s.searchIndex.Reset(docs)
+}
+
+// ReindexArticles re-indexes the published articles that match filter, after a change
+// outside of this service, such as a tag rename or a new username, altered their search
+// documents. It returns how many were indexed and is a no-op without a search index.
+func (s *ArticleService) ReindexArticles(ctx context.Context, filter repository.ArticleFilter) (int, error) {
+	if s.searchIndex == nil {
+		return 0, nil
+	}
+
+	docs, err := s.searchDocuments(s.db.WithContext(ctx), filter)
+	if err != nil {
+		return 0, err
+	}
+	for _, doc := range docs {
+		if err := s.searchIndex.Index(doc); err != nil {
+			return 0, err
+		}
+	}
+	return len(docs), nil
+}
+
+// refreshSearchIndex re-indexes the articles that match filter once another service
+// committed a change to them, so a failure is only logged like in updateSearchIndex
+func (s *ArticleService) refreshSearchIndex(ctx context.Context, filter repository.ArticleFilter) {
+	if _, err := s.ReindexArticles(ctx, filter); err != nil {
+		log.Printf("Failed to re-index articles: %v", err)
+	}
+}
+
+// searchDocuments loads the published articles that match filter as search documents,
+// in batches
+func (s *ArticleService) searchDocuments(db *gorm.DB, filter repository.ArticleFilter) ([]search.Document, error) {
+	docs := make([]search.Document, 0)
+	page := repository.ArticlePage{Limit: reindexBatchSize, SkipCount: true}
+	for {
+		articles, _, err := s.articleRepo.ListArticles(db, filter, nil, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, article := range articles {
+			docs = append(docs, articleDocument(article))
+		}
+		if len(articles) < reindexBatchSize {
+			return docs, nil
+		}
+		last := articles[len(articles)-1]
+		page.After = &repository.ArticleCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// updateSearchIndex indexes a saved article, or drops it from the index when it is not
+// published. The database write has already been committed, so a failure is only logged;
+// RebuildSearchIndex repairs the index.
+func (s *ArticleService) updateSearchIndex(article *models.Article) {
+	if s.searchIndex == nil {
+		return
+	}
+	if article.Status != models.ArticleStatusPublished {
+		s.removeFromSearchIndex(article.ID)
+		return
+	}
+	if err := s.searchIndex.Index(articleDocument(article)); err != nil {
+		log.Printf("Failed to index article %d: %v", article.ID, err)
+	}
+}
+
+// removeFromSearchIndex drops a deleted article from the search index, logging failures
+func (s *ArticleService) removeFromSearchIndex(articleID int64) {
+	if s.searchIndex == nil {
+		return
+	}
+	if err := s.searchIndex.Delete(articleID); err != nil {
+		log.Printf("Failed to remove article %d from the search index: %v", articleID, err)
+	}
+}
+
+// articleDocument converts an article with preloaded author and tags to a search document
+func articleDocument(article *models.Article) search.Document {
+	doc := search.Document{
+		ID:          article.ID,
+		Title:       article.Title,
+		Description: article.Description,
+		Body:        article.Body,
+		Tags:        make([]string, 0, len(article.ArticleTags)),
+	}
+	if article.Author != nil {
+		doc.Author = article.Author.Username
+	}
+	for _, at := range article.ArticleTags {
+		if at.Tag != nil {
+			doc.Tags = append(doc.Tags, at.Tag.Name)
+		}
+	}
+	return doc
+}
+
+// highlightArticle highlights the title and picks the snippet from the body, falling
+// back to the description when only it (or the title) matched
+func highlightArticle(article *models.Article, terms []string) dtos.ArticleSearchHighlight {
+	title, _ := utils.Highlight(article.Title, terms, 0)
+	snippet, matched := utils.Highlight(article.Body, terms, searchSnippetLength)
+	if !matched {
+		if description, ok := utils.Highlight(article.Description, terms, searchSnippetLength); ok {
+			snippet = description
+		}
+	}
+	return dtos.ArticleSearchHighlight{Title: title, Snippet: snippet}
+}
+
 // GetArticleBySlug gets article by slug
 func (s *ArticleService) GetArticleBySlug(ctx context.Context, slug string, currentUserID *int64) (*dtos.ArticleDetailResponse, error) {
-	db := s.db.WithContext(ctx)
-	article, err := s.articleRepo.FindArticleBySlug(db, slug)
+	db := s.readDB.WithContext(ctx)
+	article, err := findArticleBySlug(db, s.articleRepo, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !articleVisibleTo(article, currentUserID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	favorited, err := s.favoritedArticles(db, []*models.Article{article}, currentUserID)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.articleToResponse(article, currentUserID)
+	resp, err := articleToResponse(article, favorited[article.ID])
 	if err != nil {
 		return nil, err
 	}
@@ -154,10 +638,12 @@ func (s *ArticleService) GetArticleBySlug(ctx context.Context, slug string, curr
 // CreateArticle creates a new article
 func (s *ArticleService) CreateArticle(ctx context.Context, req *dtos.CreateArticleRequest, authorID int64) (*dtos.ArticleDetailResponse, error) {
 	db := s.db.WithContext(ctx)
-	slug := utils.GenerateSlug(req.Article.Title)
+	tags, err := NormalizeTags(req.Article.TagList)
+	if err != nil {
+		return nil, err
+	}
 
 	article := &models.Article{
-		Slug:        slug,
 		Title:       req.Article.Title,
 		Description: req.Article.Description,
 		Body:        req.Article.Body,
@@ -165,28 +651,45 @@ func (s *ArticleService) CreateArticle(ctx context.Context, req *dtos.CreateArti
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
+	status := cmp.Or(req.Article.Status, models.ArticleStatusPublished)
+	published, _, err := setArticleStatus(article, status, req.Article.PublishedAt, article.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := db.Transaction(func(tx *gorm.DB) error {
-		if err := s.articleRepo.CreateArticle(tx, article); err != nil {
+		// Copying to every timeline is too costly for popular authors; their feeds read the article on demand
+		followers, err := s.followRepo.CountFollowers(tx, authorID)
+		if err != nil {
 			return err
 		}
-		if len(req.Article.TagList) > 0 {
-			if err := s.articleRepo.AssignTagsToArticle(tx, article.ID, req.Article.TagList); err != nil {
+		article.FanOutOnRead = followers > s.fanOutMaxFollowers
+
+		if err := s.saveWithUniqueSlug(tx, article, s.articleRepo.CreateArticle); err != nil {
+			return err
+		}
+		if len(tags) > 0 {
+			if err := s.articleRepo.AssignTagsToArticle(tx, article.ID, tags); err != nil {
 				return err
 			}
 		}
+		if published && !article.FanOutOnRead {
+			return s.timelineRepo.FanOutArticle(tx, article)
+		}
 		return nil
 	}); err != nil {
 		return nil, err
 	}
 
-	// Fetch the created article with preloaded data
-	createdArticle, err := s.articleRepo.FindArticleBySlug(db, slug)
+	// Fetch the created article with preloaded data from the primary; a replica may not have it yet
+	createdArticle, err := s.articleRepo.FindArticleBySlug(db, article.Slug)
 	if err != nil {
 		return nil, err
 	}
+	s.updateSearchIndex(createdArticle)
 
-	resp, err := s.articleToResponse(createdArticle, &authorID)
+	// Nobody can have favorited the article yet
+	resp, err := articleToResponse(createdArticle, false)
 	if err != nil {
 		return nil, err
 	}
@@ -196,38 +699,125 @@ func (s *ArticleService) CreateArticle(ctx context.Context, req *dtos.CreateArti
 	}, nil
 }
 
-// UpdateArticle updates an article
-func (s *ArticleService) UpdateArticle(ctx context.Context, slug string, req *dtos.UpdateArticleRequest, authorID int64) (*dtos.ArticleDetailResponse, error) {
+// UpdateArticle updates an article with the fields of req that are not empty; an empty
+// tag list leaves the tags alone. See PatchArticle for ifMatch.
+func (s *ArticleService) UpdateArticle(ctx context.Context, slug string, req *dtos.UpdateArticleRequest, authorID int64, ifMatch []int64) (*dtos.ArticleDetailResponse, error) {
+	patch := &dtos.ArticlePatch{}
+	if req.Article.Title != "" {
+		patch.Title = dtos.OptionalOf(req.Article.Title)
+	}
+	if req.Article.Description != "" {
+		patch.Description = dtos.OptionalOf(req.Article.Description)
+	}
+	if req.Article.Body != "" {
+		patch.Body = dtos.OptionalOf(req.Article.Body)
+	}
+	if len(req.Article.TagList) > 0 {
+		patch.TagList = dtos.OptionalOf(req.Article.TagList)
+	}
+	if req.Article.Status != "" {
+		patch.Status = dtos.OptionalOf(req.Article.Status)
+	}
+	if req.Article.PublishedAt != nil {
+		patch.PublishedAt = dtos.OptionalOf(*req.Article.PublishedAt)
+	}
+	return s.PatchArticle(ctx, slug, &dtos.PatchArticleRequest{Article: patch}, authorID, ifMatch)
+}
+
+// PatchArticle applies a merge patch to an article; a null or empty tag list removes every
+// tag. A publishedAt needs the scheduled status, given in the patch or already set; see
+// setArticleStatus for the rest of the status change. With ifMatch set, the patch only
+// applies to one of those versions of the article (an empty, non-nil ifMatch matches
+// none). Either way it fails with a *appErrors.VersionConflictError if another update
+// gets in first. Only the author may change an article; see checkArticleAuthor.
+func (s *ArticleService) PatchArticle(ctx context.Context, slug string, req *dtos.PatchArticleRequest, authorID int64, ifMatch []int64) (*dtos.ArticleDetailResponse, error) {
 	db := s.db.WithContext(ctx)
 	finalSlug := slug
+	var articleID int64
+	patch := req.Article
+	var tags []string
+	if patch.TagList.Set {
+		// Reject tags that break the limits before changing anything
+		var err error
+		if tags, err = NormalizeTags(patch.TagList.Value); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := db.Transaction(func(tx *gorm.DB) error {
 		article, err := s.articleRepo.FindArticleBySlug(tx, slug)
 		if err != nil {
 			return err
 		}
+		if err := checkArticleAuthor(article, authorID); err != nil {
+			return err
+		}
+		articleID = article.ID
+		if ifMatch != nil && !slices.Contains(ifMatch, article.Version) {
+			return &appErrors.VersionConflictError{Version: article.Version}
+		}
 
-		// Update fields if provided
-		if req.Article.Title != "" {
-			article.Title = req.Article.Title
-			article.Slug = utils.GenerateSlug(req.Article.Title) // Regenerate slug from new title
+		// A new title gets a new slug
+		titleChanged := patch.Title.Set && patch.Title.Value != article.Title
+		if titleChanged {
+			article.Title = patch.Title.Value
 		}
-		if req.Article.Description != "" {
-			article.Description = req.Article.Description
+		if patch.Description.Set {
+			article.Description = patch.Description.Value
 		}
-		if req.Article.Body != "" {
-			article.Body = req.Article.Body
+		if patch.Body.Set {
+			article.Body = patch.Body.Value
 		}
 
 		article.UpdatedAt = time.Now()
 
-		if err := s.articleRepo.UpdateArticle(tx, article); err != nil {
+		var published, unpublished bool
+		if patch.Status.Set || patch.PublishedAt.Set {
+			status := article.Status
+			if patch.Status.Set {
+				status = patch.Status.Value
+			}
+			var publishedAt *time.Time
+			if patch.PublishedAt.Null {
+				// Removing the publication time of a scheduled article unschedules it;
+				// the other statuses have none to remove
+				if !patch.Status.Set && status == models.ArticleStatusScheduled {
+					status = models.ArticleStatusDraft
+				}
+			} else if patch.PublishedAt.Set {
+				publishedAt = &patch.PublishedAt.Value
+			} else if status == models.ArticleStatusScheduled && article.Status == models.ArticleStatusScheduled {
+				// Keep the publication time of an article that stays scheduled
+				publishedAt = article.PublishedAt
+			}
+			if published, unpublished, err = setArticleStatus(article, status, publishedAt, article.UpdatedAt); err != nil {
+				return err
+			}
+		}
+
+		if titleChanged {
+			err = s.saveWithUniqueSlug(tx, article, s.articleRepo.UpdateArticle)
+		} else {
+			err = s.articleRepo.UpdateArticle(tx, article)
+		}
+		if err != nil {
 			return err
 		}
 
-		// Update tags if provided
-		if len(req.Article.TagList) > 0 {
-			if err := s.articleRepo.AssignTagsToArticle(tx, article.ID, req.Article.TagList); err != nil {
+		if patch.TagList.Set {
+			if err := s.articleRepo.AssignTagsToArticle(tx, article.ID, tags); err != nil {
+				return err
+			}
+		}
+
+		// Feeds hold published articles only
+		switch {
+		case published && !article.FanOutOnRead:
+			if err := s.timelineRepo.FanOutArticle(tx, article); err != nil {
+				return err
+			}
+		case unpublished:
+			if err := s.timelineRepo.RemoveArticle(tx, article.ID); err != nil {
 				return err
 			}
 		}
@@ -235,16 +825,26 @@ func (s *ArticleService) UpdateArticle(ctx context.Context, slug string, req *dt
 		finalSlug = article.Slug
 		return nil
 	}); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			// Read the winner's version after the transaction, which may not see it
+			return nil, s.versionConflict(db, articleID)
+		}
 		return nil, err
 	}
 
-	// Fetch updated article
+	// Fetch updated article from the primary; a replica may still return the old version
 	updatedArticle, err := s.articleRepo.FindArticleBySlug(db, finalSlug)
 	if err != nil {
 		return nil, err
 	}
+	s.updateSearchIndex(updatedArticle)
+
+	favorited, err := s.favoritedArticles(db, []*models.Article{updatedArticle}, &authorID)
+	if err != nil {
+		return nil, err
+	}
 
-	resp, err := s.articleToResponse(updatedArticle, &authorID)
+	resp, err := articleToResponse(updatedArticle, favorited[updatedArticle.ID])
 	if err != nil {
 		return nil, err
 	}
@@ -254,13 +854,134 @@ func (s *ArticleService) UpdateArticle(ctx context.Context, slug string, req *dt
 	}, nil
 }
 
-// DeleteArticle deletes an article
-func (s *ArticleService) DeleteArticle(ctx context.Context, slug string) error {
+// versionConflict reports the version an article was updated to by a concurrent writer
+func (s *ArticleService) versionConflict(db *gorm.DB, articleID int64) error {
+	articles, err := s.articleRepo.FindArticlesByIDs(db, []int64{articleID})
+	if err != nil {
+		return err
+	}
+	if len(articles) == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return &appErrors.VersionConflictError{Version: articles[0].Version}
+}
+
+// findArticleBySlug finds an article by its current slug. For a slug the article used
+// before a title change it returns a *appErrors.SlugMovedError with the current slug.
+func findArticleBySlug(db *gorm.DB, articleRepo repository.ArticleRepository, slug string) (*models.Article, error) {
+	article, err := articleRepo.FindArticleBySlug(db, slug)
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return article, err
+	}
+
+	current, historyErr := articleRepo.FindCurrentSlug(db, slug)
+	if historyErr != nil {
+		if errors.Is(historyErr, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, historyErr
+	}
+	return nil, &appErrors.SlugMovedError{Slug: current}
+}
+
+// articleVisibleTo reports whether the current user may see an article: anyone may see a
+// published article, and only its author one that is not
+func articleVisibleTo(article *models.Article, currentUserID *int64) bool {
+	return article.Status == models.ArticleStatusPublished || (currentUserID != nil && *currentUserID == article.AuthorID)
+}
+
+// checkArticleAuthor lets only the author of an article change it. Anyone else gets
+// gorm.ErrRecordNotFound for an article they cannot see, so that its existence is not
+// revealed, and ErrForbidden for a published one.
+func checkArticleAuthor(article *models.Article, userID int64) error {
+	if article.AuthorID == userID {
+		return nil
+	}
+	if !articleVisibleTo(article, &userID) {
+		return gorm.ErrRecordNotFound
+	}
+	return appErrors.ErrForbidden
+}
+
+// setArticleStatus moves an article to a status, as of now, and reports whether that
+// published or unpublished it. A scheduled article needs publishedAt, in the future;
+// other statuses take none. A draft or scheduled article that is published takes the
+// publication time as its creation time too, so it is listed as a new article; an
+// archived one that is published again keeps both.
+func setArticleStatus(article *models.Article, status string, publishedAt *time.Time, now time.Time) (published, unpublished bool, err error) {
+	if status == models.ArticleStatusScheduled {
+		if publishedAt == nil || !publishedAt.After(now) {
+			return false, false, fmt.Errorf("%w: a scheduled article needs a publishedAt in the future", appErrors.ErrInvalidPublishedAt)
+		}
+	} else if publishedAt != nil {
+		return false, false, fmt.Errorf("%w: only a scheduled article can have a publishedAt", appErrors.ErrInvalidPublishedAt)
+	}
+
+	previous := article.Status
+	wasPublished := previous == models.ArticleStatusPublished
+	neverPublished := previous == "" || previous == models.ArticleStatusDraft || previous == models.ArticleStatusScheduled
+	switch status {
+	case models.ArticleStatusPublished:
+		if neverPublished {
+			article.CreatedAt = now
+			article.PublishedAt = &now
+		} else if article.PublishedAt == nil {
+			article.PublishedAt = &now
+		}
+	case models.ArticleStatusScheduled:
+		at := *publishedAt
+		article.PublishedAt = &at
+	case models.ArticleStatusDraft:
+		article.PublishedAt = nil
+	case models.ArticleStatusArchived:
+		if neverPublished {
+			article.PublishedAt = nil
+		}
+	}
+	article.Status = status
+
+	isPublished := status == models.ArticleStatusPublished
+	return isPublished && !wasPublished, wasPublished && !isPublished, nil
+}
+
+// saveWithUniqueSlug saves an article under the first free slug derived from its title.
+// Each attempt runs in a savepoint, so a unique violation leaves the surrounding transaction
+// usable; a concurrent writer that takes a candidate first just moves this one to the next.
+func (s *ArticleService) saveWithUniqueSlug(tx *gorm.DB, article *models.Article, save func(db *gorm.DB, article *models.Article) error) error {
+	base := utils.GenerateSlug(article.Title)
+	for attempt := 1; attempt <= maxSlugAttempts; attempt++ {
+		article.Slug = utils.SlugCandidate(base, attempt)
+		err := tx.Transaction(func(sp *gorm.DB) error {
+			return save(sp, article)
+		})
+		if err == nil {
+			return nil
+		}
+		if !appErrors.IsUniqueViolation(err) {
+			return err
+		}
+	}
+	return appErrors.ErrSlugConflict
+}
+
+// DeleteArticle deletes an article of the user; see checkArticleAuthor
+func (s *ArticleService) DeleteArticle(ctx context.Context, slug string, userID int64) error {
 	db := s.db.WithContext(ctx)
-	return db.Transaction(func(tx *gorm.DB) error {
-		if _, err := s.articleRepo.FindArticleBySlug(tx, slug); err != nil {
+	var articleID int64
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		article, err := s.articleRepo.FindArticleBySlug(tx, slug)
+		if err != nil {
+			return err
+		}
+		if err := checkArticleAuthor(article, userID); err != nil {
 			return err
 		}
+		articleID = article.ID
 		return s.articleRepo.DeleteArticleBySlug(tx, slug)
-	})
+	}); err != nil {
+		return err
+	}
+
+	s.removeFromSearchIndex(articleID)
+	return nil
 }
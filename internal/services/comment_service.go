@@ -13,13 +13,15 @@ import (
 
 type CommentService struct {
 	db          *gorm.DB
+	readDB      *gorm.DB // replica pool for reads that tolerate lag
 	commentRepo repository.CommentRepository
 	articleRepo repository.ArticleRepository
 }
 
-func NewCommentService(db *gorm.DB, commentRepo repository.CommentRepository, articleRepo repository.ArticleRepository) *CommentService {
+func NewCommentService(db, readDB *gorm.DB, commentRepo repository.CommentRepository, articleRepo repository.ArticleRepository) *CommentService {
 	return &CommentService{
 		db:          db,
+		readDB:      readDB,
 		commentRepo: commentRepo,
 		articleRepo: articleRepo,
 	}
@@ -31,13 +33,16 @@ func (s *CommentService) CreateComment(ctx context.Context, req *dtos.CreateComm
 
 	var createdComment *models.Comment
 	if err := db.Transaction(func(tx *gorm.DB) error {
-		article, err := s.articleRepo.FindArticleBySlug(tx, slug)
+		article, err := findArticleBySlug(tx, s.articleRepo, slug)
 		if err != nil {
 			if err == gorm.ErrRecordNotFound {
 				return appErrors.ErrNotFound
 			}
 			return err
 		}
+		if !articleVisibleTo(article, &authorID) {
+			return appErrors.ErrNotFound
+		}
 
 		comment := &models.Comment{
 			Body:      req.Comment.Body,
@@ -67,17 +72,21 @@ func (s *CommentService) CreateComment(ctx context.Context, req *dtos.CreateComm
 	}, nil
 }
 
-// GetCommentsByArticleSlug gets all comments for an article by slug
-func (s *CommentService) GetCommentsByArticleSlug(ctx context.Context, slug string) (*dtos.CommentsListResponse, error) {
-	db := s.db.WithContext(ctx)
+// GetCommentsByArticleSlug gets all comments for an article by slug, if the current user
+// can see the article
+func (s *CommentService) GetCommentsByArticleSlug(ctx context.Context, slug string, currentUserID *int64) (*dtos.CommentsListResponse, error) {
+	db := s.readDB.WithContext(ctx)
 	// Get article by slug to get article ID
-	article, err := s.articleRepo.FindArticleBySlug(db, slug)
+	article, err := findArticleBySlug(db, s.articleRepo, slug)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, appErrors.ErrNotFound
 		}
 		return nil, err
 	}
+	if !articleVisibleTo(article, currentUserID) {
+		return nil, appErrors.ErrNotFound
+	}
 
 	comments, err := s.commentRepo.GetCommentsByArticleID(db, article.ID)
 	if err != nil {
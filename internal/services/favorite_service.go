@@ -4,7 +4,6 @@ import (
 	"context"
 	"go-gin-realworld-api/internal/dtos"
 	appErrors "go-gin-realworld-api/internal/errors"
-	"go-gin-realworld-api/internal/models"
 	"go-gin-realworld-api/internal/repository"
 
 	"gorm.io/gorm"
@@ -30,13 +29,17 @@ func (s *FavoriteService) FavoriteArticle(ctx context.Context, slug string, user
 	var articleID int64
 	var notFound bool
 	if err := db.Transaction(func(tx *gorm.DB) error {
-		article, err := s.articleRepo.FindArticleBySlug(tx, slug)
+		article, err := findArticleBySlug(tx, s.articleRepo, slug)
 		if err != nil {
 			if err == gorm.ErrRecordNotFound {
 				notFound = true
 			}
 			return err
 		}
+		if !articleVisibleTo(article, &userID) {
+			notFound = true
+			return gorm.ErrRecordNotFound
+		}
 		articleID = article.ID
 
 		// Check if already favorited
@@ -46,16 +49,20 @@ func (s *FavoriteService) FavoriteArticle(ctx context.Context, slug string, user
 		}
 
 		if !isFavorited {
-			// Add favorite
-			if err := s.favoriteRepo.AddFavorite(tx, userID, article.ID); err != nil {
+			// Add favorite in a savepoint: a concurrent request may add it between the
+			// check and the insert, and its unique violation means it is already favorited
+			err := tx.Transaction(func(sp *gorm.DB) error {
+				return s.favoriteRepo.AddFavorite(sp, userID, article.ID)
+			})
+			if appErrors.IsUniqueViolation(err) {
+				return nil
+			}
+			if err != nil {
 				return err
 			}
 
 			// Increment favorites count
-			article.FavoritesCount++
-			if err := s.articleRepo.UpdateArticle(tx, article); err != nil {
-				return err
-			}
+			return s.favoriteRepo.AdjustFavoritesCount(tx, article.ID, 1)
 		}
 		return nil
 	}); err != nil {
@@ -65,14 +72,14 @@ func (s *FavoriteService) FavoriteArticle(ctx context.Context, slug string, user
 		return nil, err
 	}
 
-	// Get updated article with favorites info
-	updatedArticle, err := s.favoriteRepo.GetArticleWithFavorites(db, articleID)
+	// Get the article with its updated favorites count
+	updatedArticle, err := s.favoriteRepo.GetArticle(db, articleID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Convert to response
-	resp, err := articleToResponse(updatedArticle, &userID)
+	resp, err := articleToResponse(updatedArticle, true)
 	if err != nil {
 		return nil, err
 	}
@@ -88,34 +95,28 @@ func (s *FavoriteService) UnfavoriteArticle(ctx context.Context, slug string, us
 	var articleID int64
 	var notFound bool
 	if err := db.Transaction(func(tx *gorm.DB) error {
-		article, err := s.articleRepo.FindArticleBySlug(tx, slug)
+		article, err := findArticleBySlug(tx, s.articleRepo, slug)
 		if err != nil {
 			if err == gorm.ErrRecordNotFound {
 				notFound = true
 			}
 			return err
 		}
+		if !articleVisibleTo(article, &userID) {
+			notFound = true
+			return gorm.ErrRecordNotFound
+		}
 		articleID = article.ID
 
-		// Check if favorited
-		isFavorited, err := s.favoriteRepo.IsFavorited(tx, userID, article.ID)
+		// Remove favorite; only the request that actually removed it decrements the count
+		removed, err := s.favoriteRepo.RemoveFavorite(tx, userID, article.ID)
 		if err != nil {
 			return err
 		}
 
-		if isFavorited {
-			// Remove favorite
-			if err := s.favoriteRepo.RemoveFavorite(tx, userID, article.ID); err != nil {
-				return err
-			}
-
+		if removed {
 			// Decrement favorites count
-			if article.FavoritesCount > 0 {
-				article.FavoritesCount--
-				if err := s.articleRepo.UpdateArticle(tx, article); err != nil {
-					return err
-				}
-			}
+			return s.favoriteRepo.AdjustFavoritesCount(tx, article.ID, -1)
 		}
 		return nil
 	}); err != nil {
@@ -125,14 +126,14 @@ func (s *FavoriteService) UnfavoriteArticle(ctx context.Context, slug string, us
 		return nil, err
 	}
 
-	// Get updated article with favorites info
-	updatedArticle, err := s.favoriteRepo.GetArticleWithFavorites(db, articleID)
+	// Get the article with its updated favorites count
+	updatedArticle, err := s.favoriteRepo.GetArticle(db, articleID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Convert to response
-	resp, err := articleToResponse(updatedArticle, &userID)
+	resp, err := articleToResponse(updatedArticle, false)
 	if err != nil {
 		return nil, err
 	}
@@ -142,41 +143,17 @@ func (s *FavoriteService) UnfavoriteArticle(ctx context.Context, slug string, us
 	}, nil
 }
 
-// articleToResponse converts a model Article to ArticleResponse DTO (reuse from ArticleService)
-func articleToResponse(article *models.Article, currentUserID *int64) (dtos.ArticleResponse, error) {
-	// Convert tags from preloaded ArticleTags
-	tagList := make([]string, 0)
-	if article.ArticleTags != nil {
-		for _, at := range article.ArticleTags {
-			if at.Tag != nil {
-				tagList = append(tagList, at.Tag.Name)
-			}
-		}
-	}
-
-	// Check if current user favorited this article
-	favorited := false
-	if currentUserID != nil {
-		for _, fav := range article.Favorites {
-			if fav.UserID == *currentUserID {
-				favorited = true
-				break
-			}
-		}
+// ReconcileFavoritesCounts recomputes every article's favorites count from the favorites
+// table and returns the articles whose count had drifted
+func (s *FavoriteService) ReconcileFavoritesCounts(ctx context.Context) ([]repository.FavoritesCountDrift, error) {
+	var drifts []repository.FavoritesCountDrift
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		drifts, err = s.favoriteRepo.ReconcileFavoritesCounts(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	return dtos.ArticleResponse{
-		Slug:           article.Slug,
-		Title:          article.Title,
-		Description:    article.Description,
-		Body:           article.Body,
-		TagList:        tagList,
-		CreatedAt:      article.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:      article.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		Favorited:      favorited,
-		FavoritesCount: article.FavoritesCount,
-		Author: dtos.ArticleAuthorResponse{
-			Username: article.Author.Username,
-		},
-	}, nil
+	return drifts, nil
 }
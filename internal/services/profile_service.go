@@ -10,18 +10,20 @@ import (
 )
 
 type ProfileService struct {
-	db          *gorm.DB
-	userRepo    repository.UserRepository
-	profileRepo repository.ProfileRepository
-	followRepo  repository.FollowRepository
+	db           *gorm.DB
+	userRepo     repository.UserRepository
+	profileRepo  repository.ProfileRepository
+	followRepo   repository.FollowRepository
+	timelineRepo repository.TimelineRepository
 }
 
-func NewProfileService(db *gorm.DB, userRepo repository.UserRepository, profileRepo repository.ProfileRepository, followRepo repository.FollowRepository) *ProfileService {
+func NewProfileService(db *gorm.DB, userRepo repository.UserRepository, profileRepo repository.ProfileRepository, followRepo repository.FollowRepository, timelineRepo repository.TimelineRepository) *ProfileService {
 	return &ProfileService{
-		db:          db,
-		userRepo:    userRepo,
-		profileRepo: profileRepo,
-		followRepo:  followRepo,
+		db:           db,
+		userRepo:     userRepo,
+		profileRepo:  profileRepo,
+		followRepo:   followRepo,
+		timelineRepo: timelineRepo,
 	}
 }
 
@@ -64,7 +66,7 @@ func (s *ProfileService) GetProfileByUsername(ctx context.Context, username stri
 	return resp, nil
 }
 
-// FollowUser creates a follow relationship
+// FollowUser creates a follow relationship and adds the followee's articles to the follower's timeline
 func (s *ProfileService) FollowUser(ctx context.Context, followerID int64, followeeUsername string) (*dtos.ProfileResponse, error) {
 	db := s.db.WithContext(ctx)
 	if err := db.Transaction(func(tx *gorm.DB) error {
@@ -87,6 +89,9 @@ func (s *ProfileService) FollowUser(ctx context.Context, followerID int64, follo
 			if err := s.followRepo.CreateFollow(tx, follow); err != nil {
 				return err
 			}
+			if err := s.timelineRepo.BackfillTimeline(tx, followerID, followee.ID); err != nil {
+				return err
+			}
 		}
 		return nil
 	}); err != nil {
@@ -97,7 +102,7 @@ func (s *ProfileService) FollowUser(ctx context.Context, followerID int64, follo
 	return s.GetProfileByUsername(ctx, followeeUsername, followerID)
 }
 
-// UnfollowUser deletes a follow relationship
+// UnfollowUser deletes a follow relationship and removes the followee's articles from the follower's timeline
 func (s *ProfileService) UnfollowUser(ctx context.Context, followerID int64, followeeUsername string) (*dtos.ProfileResponse, error) {
 	db := s.db.WithContext(ctx)
 	if err := db.Transaction(func(tx *gorm.DB) error {
@@ -110,7 +115,7 @@ func (s *ProfileService) UnfollowUser(ctx context.Context, followerID int64, fol
 		if err := s.followRepo.DeleteFollow(tx, followerID, followee.ID); err != nil {
 			return err
 		}
-		return nil
+		return s.timelineRepo.PruneTimeline(tx, followerID, followee.ID)
 	}); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"go-gin-realworld-api/internal/dtos"
+	appErrors "go-gin-realworld-api/internal/errors"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// relatedCandidates is how many articles are read from each source of related articles
+// (shared tags, co-favorites) before they are scored together
+const relatedCandidates = 100
+
+// Default weights of the related score
+const (
+	defaultRelatedTagWeight        = 2.0
+	defaultRelatedCoFavoriteWeight = 1.0
+)
+
+type RelatedService struct {
+	readDB           *gorm.DB // replica pool for reads that tolerate lag
+	relatedRepo      repository.RelatedRepository
+	articleRepo      repository.ArticleRepository
+	articleService   *ArticleService
+	tagWeight        float64
+	coFavoriteWeight float64
+}
+
+func NewRelatedService(readDB *gorm.DB, relatedRepo repository.RelatedRepository, articleRepo repository.ArticleRepository, articleService *ArticleService) *RelatedService {
+	return &RelatedService{
+		readDB:           readDB,
+		relatedRepo:      relatedRepo,
+		articleRepo:      articleRepo,
+		articleService:   articleService,
+		tagWeight:        defaultRelatedTagWeight,
+		coFavoriteWeight: defaultRelatedCoFavoriteWeight,
+	}
+}
+
+// SetWeights sets what each shared tag and each user who favorited both articles adds to
+// the related score; a weight <= 0 ignores that source
+func (s *RelatedService) SetWeights(tagWeight, coFavoriteWeight float64) {
+	s.tagWeight = tagWeight
+	s.coFavoriteWeight = coFavoriteWeight
+}
+
+// RelatedArticles returns the articles most related to the article with slug, highest
+// score first and ties to the newer article. The current user's own articles are left out.
+func (s *RelatedService) RelatedArticles(ctx context.Context, slug string, query *dtos.RelatedArticlesQuery, currentUserID *int64) (*dtos.ArticlesListResponse, error) {
+	db := s.readDB.WithContext(ctx)
+	source, err := findArticleBySlug(db, s.articleRepo, slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrNotFound
+		}
+		return nil, err
+	}
+	if !articleVisibleTo(source, currentUserID) {
+		return nil, appErrors.ErrNotFound
+	}
+
+	scores := make(map[int64]float64)
+	for _, candidates := range []struct {
+		weight float64
+		list   func(db *gorm.DB, articleID int64, excludeAuthorID *int64, limit int) ([]repository.RelatedArticle, error)
+	}{
+		{s.tagWeight, s.relatedRepo.ListTagOverlaps},
+		{s.coFavoriteWeight, s.relatedRepo.ListCoFavorites},
+	} {
+		if candidates.weight <= 0 {
+			continue
+		}
+		related, err := candidates.list(db, source.ID, currentUserID, relatedCandidates)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range related {
+			scores[r.ArticleID] += candidates.weight * float64(r.Shared)
+		}
+	}
+
+	ids := make([]int64, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] > ids[j]
+	})
+	ids = ids[:min(len(ids), query.Limit)]
+
+	articles, err := s.articleService.findArticlesInOrder(db, ids)
+	if err != nil {
+		return nil, err
+	}
+	favorited, err := s.articleService.favoritedArticles(db, articles, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	articleResponses := make([]dtos.ArticleResponse, 0, len(articles))
+	for _, article := range articles {
+		resp, err := articleToResponse(article, favorited[article.ID])
+		if err != nil {
+			return nil, err
+		}
+		articleResponses = append(articleResponses, resp)
+	}
+	return &dtos.ArticlesListResponse{Articles: articleResponses}, nil
+}
@@ -2,25 +2,195 @@ package services
 
 import (
 	"context"
+	"slices"
+
+	"go-gin-realworld-api/internal/dtos"
+	appErrors "go-gin-realworld-api/internal/errors"
 	"go-gin-realworld-api/internal/repository"
+	"go-gin-realworld-api/internal/utils"
 
 	"gorm.io/gorm"
 )
 
 type TagService struct {
-	db      *gorm.DB
-	tagRepo repository.TagRepository
+	db             *gorm.DB
+	readDB         *gorm.DB // replica pool for reads that tolerate lag
+	tagRepo        repository.TagRepository
+	articleService *ArticleService // nil to leave the search index alone
 }
 
-func NewTagService(db *gorm.DB, tagRepo repository.TagRepository) *TagService {
+func NewTagService(db, readDB *gorm.DB, tagRepo repository.TagRepository) *TagService {
 	return &TagService{
 		db:      db,
+		readDB:  readDB,
 		tagRepo: tagRepo,
 	}
 }
 
+// SetArticleService makes renames and merges re-index the affected articles in the search
+// index of articleService, since search documents carry tag names
+func (s *TagService) SetArticleService(articleService *ArticleService) {
+	s.articleService = articleService
+}
+
 // GetAllTags retrieves all unique tags
 func (s *TagService) GetAllTags(ctx context.Context) ([]string, error) {
-	db := s.db.WithContext(ctx)
+	db := s.readDB.WithContext(ctx)
 	return s.tagRepo.GetAllTags(db)
 }
+
+// ListTagCounts retrieves tags with the number of articles that use each, alphabetically
+// or, with sort "popular", most used first. A limit <= 0 returns every tag.
+func (s *TagService) ListTagCounts(ctx context.Context, sort string, limit int) ([]dtos.TagCountResponse, error) {
+	order := repository.TagSortName
+	if sort == string(repository.TagSortPopular) {
+		order = repository.TagSortPopular
+	}
+
+	db := s.readDB.WithContext(ctx)
+	counts, err := s.tagRepo.ListTagCounts(db, order, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]dtos.TagCountResponse, 0, len(counts))
+	for _, count := range counts {
+		tags = append(tags, dtos.TagCountResponse{Name: count.Name, ArticlesCount: count.Articles})
+	}
+	return tags, nil
+}
+
+// SuggestTags returns up to limit tags that start with prefix, most used first, for
+// type-ahead. The prefix is normalized like a tag name.
+func (s *TagService) SuggestTags(ctx context.Context, prefix string, limit int) ([]string, error) {
+	prefix = utils.NormalizeTag(prefix)
+	if prefix == "" {
+		return []string{}, nil
+	}
+
+	db := s.readDB.WithContext(ctx)
+	return s.tagRepo.SuggestTags(db, prefix, limit)
+}
+
+// RenameTag renames a tag on every article that uses it. Both names are normalized, so
+// "Go" finds the tag "go". Renaming onto an existing tag fails with ErrTagExists; merge
+// the tags instead.
+func (s *TagService) RenameTag(ctx context.Context, from, to string) error {
+	from = utils.NormalizeTag(from)
+	names, err := NormalizeTags([]string{to})
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return appErrors.ErrEmptyTagName
+	}
+	to = names[0]
+	if from == to {
+		return nil
+	}
+
+	err = s.tagRepo.RenameTag(s.db.WithContext(ctx), from, to)
+	if appErrors.IsUniqueViolation(err) {
+		return appErrors.ErrTagExists
+	}
+	if err != nil {
+		return err
+	}
+	s.reindexTagged(ctx, []string{to})
+	return nil
+}
+
+// MergeTags retags the articles of tag from with tag into and deletes from. Both names
+// are normalized. It returns the number of articles that had from.
+func (s *TagService) MergeTags(ctx context.Context, from, into string) (int64, error) {
+	from, into = utils.NormalizeTag(from), utils.NormalizeTag(into)
+	if from == into {
+		return 0, appErrors.ErrMergeTagIntoItself
+	}
+	merged, err := s.tagRepo.MergeTags(s.db.WithContext(ctx), from, into)
+	if err != nil {
+		return 0, err
+	}
+	s.reindexTagged(ctx, []string{into})
+	return merged, nil
+}
+
+// DeleteUnusedTags deletes the tags no article uses and returns their names
+func (s *TagService) DeleteUnusedTags(ctx context.Context) ([]string, error) {
+	return s.tagRepo.DeleteUnusedTags(s.db.WithContext(ctx))
+}
+
+// NormalizedTag is an existing tag NormalizeExistingTags changed: renamed to To, or merged
+// into To when another tag already had that name
+type NormalizedTag struct {
+	From   string
+	To     string
+	Merged bool
+}
+
+// NormalizeExistingTags gives every tag its normalized name, merging the tags whose names
+// differ only by case, spacing or Unicode form, as tags assigned before names were
+// normalized can. It runs in one transaction and returns the tags it changed.
+func (s *TagService) NormalizeExistingTags(ctx context.Context) ([]NormalizedTag, error) {
+	var changed []NormalizedTag
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		counts, err := s.tagRepo.ListTagCounts(tx, repository.TagSortName, 0)
+		if err != nil {
+			return err
+		}
+
+		groups := make(map[string][]string)
+		var order []string
+		for _, count := range counts {
+			normalized := utils.NormalizeTag(count.Name)
+			if _, ok := groups[normalized]; !ok {
+				order = append(order, normalized)
+			}
+			groups[normalized] = append(groups[normalized], count.Name)
+		}
+
+		changed = make([]NormalizedTag, 0)
+		for _, normalized := range order {
+			names := groups[normalized]
+			// Keep the tag that already has the normalized name, or else rename the first
+			if !slices.Contains(names, normalized) {
+				if err := s.tagRepo.RenameTag(tx, names[0], normalized); err != nil {
+					return err
+				}
+				changed = append(changed, NormalizedTag{From: names[0], To: normalized})
+				names = names[1:]
+			}
+			for _, name := range names {
+				if name == normalized {
+					continue
+				}
+				if _, err := s.tagRepo.MergeTags(tx, name, normalized); err != nil {
+					return err
+				}
+				changed = append(changed, NormalizedTag{From: name, To: normalized, Merged: true})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(changed))
+	for _, tag := range changed {
+		if !slices.Contains(names, tag.To) {
+			names = append(names, tag.To)
+		}
+	}
+	s.reindexTagged(ctx, names)
+	return changed, nil
+}
+
+// reindexTagged re-indexes the articles with any of the tags names after their tag lists
+// changed
+func (s *TagService) reindexTagged(ctx context.Context, names []string) {
+	if s.articleService == nil || len(names) == 0 {
+		return
+	}
+	s.articleService.refreshSearchIndex(ctx, repository.ArticleFilter{Tags: names, TagMode: repository.TagModeAny})
+}
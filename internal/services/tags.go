@@ -0,0 +1,38 @@
+package services
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	appErrors "go-gin-realworld-api/internal/errors"
+	"go-gin-realworld-api/internal/utils"
+)
+
+// MaxTagLength is the maximum length of a normalized tag name, in characters
+const MaxTagLength = 50
+
+// MaxTagsPerArticle is the maximum number of distinct tags of an article
+const MaxTagsPerArticle = 10
+
+// NormalizeTags normalizes a list of tag names, dropping empty names and later duplicates.
+// When the result breaks the limits it fails with an error wrapping ErrTagTooLong or
+// ErrTooManyTags, whose message tells the limit.
+func NormalizeTags(names []string) ([]string, error) {
+	tags := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		tag := utils.NormalizeTag(name)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			return nil, fmt.Errorf("%w: %q has more than %d characters", appErrors.ErrTagTooLong, tag, MaxTagLength)
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	if len(tags) > MaxTagsPerArticle {
+		return nil, fmt.Errorf("%w: an article can have at most %d", appErrors.ErrTooManyTags, MaxTagsPerArticle)
+	}
+	return tags, nil
+}
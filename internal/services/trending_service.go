@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"go-gin-realworld-api/internal/dtos"
+	"go-gin-realworld-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// trendingWindows are the trending rankings by window (GET /api/articles/trending?window=)
+var trendingWindows = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+}
+
+// Trending scores: each favorite or comment in the window adds its weight divided by
+// (hours since it was made + 2)^gravity, so recent activity counts most and fades fast
+const (
+	trendingFavoriteWeight = 1.0
+	trendingCommentWeight  = 2.0
+	trendingGravity        = 1.5
+)
+
+// trendingBandHours are the ends, in hours ago, of the age bands activity is counted in by
+// the database. Activity in a band is scored as if made at its middle; bands widen with
+// age, as the score changes less, and every window ends on one.
+var trendingBandHours = []int{1, 2, 3, 4, 5, 6, 8, 10, 12, 15, 18, 21, 24, 30, 36, 42, 48, 60, 72, 84, 96, 120, 144, 168}
+
+type TrendingService struct {
+	db             *gorm.DB
+	readDB         *gorm.DB // replica pool for reads that tolerate lag
+	trendingRepo   repository.TrendingRepository
+	articleService *ArticleService
+}
+
+func NewTrendingService(db, readDB *gorm.DB, trendingRepo repository.TrendingRepository, articleService *ArticleService) *TrendingService {
+	return &TrendingService{
+		db:             db,
+		readDB:         readDB,
+		trendingRepo:   trendingRepo,
+		articleService: articleService,
+	}
+}
+
+// TrendingArticles returns a page of the articles ranked for a window, highest score first.
+// Until a refresh has ranked any article for the window, the newest articles are listed.
+func (s *TrendingService) TrendingArticles(ctx context.Context, query *dtos.TrendingArticlesQuery, currentUserID *int64) (*dtos.ArticlesListResponse, error) {
+	limit, offset := normalizeLimitOffset(query.Limit, query.Offset)
+	db := s.readDB.WithContext(ctx)
+
+	ids, total, err := s.trendingRepo.ListTrending(db, query.Window, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if total == 0 {
+		response, err := s.articleService.ListArticles(ctx, &dtos.ListArticlesQuery{Limit: limit, Offset: offset}, currentUserID)
+		if err != nil {
+			return nil, err
+		}
+		// Trending pages by offset only
+		response.NextCursor, response.PrevCursor = "", ""
+		return response, nil
+	}
+
+	articles, err := s.articleService.findArticlesInOrder(db, ids)
+	if err != nil {
+		return nil, err
+	}
+	favorited, err := s.articleService.favoritedArticles(db, articles, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	articleResponses := make([]dtos.ArticleResponse, 0, len(articles))
+	for _, article := range articles {
+		resp, err := articleToResponse(article, favorited[article.ID])
+		if err != nil {
+			return nil, err
+		}
+		articleResponses = append(articleResponses, resp)
+	}
+	count := int(total)
+	return &dtos.ArticlesListResponse{Articles: articleResponses, ArticlesCount: &count}, nil
+}
+
+// RefreshTrending recomputes the ranking of every window from the favorites and comments
+// made within it, which the database counts by article and age band
+func (s *TrendingService) RefreshTrending(ctx context.Context) error {
+	now := time.Now()
+	bounds := make([]time.Time, 0, len(trendingBandHours))
+	for _, hours := range trendingBandHours {
+		bounds = append(bounds, now.Add(-time.Duration(hours)*time.Hour))
+	}
+
+	readDB := s.readDB.WithContext(ctx)
+	favorites, err := s.trendingRepo.CountFavoritesByBand(readDB, bounds)
+	if err != nil {
+		return err
+	}
+	comments, err := s.trendingRepo.CountCommentsByBand(readDB, bounds)
+	if err != nil {
+		return err
+	}
+
+	db := s.db.WithContext(ctx)
+	for window, span := range trendingWindows {
+		scores := trendingScores(favorites, comments, span)
+		if err := s.trendingRepo.ReplaceTrendingScores(db, window, scores, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshTrendingIfDue refreshes the rankings unless another server did within the last
+// interval, so that servers sharing a database do not all repeat the same refresh
+func (s *TrendingService) refreshTrendingIfDue(ctx context.Context, interval time.Duration) error {
+	now := time.Now()
+	// Leave some slack so that a tick arriving a little early still finds the refresh due
+	claimed, err := s.trendingRepo.ClaimTrendingRefresh(s.db.WithContext(ctx), now, now.Add(-interval*9/10))
+	if err != nil || !claimed {
+		return err
+	}
+	return s.RefreshTrending(ctx)
+}
+
+// RunTrendingRefresher refreshes the rankings now and then every interval until ctx is
+// done, skipping a refresh another server already made within the interval. A failed
+// refresh is logged and the previous rankings are kept until the next one.
+func (s *TrendingService) RunTrendingRefresher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.refreshTrendingIfDue(ctx, interval); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to refresh trending articles: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// trendingScores scores the articles with favorites or comments counted in the bands
+// that end within span
+func trendingScores(favorites, comments []repository.ActivityCount, span time.Duration) []repository.TrendingScore {
+	scores := make(map[int64]float64)
+	add := func(counts []repository.ActivityCount, weight float64) {
+		for _, c := range counts {
+			end := trendingBandHours[c.Band]
+			if time.Duration(end)*time.Hour > span {
+				continue
+			}
+			start := 0
+			if c.Band > 0 {
+				start = trendingBandHours[c.Band-1]
+			}
+			hours := float64(start+end) / 2
+			scores[c.ArticleID] += float64(c.Count) * weight / math.Pow(hours+2, trendingGravity)
+		}
+	}
+	add(favorites, trendingFavoriteWeight)
+	add(comments, trendingCommentWeight)
+
+	ranked := make([]repository.TrendingScore, 0, len(scores))
+	for articleID, score := range scores {
+		ranked = append(ranked, repository.TrendingScore{ArticleID: articleID, Score: score})
+	}
+	return ranked
+}
@@ -3,21 +3,20 @@ package services
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"go-gin-realworld-api/internal/dtos"
 	customErr "go-gin-realworld-api/internal/errors"
 	"go-gin-realworld-api/internal/models"
 	"go-gin-realworld-api/internal/repository"
 
-	"github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
 )
 
 type UserService struct {
-	db          *gorm.DB
-	userRepo    repository.UserRepository
-	profileRepo repository.ProfileRepository
-	followRepo  repository.FollowRepository
+	db             *gorm.DB
+	userRepo       repository.UserRepository
+	profileRepo    repository.ProfileRepository
+	followRepo     repository.FollowRepository
+	articleService *ArticleService // nil to leave the search index alone
 }
 
 func NewUserService(db *gorm.DB, userRepo repository.UserRepository, profileRepo repository.ProfileRepository, followRepo repository.FollowRepository) *UserService {
@@ -29,6 +28,12 @@ func NewUserService(db *gorm.DB, userRepo repository.UserRepository, profileRepo
 	}
 }
 
+// SetArticleService makes username changes re-index the user's articles in the search
+// index of articleService, since search documents carry the author's username
+func (s *UserService) SetArticleService(articleService *ArticleService) {
+	s.articleService = articleService
+}
+
 // RegisterUser registers a new user
 func (s *UserService) RegisterUser(c context.Context, username, email, password string) (*models.User, error) {
 
@@ -70,9 +75,33 @@ func (s *UserService) GetUserByID(c context.Context, id int64) (*models.User, er
 	return s.userRepo.FindUserByID(s.db.WithContext(c), id)
 }
 
-// UpdateUser updates user information
+// UpdateUser updates user information with the fields of req that are not empty
 func (s *UserService) UpdateUser(c context.Context, userID int64, req *dtos.UpdateUserRequest) (*models.User, error) {
+	patch := &dtos.UserPatch{}
+	for _, field := range []struct {
+		value  string
+		member *dtos.Optional[string]
+	}{
+		{req.User.Email, &patch.Email},
+		{req.User.Username, &patch.Username},
+		{req.User.Password, &patch.Password},
+		{req.User.Image, &patch.Image},
+		{req.User.Bio, &patch.Bio},
+	} {
+		if field.value != "" {
+			*field.member = dtos.OptionalOf(field.value)
+		}
+	}
+	return s.PatchUser(c, userID, &dtos.PatchUserRequest{User: patch})
+}
+
+// PatchUser applies a merge patch to a user and their profile; a null image or bio clears
+// it. The returned user carries the updated profile. A username or email that is taken
+// fails with ErrUserAlreadyExists.
+func (s *UserService) PatchUser(c context.Context, userID int64, req *dtos.PatchUserRequest) (*models.User, error) {
 	var user *models.User
+	var renamed bool
+	patch := req.User
 
 	err := s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
 		// Get user
@@ -82,19 +111,22 @@ func (s *UserService) UpdateUser(c context.Context, userID int64, req *dtos.Upda
 			return err
 		}
 
-		// Update user fields if provided
-		if req.User.Email != "" {
-			user.Email = req.User.Email
+		if patch.Email.Set {
+			user.Email = patch.Email.Value
 		}
-		if req.User.Username != "" {
-			user.Username = req.User.Username
+		if patch.Username.Set {
+			renamed = user.Username != patch.Username.Value
+			user.Username = patch.Username.Value
 		}
-		if req.User.Password != "" {
-			user.Password = hashPassword(req.User.Password)
+		if patch.Password.Set {
+			user.Password = hashPassword(patch.Password.Value)
 		}
 
 		// Update user
 		if err := s.userRepo.UpdateUser(tx, user); err != nil {
+			if isDuplicateUserError(err) {
+				return customErr.ErrUserAlreadyExists
+			}
 			return err
 		}
 
@@ -107,12 +139,11 @@ func (s *UserService) UpdateUser(c context.Context, userID int64, req *dtos.Upda
 			}
 		}
 
-		// Update profile fields if provided
-		if req.User.Image != "" {
-			profile.Image = sql.NullString{String: req.User.Image, Valid: true}
+		if patch.Image.Set {
+			profile.Image = sql.NullString{String: patch.Image.Value, Valid: !patch.Image.Null}
 		}
-		if req.User.Bio != "" {
-			profile.Bio = sql.NullString{String: req.User.Bio, Valid: true}
+		if patch.Bio.Set {
+			profile.Bio = sql.NullString{String: patch.Bio.Value, Valid: !patch.Bio.Null}
 		}
 
 		// Update profile
@@ -120,18 +151,21 @@ func (s *UserService) UpdateUser(c context.Context, userID int64, req *dtos.Upda
 			return err
 		}
 
+		user.Profile = profile
 		return nil
 	})
+	if err != nil {
+		return user, err
+	}
 
-	return user, err
+	if renamed && s.articleService != nil {
+		s.articleService.refreshSearchIndex(c, repository.ArticleFilter{AuthorID: userID})
+	}
+	return user, nil
 }
 
 // isDuplicateUserError checks if the error is a duplicate user error
-// by checking MySQL error code 1062
+// (unique violation on username or email, regardless of database driver)
 func isDuplicateUserError(err error) bool {
-	var mysqlErr *mysql.MySQLError
-	if errors.As(err, &mysqlErr) {
-		return mysqlErr.Number == customErr.MySQLErrDuplicateEntry
-	}
-	return false
+	return customErr.IsUniqueViolation(err)
 }
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+	appErrors "go-gin-realworld-api/internal/errors"
+	"time"
+)
+
+// Cursor is an opaque pagination position in the newest-first article order.
+// Backward cursors page towards newer articles (prevCursor).
+type Cursor struct {
+	CreatedAt time.Time
+	ID        int64
+	Backward  bool
+}
+
+// EncodeCursor encodes a cursor as a URL-safe string
+func EncodeCursor(cursor Cursor) string {
+	direction := "n"
+	if cursor.Backward {
+		direction = "p"
+	}
+	raw := fmt.Sprintf("%s:%d:%d", direction, cursor.CreatedAt.UnixNano(), cursor.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor produced by EncodeCursor
+func DecodeCursor(encoded string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, appErrors.ErrInvalidCursor
+	}
+
+	var direction string
+	var nanos, id int64
+	if _, err := fmt.Sscanf(string(raw), "%1s:%d:%d", &direction, &nanos, &id); err != nil {
+		return Cursor{}, appErrors.ErrInvalidCursor
+	}
+	if (direction != "n" && direction != "p") || id <= 0 {
+		return Cursor{}, appErrors.ErrInvalidCursor
+	}
+
+	return Cursor{
+		CreatedAt: time.Unix(0, nanos),
+		ID:        id,
+		Backward:  direction == "p",
+	}, nil
+}
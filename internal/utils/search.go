@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"html"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxSearchTerms bounds the number of words of a search query that are used
+const maxSearchTerms = 10
+
+// SearchTerms splits a search query into its distinct lowercased words
+func SearchTerms(query string) []string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		if !slices.Contains(terms, word) {
+			terms = append(terms, word)
+		}
+		if len(terms) == maxSearchTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// Highlight HTML-escapes text and wraps every word that starts with one of the terms in
+// <em> tags, so that "connect" highlights "Connection" and "connecting". With maxLen > 0
+// the text is first cut to an excerpt of about maxLen bytes around the first match, marked
+// with "…" where it was cut. It reports whether any term matched; without a match the
+// excerpt is taken from the start of the text.
+func Highlight(text string, terms []string, maxLen int) (string, bool) {
+	matches := matchingWords(text, terms)
+
+	start, end := 0, len(text)
+	prefix, suffix := "", ""
+	if maxLen > 0 && len(text) > maxLen {
+		if len(matches) > 0 {
+			start = excerptStart(text, matches[0][0], maxLen/4)
+		}
+		end = excerptEnd(text, start, maxLen)
+		if start > 0 {
+			prefix = "…"
+		}
+		if end < len(text) {
+			suffix = "…"
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	last := start
+	for _, match := range matches {
+		if match[0] < start || match[1] > end {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[last:match[0]]))
+		b.WriteString("<em>")
+		b.WriteString(html.EscapeString(text[match[0]:match[1]]))
+		b.WriteString("</em>")
+		last = match[1]
+	}
+	b.WriteString(html.EscapeString(text[last:end]))
+	b.WriteString(suffix)
+	return b.String(), len(matches) > 0
+}
+
+// matchingWords returns the byte ranges of the words of text that start with one of the terms
+func matchingWords(text string, terms []string) [][2]int {
+	var matches [][2]int
+	wordStart := -1
+	for i, r := range text + " " {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			if wordStart < 0 {
+				wordStart = i
+			}
+			continue
+		}
+		if wordStart < 0 {
+			continue
+		}
+
+		word := strings.ToLower(text[wordStart:i])
+		for _, term := range terms {
+			if term != "" && strings.HasPrefix(word, term) {
+				matches = append(matches, [2]int{wordStart, i})
+				break
+			}
+		}
+		wordStart = -1
+	}
+	return matches
+}
+
+// excerptStart backs up to context bytes before the match, starting at a word boundary
+func excerptStart(text string, match, context int) int {
+	start := max(match-context, 0)
+	if start == 0 {
+		return 0
+	}
+	if i := strings.IndexByte(text[start:match], ' '); i >= 0 {
+		return start + i + 1
+	}
+	for start < match && !utf8.RuneStart(text[start]) {
+		start++
+	}
+	return start
+}
+
+// excerptEnd ends an excerpt of at most maxLen bytes at a word boundary when possible
+func excerptEnd(text string, start, maxLen int) int {
+	end := min(start+maxLen, len(text))
+	if end == len(text) {
+		return end
+	}
+	if i := strings.LastIndexByte(text[start:end], ' '); i > 0 {
+		return start + i
+	}
+	for end > start && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	return end
+}
@@ -1,28 +1,141 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/rand/v2"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
 )
 
-// GenerateSlug generates a URL-friendly slug from a title
+// numberedSlugAttempts is how many candidates use a sequential suffix ("-2", "-3", ...)
+// before SlugCandidate switches to random suffixes
+const numberedSlugAttempts = 5
+
+const slugSuffixAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// MaxSlugLength caps generated slugs well below the varchar(500) column,
+// leaving room for the suffixes added by SlugCandidate
+const MaxSlugLength = 200
+
+// reservedSlugs are the static routes next to /api/articles/:slug, which would shadow an
+// article with one of these slugs
+var reservedSlugs = map[string]bool{
+	"feed":     true,
+	"search":   true,
+	"trending": true,
+}
+
+// slugHashLength is the number of hex characters of the title hash used when
+// part of a title cannot be transliterated
+const slugHashLength = 8
+
+// transliterations maps letters that do not decompose into ASCII under NFD.
+// Accented Latin letters ("é", "ệ") need no entry: their combining marks are dropped.
+var transliterations = map[rune]string{
+	// Latin
+	'đ': "d", 'ð': "d", 'ħ': "h", 'ı': "i", 'ł': "l", 'ø': "o", 'ŧ': "t",
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'þ': "th",
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'ґ': "g", 'д': "d", 'е': "e", 'є': "ye",
+	'ж': "zh", 'з': "z", 'и': "i", 'і': "i", 'ј': "j", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f",
+	'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y",
+	'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	// Greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th",
+	'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p",
+	'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps",
+	'ω': "o",
+}
+
+var multipleHyphens = regexp.MustCompile("-+")
+
+// GenerateSlug generates a URL-friendly slug from a title.
+//
+// Latin, Cyrillic and Greek letters are transliterated to ASCII ("Tiếng Việt" becomes
+// "tieng-viet"). Letters of other scripts, such as CJK, cannot be; a short hash of the
+// title is appended instead so that such titles still get distinct, non-empty slugs.
+// The result is at most MaxSlugLength bytes, cut at a word boundary.
 func GenerateSlug(title string) string {
-	// Convert to lowercase
-	slug := strings.ToLower(title)
+	var b strings.Builder
+	untransliterated := false
+	for _, r := range norm.NFD.String(strings.ToLower(title)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteByte('-')
+		case r < utf8.RuneSelf || unicode.Is(unicode.Mn, r):
+			// Drop ASCII punctuation and the combining marks split off by NFD
+		default:
+			if ascii, ok := transliterations[r]; ok {
+				b.WriteString(ascii)
+			} else if unicode.IsLetter(r) || unicode.IsNumber(r) {
+				untransliterated = true
+				b.WriteByte('-')
+			}
+		}
+	}
 
-	// Replace spaces with hyphens
-	slug = strings.ReplaceAll(slug, " ", "-")
+	// Remove multiple consecutive hyphens and leading/trailing hyphens
+	slug := strings.Trim(multipleHyphens.ReplaceAllString(b.String(), "-"), "-")
 
-	// Remove special characters except hyphens
-	reg := regexp.MustCompile("[^a-z0-9-]+")
-	slug = reg.ReplaceAllString(slug, "")
+	if !untransliterated {
+		return truncateSlug(slug, MaxSlugLength)
+	}
+
+	sum := sha256.Sum256([]byte(strings.TrimSpace(title)))
+	hash := hex.EncodeToString(sum[:])[:slugHashLength]
+	if slug = truncateSlug(slug, MaxSlugLength-len(hash)-1); slug == "" {
+		return hash
+	}
+	return slug + "-" + hash
+}
+
+// truncateSlug shortens slug to at most max bytes, dropping the last partial word
+func truncateSlug(slug string, max int) string {
+	if len(slug) <= max {
+		return slug
+	}
+
+	cut := slug[:max]
+	if slug[max] != '-' {
+		// A word longer than max is cut mid-word rather than dropped
+		if i := strings.LastIndexByte(cut, '-'); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	return strings.Trim(cut, "-")
+}
 
-	// Remove multiple consecutive hyphens
-	reg = regexp.MustCompile("-+")
-	slug = reg.ReplaceAllString(slug, "-")
+// SlugCandidate returns the slug to try on the given attempt (starting at 1) when
+// allocating a unique slug: the base slug itself, then numbered variants such as
+// "hello-world-2", then variants with a short random suffix. A reserved base, such as
+// "feed", is never returned as is, so its candidates start at "feed-2".
+func SlugCandidate(base string, attempt int) string {
+	if reservedSlugs[base] {
+		attempt++
+	}
 
-	// Remove leading/trailing hyphens
-	slug = strings.Trim(slug, "-")
+	switch {
+	case attempt <= 1 && base != "":
+		return base
+	case attempt <= numberedSlugAttempts && base != "":
+		return base + "-" + strconv.Itoa(attempt)
+	}
 
-	return slug
+	suffix := make([]byte, 6)
+	for i := range suffix {
+		suffix[i] = slugSuffixAlphabet[rand.IntN(len(slugSuffixAlphabet))]
+	}
+	if base == "" {
+		return string(suffix)
+	}
+	return base + "-" + string(suffix)
 }
@@ -0,0 +1,13 @@
+package utils
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeTag returns the canonical form of a tag name: NFC-normalized, trimmed,
+// lowercased, with each run of whitespace collapsed into a single space
+func NormalizeTag(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(norm.NFC.String(name)), " "))
+}
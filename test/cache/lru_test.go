@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"go-gin-realworld-api/internal/cache"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := cache.NewLRU[string, int](2, 0)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	// Reading a makes b the least recently used
+	value, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	c.Set("c", 3)
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+	value, ok = c.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+
+	assert.Equal(t, cache.Stats{Hits: 2, Misses: 1, Evictions: 1, Size: 2, Capacity: 2}, c.Stats())
+}
+
+func TestLRU_SetReplacesValue(t *testing.T) {
+	c := cache.NewLRU[string, int](2, 0)
+	c.Set("a", 1)
+	c.Set("a", 2)
+
+	value, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+	assert.Equal(t, 1, c.Stats().Size)
+}
+
+func TestLRU_EntriesExpire(t *testing.T) {
+	c := cache.NewLRU[string, int](2, 20*time.Millisecond)
+	c.Set("a", 1)
+
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	time.Sleep(30 * time.Millisecond)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, cache.Stats{Hits: 1, Misses: 1, Size: 0, Capacity: 2}, c.Stats())
+}
+
+func TestLRU_Remove(t *testing.T) {
+	c := cache.NewLRU[string, int](4, 0)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	c.Remove("a")
+	c.RemoveFunc(func(key string, value int) bool { return value == 3 })
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	_, ok = c.Get("c")
+	assert.False(t, ok)
+	_, ok = c.Get("b")
+	assert.True(t, ok)
+
+	c.Clear()
+	assert.Equal(t, 0, c.Stats().Size)
+}
@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	appErrors "go-gin-realworld-api/internal/errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "Translated gorm error", err: gorm.ErrDuplicatedKey, expected: true},
+		{name: "Wrapped translated gorm error", err: fmt.Errorf("create user: %w", gorm.ErrDuplicatedKey), expected: true},
+		{name: "MySQL duplicate entry", err: &mysql.MySQLError{Number: 1062}, expected: true},
+		{name: "Postgres unique violation", err: &pgconn.PgError{Code: "23505"}, expected: true},
+		{name: "MySQL other error", err: &mysql.MySQLError{Number: 1452}, expected: false},
+		{name: "Postgres foreign key violation", err: &pgconn.PgError{Code: "23503"}, expected: false},
+		{name: "Unrelated error", err: errors.New("boom"), expected: false},
+		{name: "Nil error", err: nil, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, appErrors.IsUniqueViolation(tt.err))
+		})
+	}
+}
+
+func TestIsForeignKeyViolation(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "Translated gorm error", err: gorm.ErrForeignKeyViolated, expected: true},
+		{name: "MySQL row is referenced", err: &mysql.MySQLError{Number: 1451}, expected: true},
+		{name: "MySQL no referenced row", err: &mysql.MySQLError{Number: 1452}, expected: true},
+		{name: "Postgres foreign key violation", err: &pgconn.PgError{Code: "23503"}, expected: true},
+		{name: "MySQL duplicate entry", err: &mysql.MySQLError{Number: 1062}, expected: false},
+		{name: "Postgres unique violation", err: &pgconn.PgError{Code: "23505"}, expected: false},
+		{name: "Unrelated error", err: errors.New("boom"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, appErrors.IsForeignKeyViolation(tt.err))
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"go-gin-realworld-api/internal/dtos"
 	"go-gin-realworld-api/internal/handlers"
 	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
 	"go-gin-realworld-api/internal/services"
 	"go-gin-realworld-api/test/mocks"
 
@@ -22,18 +23,24 @@ import (
 )
 
 type articleHandlerMocks struct {
-	articleRepo *mocks.MockArticleRepository
-	sqlMock     sqlmock.Sqlmock
+	articleRepo  *mocks.MockArticleRepository
+	favoriteRepo *mocks.MockFavoriteRepository
+	followRepo   *mocks.MockFollowRepository
+	timelineRepo *mocks.MockTimelineRepository
+	sqlMock      sqlmock.Sqlmock
 }
 
 func setupArticleHandlerTest(t *testing.T) (*gin.Engine, *handlers.ArticleHandler, articleHandlerMocks) {
 	m := articleHandlerMocks{
-		articleRepo: new(mocks.MockArticleRepository),
+		articleRepo:  new(mocks.MockArticleRepository),
+		favoriteRepo: new(mocks.MockFavoriteRepository),
+		followRepo:   new(mocks.MockFollowRepository),
+		timelineRepo: new(mocks.MockTimelineRepository),
 	}
 
 	mockDB, sqlMock := CreateMockDB(t)
 	m.sqlMock = sqlMock
-	articleService := services.NewArticleService(mockDB, m.articleRepo)
+	articleService := services.NewArticleService(mockDB, mockDB, m.articleRepo, m.favoriteRepo, m.followRepo, m.timelineRepo, nil)
 	articleHandler := handlers.NewArticleHandler(articleService)
 
 	router := SetupRouter()
@@ -57,7 +64,7 @@ func TestArticleHandler_ListArticles_Success(t *testing.T) {
 		},
 	}
 
-	m.articleRepo.On("ListArticles", mock.Anything, "", "", (*bool)(nil), (*int64)(nil), 20, 0).
+	m.articleRepo.On("ListArticles", mock.Anything, repository.ArticleFilter{}, (*int64)(nil), repository.ArticlePage{Limit: 21}).
 		Return(articles, int64(1), nil)
 
 	req, _ := http.NewRequest("GET", "/api/articles", nil)
@@ -69,13 +76,151 @@ func TestArticleHandler_ListArticles_Success(t *testing.T) {
 	var resp dtos.ArticlesListResponse
 	err := json.Unmarshal(w.Body.Bytes(), &resp)
 	assert.NoError(t, err)
-	assert.Equal(t, 1, resp.ArticlesCount)
+	assert.Equal(t, 1, *resp.ArticlesCount)
 	assert.Equal(t, "test-article", resp.Articles[0].Slug)
 	assert.Equal(t, "author1", resp.Articles[0].Author.Username)
 
 	m.articleRepo.AssertExpectations(t)
 }
 
+func TestArticleHandler_ListArticles_TagFilters(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+	router.GET("/api/articles", articleHandler.ListArticles)
+
+	// Tags are normalized and de-duplicated, whether comma-separated or repeated
+	filter := repository.ArticleFilter{
+		Tags:        []string{"go", "testing"},
+		TagMode:     repository.TagModeAll,
+		ExcludeTags: []string{"beginner"},
+	}
+	m.articleRepo.On("ListArticles", mock.Anything, filter, (*int64)(nil), repository.ArticlePage{Limit: 21}).
+		Return([]*models.Article{}, int64(0), nil)
+
+	req, _ := http.NewRequest("GET", "/api/articles?tag=Go,testing&tag=go&tagMode=all&excludeTag=Beginner", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	m.articleRepo.AssertExpectations(t)
+
+	req, _ = http.NewRequest("GET", "/api/articles?tag=go&tagMode=both", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	AssertAPIError(t, w, http.StatusBadRequest, "Validation failed", map[string]string{"TagMode": "is invalid"})
+}
+
+func TestArticleHandler_ListArticles_SortAndDates(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+	router.GET("/api/articles", articleHandler.ListArticles)
+
+	createdAfter := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	createdBefore := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	filter := repository.ArticleFilter{CreatedAfter: createdAfter, CreatedBefore: createdBefore}
+	articles := []*models.Article{
+		{ID: 2, Slug: "popular", Author: &models.User{Username: "author1"}, CreatedAt: createdAfter, UpdatedAt: createdAfter},
+		{ID: 1, Slug: "less-popular", Author: &models.User{Username: "author1"}, CreatedAt: createdAfter, UpdatedAt: createdAfter},
+	}
+	m.articleRepo.On("ListArticles", mock.Anything, filter, (*int64)(nil), repository.ArticlePage{Limit: 2, Sort: repository.ArticleSortMostFavorited}).
+		Return(articles, int64(2), nil)
+
+	req, _ := http.NewRequest("GET", "/api/articles?sort=mostFavorited&limit=1&createdAfter=2026-10-01T00:00:00Z&createdBefore=2026-11-01T00:00:00Z", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dtos.ArticlesListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Articles, 1)
+	assert.Equal(t, "popular", resp.Articles[0].Slug)
+	// Sorts other than newest page by offset, so no cursors are returned
+	assert.Empty(t, resp.NextCursor)
+	m.articleRepo.AssertExpectations(t)
+
+	req, _ = http.NewRequest("GET", "/api/articles?sort=oldest&cursor=bogus", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	AssertAPIError(t, w, http.StatusBadRequest, "cursor pagination is only available with sort=newest; use offset")
+
+	req, _ = http.NewRequest("GET", "/api/articles?sort=random", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	AssertAPIError(t, w, http.StatusBadRequest, "Validation failed", map[string]string{"Sort": "is invalid"})
+
+	req, _ = http.NewRequest("GET", "/api/articles?createdAfter=2026-11-01T00:00:00Z&createdBefore=2026-10-01T00:00:00Z", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	AssertAPIError(t, w, http.StatusBadRequest, "Validation failed", map[string]string{"createdBefore": "must be later than createdAfter"})
+}
+
+func TestArticleHandler_ListArticles_InvalidCursor(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+	router.GET("/api/articles", articleHandler.ListArticles)
+
+	req, _ := http.NewRequest("GET", "/api/articles?cursor=bogus", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	m.articleRepo.AssertNotCalled(t, "ListArticles")
+}
+
+func TestArticleHandler_SearchArticles_Success(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+	router.GET("/api/articles/search", articleHandler.SearchArticles)
+
+	articles := []*models.Article{
+		{
+			ID:          1,
+			Slug:        "gin-middleware",
+			Title:       "Writing Gin middleware",
+			Description: "Description",
+			Body:        "Middleware in Gin wraps every handler",
+			Author:      &models.User{Username: "author1"},
+		},
+	}
+
+	m.articleRepo.On("SearchArticles", mock.Anything, repository.ArticleSearch{
+		Query:  "gin middleware",
+		Terms:  []string{"gin", "middleware"},
+		Author: "author1",
+		Limit:  20,
+	}).Return(articles, int64(1), nil)
+
+	req, _ := http.NewRequest("GET", "/api/articles/search?q=gin+middleware&author=author1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dtos.ArticleSearchResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.ArticlesCount)
+	assert.Equal(t, "gin-middleware", resp.Articles[0].Slug)
+	assert.Equal(t, "Writing <em>Gin</em> <em>middleware</em>", resp.Articles[0].Highlight.Title)
+	assert.Equal(t, "<em>Middleware</em> in <em>Gin</em> wraps every handler", resp.Articles[0].Highlight.Snippet)
+
+	m.articleRepo.AssertExpectations(t)
+}
+
+func TestArticleHandler_SearchArticles_InvalidQuery(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+	router.GET("/api/articles/search", articleHandler.SearchArticles)
+
+	for _, target := range []string{"/api/articles/search", "/api/articles/search?q=%21%3F"} {
+		req, _ := http.NewRequest("GET", target, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, target)
+	}
+	m.articleRepo.AssertNotCalled(t, "SearchArticles")
+}
+
 func TestArticleHandler_GetArticle_Success(t *testing.T) {
 	router, articleHandler, m := setupArticleHandlerTest(t)
 	router.GET("/api/articles/:slug", articleHandler.GetArticle)
@@ -87,6 +232,7 @@ func TestArticleHandler_GetArticle_Success(t *testing.T) {
 		Title:       "Test Article",
 		Description: "Description",
 		Body:        "Body",
+		Status:      models.ArticleStatusPublished,
 		Author:      &models.User{Username: "author1"},
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
@@ -130,9 +276,14 @@ func TestArticleHandler_FeedArticles_Success(t *testing.T) {
 			UpdatedAt:   time.Now(),
 		},
 	}
+	entries := []*models.TimelineEntry{{UserID: 1, ArticleID: 1, CreatedAt: articles[0].CreatedAt}}
 
-	m.articleRepo.On("FeedArticles", mock.Anything, int64(1), 20, 0).
-		Return(articles, int64(1), nil)
+	m.timelineRepo.On("ListTimeline", mock.Anything, int64(1), repository.FeedFilter{}, repository.ArticlePage{Limit: 21}).
+		Return(entries, int64(1), nil)
+	m.timelineRepo.On("ListFanOutOnRead", mock.Anything, int64(1), repository.FeedFilter{}, repository.ArticlePage{Limit: 21}).
+		Return([]*models.TimelineEntry{}, int64(0), nil)
+	m.articleRepo.On("FindArticlesByIDs", mock.Anything, []int64{1}).Return(articles, nil)
+	m.favoriteRepo.On("FavoritedArticleIDs", mock.Anything, int64(1), []int64{1}).Return([]int64{1}, nil)
 
 	req, _ := http.NewRequest("GET", "/api/articles/feed", nil)
 	w := httptest.NewRecorder()
@@ -143,10 +294,68 @@ func TestArticleHandler_FeedArticles_Success(t *testing.T) {
 	var resp dtos.ArticlesListResponse
 	err := json.Unmarshal(w.Body.Bytes(), &resp)
 	assert.NoError(t, err)
-	assert.Equal(t, 1, resp.ArticlesCount)
+	assert.Equal(t, 1, *resp.ArticlesCount)
 	assert.Equal(t, "feed-article", resp.Articles[0].Slug)
+	assert.True(t, resp.Articles[0].Favorited)
 
 	m.articleRepo.AssertExpectations(t)
+	m.favoriteRepo.AssertExpectations(t)
+	m.timelineRepo.AssertExpectations(t)
+}
+
+func TestArticleHandler_FeedArticles_SortAndDates(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", int64(1))
+		c.Next()
+	})
+	router.GET("/api/articles/feed", articleHandler.FeedArticles)
+
+	createdAfter := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	createdBefore := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	filter := repository.FeedFilter{CreatedAfter: createdAfter, CreatedBefore: createdBefore}
+	articles := []*models.Article{
+		{ID: 2, Slug: "popular", Status: models.ArticleStatusPublished, Author: &models.User{Username: "author1"}, CreatedAt: createdAfter, UpdatedAt: createdAfter},
+		{ID: 1, Slug: "less-popular", Status: models.ArticleStatusPublished, Author: &models.User{Username: "author1"}, CreatedAt: createdAfter, UpdatedAt: createdAfter},
+	}
+	entries := []*models.TimelineEntry{{UserID: 1, ArticleID: 2, CreatedAt: createdAfter}, {UserID: 1, ArticleID: 1, CreatedAt: createdAfter}}
+	m.timelineRepo.On("ListFeed", mock.Anything, int64(1), filter, repository.ArticlePage{Limit: 2, Sort: repository.ArticleSortMostFavorited}).
+		Return(entries, int64(2), nil)
+	m.articleRepo.On("FindArticlesByIDs", mock.Anything, []int64{2, 1}).Return(articles, nil)
+	m.favoriteRepo.On("FavoritedArticleIDs", mock.Anything, int64(1), []int64{2}).Return([]int64{}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/articles/feed?sort=mostFavorited&limit=1&createdAfter=2026-10-01T00:00:00Z&createdBefore=2026-11-01T00:00:00Z", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dtos.ArticlesListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Articles, 1)
+	assert.Equal(t, "popular", resp.Articles[0].Slug)
+	assert.Empty(t, resp.NextCursor)
+	m.timelineRepo.AssertExpectations(t)
+	m.timelineRepo.AssertNotCalled(t, "ListTimeline", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	for _, tc := range []struct {
+		name    string
+		query   string
+		message string
+		details []map[string]string
+	}{
+		{"cursor with sort", "sort=oldest&cursor=bogus", "cursor pagination is only available with sort=newest; use offset", nil},
+		{"unknown sort", "sort=random", "Validation failed", []map[string]string{{"Sort": "is invalid"}}},
+		{"empty range", "createdAfter=2026-11-01T00:00:00Z&createdBefore=2026-10-01T00:00:00Z", "Validation failed", []map[string]string{{"createdBefore": "must be later than createdAfter"}}},
+		{"equal ends", "createdAfter=2026-10-01T00:00:00Z&createdBefore=2026-10-01T00:00:00Z", "Validation failed", []map[string]string{{"createdBefore": "must be later than createdAfter"}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/api/articles/feed?"+tc.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			AssertAPIError(t, w, http.StatusBadRequest, tc.message, tc.details...)
+		})
+	}
 }
 
 func TestArticleHandler_CreateArticle_Success(t *testing.T) {
@@ -166,11 +375,14 @@ func TestArticleHandler_CreateArticle_Success(t *testing.T) {
 	jsonBody, _ := json.Marshal(reqBody)
 
 	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	m.followRepo.On("CountFollowers", mock.Anything, int64(1)).Return(int64(2), nil)
 	m.articleRepo.On("CreateArticle", mock.Anything, mock.AnythingOfType("*models.Article")).Return(nil).Run(func(args mock.Arguments) {
 		article := args.Get(1).(*models.Article)
 		article.ID = 1
 	})
 	m.articleRepo.On("AssignTagsToArticle", mock.Anything, int64(1), reqBody.Article.TagList).Return(nil)
+	m.timelineRepo.On("FanOutArticle", mock.Anything, mock.AnythingOfType("*models.Article")).Return(nil)
 	m.sqlMock.ExpectCommit()
 
 	// After creation, the service fetches the article again
@@ -199,6 +411,7 @@ func TestArticleHandler_CreateArticle_Success(t *testing.T) {
 	assert.Equal(t, "new-article", resp.Article.Slug)
 
 	m.articleRepo.AssertExpectations(t)
+	m.timelineRepo.AssertExpectations(t)
 }
 
 func TestArticleHandler_UpdateArticle_Success(t *testing.T) {
@@ -226,6 +439,7 @@ func TestArticleHandler_UpdateArticle_Success(t *testing.T) {
 
 	m.sqlMock.ExpectBegin()
 	m.articleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(existingArticle, nil)
+	m.sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
 	m.articleRepo.On("UpdateArticle", mock.Anything, mock.AnythingOfType("*models.Article")).Return(nil)
 	m.sqlMock.ExpectCommit()
 
@@ -240,6 +454,7 @@ func TestArticleHandler_UpdateArticle_Success(t *testing.T) {
 		UpdatedAt:   time.Now(),
 	}
 	m.articleRepo.On("FindArticleBySlug", mock.Anything, "new-title").Return(updatedArticle, nil)
+	m.favoriteRepo.On("FavoritedArticleIDs", mock.Anything, int64(1), []int64{1}).Return([]int64{}, nil)
 
 	req, _ := http.NewRequest("PUT", "/api/articles/"+slug, bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
@@ -266,7 +481,7 @@ func TestArticleHandler_DeleteArticle_Success(t *testing.T) {
 	router.DELETE("/api/articles/:slug", articleHandler.DeleteArticle)
 
 	slug := "test-article"
-	article := &models.Article{ID: 1, Slug: slug}
+	article := &models.Article{ID: 1, Slug: slug, AuthorID: 1}
 
 	m.sqlMock.ExpectBegin()
 	m.articleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(article, nil)
@@ -288,6 +503,7 @@ func TestArticleHandler_GetArticle_NotFound(t *testing.T) {
 
 	slug := "non-existent"
 	m.articleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(nil, gorm.ErrRecordNotFound)
+	m.articleRepo.On("FindCurrentSlug", mock.Anything, slug).Return("", gorm.ErrRecordNotFound)
 
 	req, _ := http.NewRequest("GET", "/api/articles/"+slug, nil)
 	w := httptest.NewRecorder()
@@ -296,6 +512,25 @@ func TestArticleHandler_GetArticle_NotFound(t *testing.T) {
 	AssertAPIError(t, w, http.StatusNotFound, "article not found")
 }
 
+func TestArticleHandler_GetArticle_MovedSlug(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+	router.GET("/api/articles/:slug", articleHandler.GetArticle)
+
+	m.articleRepo.On("FindArticleBySlug", mock.Anything, "old-title").Return(nil, gorm.ErrRecordNotFound)
+	m.articleRepo.On("FindCurrentSlug", mock.Anything, "old-title").Return("new-title", nil)
+
+	req, _ := http.NewRequest("GET", "/api/articles/old-title?lang=en", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "/api/articles/new-title?lang=en", w.Header().Get("Location"))
+
+	var resp dtos.ArticleMovedResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "new-title", resp.Slug)
+}
+
 func TestArticleHandler_CreateArticle_Unauthorized(t *testing.T) {
 	router, articleHandler, _ := setupArticleHandlerTest(t)
 	router.POST("/api/articles", articleHandler.CreateArticle)
@@ -333,6 +568,174 @@ func TestArticleHandler_UpdateArticle_NotFound(t *testing.T) {
 	AssertAPIError(t, w, http.StatusNotFound, "article not found")
 }
 
+func TestArticleHandler_UpdateArticle_NotAuthor(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		code    int
+		message string
+	}{
+		{"published", models.ArticleStatusPublished, http.StatusForbidden, "you can only edit your own articles"},
+		{"draft", models.ArticleStatusDraft, http.StatusNotFound, "article not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, articleHandler, m := setupArticleHandlerTest(t)
+
+			router.Use(func(c *gin.Context) {
+				c.Set("user_id", int64(2))
+				c.Next()
+			})
+			router.PUT("/api/articles/:slug", articleHandler.UpdateArticle)
+
+			slug := "someone-elses-article"
+			article := &models.Article{ID: 1, Slug: slug, AuthorID: 1, Status: tt.status}
+			reqBody := dtos.UpdateArticleRequest{}
+			reqBody.Article.Body = "Rewritten"
+			jsonBody, _ := json.Marshal(reqBody)
+
+			m.sqlMock.ExpectBegin()
+			m.articleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(article, nil)
+			m.sqlMock.ExpectRollback()
+
+			req, _ := http.NewRequest("PUT", "/api/articles/"+slug, bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			AssertAPIError(t, w, tt.code, tt.message)
+			m.articleRepo.AssertNotCalled(t, "UpdateArticle", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestArticleHandler_UpdateArticle_PreconditionFailed(t *testing.T) {
+	for name, ifMatch := range map[string]string{
+		"stale version": `"1"`,
+		"weak tag":      `W/"2"`,
+		"not a version": `"abc"`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			router, articleHandler, m := setupArticleHandlerTest(t)
+
+			router.Use(func(c *gin.Context) {
+				c.Set("user_id", int64(1))
+				c.Next()
+			})
+			router.PUT("/api/articles/:slug", articleHandler.UpdateArticle)
+
+			slug := "edited-article"
+			reqBody := dtos.UpdateArticleRequest{}
+			reqBody.Article.Body = "New Body"
+			jsonBody, _ := json.Marshal(reqBody)
+
+			m.sqlMock.ExpectBegin()
+			m.articleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(&models.Article{ID: 1, Slug: slug, AuthorID: 1, Version: 2}, nil)
+			m.sqlMock.ExpectRollback()
+
+			req, _ := http.NewRequest("PUT", "/api/articles/"+slug, bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("If-Match", ifMatch)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			AssertAPIError(t, w, http.StatusPreconditionFailed, "article has been changed since it was read")
+			var resp struct {
+				Details struct {
+					Version int64 `json:"version"`
+				} `json:"details"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, int64(2), resp.Details.Version)
+			m.articleRepo.AssertNotCalled(t, "UpdateArticle", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestArticleHandler_PatchArticle_NotNullable(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", int64(1))
+		c.Next()
+	})
+	router.PATCH("/api/articles/:slug", articleHandler.PatchArticle)
+
+	payload := `{"article": {"title": null, "body": null, "tagList": null}}`
+	req, _ := http.NewRequest("PATCH", "/api/articles/some-article", bytes.NewBufferString(payload))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	AssertAPIError(t, w, http.StatusBadRequest, "Validation failed", map[string]string{
+		"title": "cannot be null",
+		"body":  "cannot be null",
+	})
+	m.articleRepo.AssertNotCalled(t, "FindArticleBySlug", mock.Anything, mock.Anything)
+}
+
+func TestArticleHandler_CreateArticle_SlugConflict(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", int64(1))
+		c.Next()
+	})
+	router.POST("/api/articles", articleHandler.CreateArticle)
+
+	reqBody := dtos.CreateArticleRequest{}
+	reqBody.Article.Title = "Hello World"
+	reqBody.Article.Description = "Description"
+	reqBody.Article.Body = "Body"
+	jsonBody, _ := json.Marshal(reqBody)
+
+	// Every candidate slug is taken
+	m.sqlMock.ExpectBegin()
+	for i := 0; i < 8; i++ {
+		m.sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+		m.sqlMock.ExpectExec("ROLLBACK TO SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	m.sqlMock.ExpectRollback()
+	m.followRepo.On("CountFollowers", mock.Anything, int64(1)).Return(int64(0), nil)
+	m.articleRepo.On("CreateArticle", mock.Anything, mock.AnythingOfType("*models.Article")).Return(gorm.ErrDuplicatedKey)
+
+	req, _ := http.NewRequest("POST", "/api/articles", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	AssertAPIError(t, w, http.StatusConflict, "an article with a similar title already exists, please choose another title")
+	m.articleRepo.AssertNumberOfCalls(t, "CreateArticle", 8)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestArticleHandler_CreateArticle_TooManyTags(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", int64(1))
+		c.Next()
+	})
+	router.POST("/api/articles", articleHandler.CreateArticle)
+
+	reqBody := dtos.CreateArticleRequest{}
+	reqBody.Article.Title = "Hello World"
+	reqBody.Article.Description = "Description"
+	reqBody.Article.Body = "Body"
+	reqBody.Article.TagList = []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"}
+	jsonBody, _ := json.Marshal(reqBody)
+
+	req, _ := http.NewRequest("POST", "/api/articles", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	AssertAPIError(t, w, http.StatusBadRequest, "Validation failed", map[string]string{"tagList": "too many tags: an article can have at most 10"})
+	m.articleRepo.AssertNotCalled(t, "CreateArticle", mock.Anything, mock.Anything)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
 func TestArticleHandler_DeleteArticle_NotFound(t *testing.T) {
 	router, articleHandler, m := setupArticleHandlerTest(t)
 
@@ -353,3 +756,86 @@ func TestArticleHandler_DeleteArticle_NotFound(t *testing.T) {
 
 	AssertAPIError(t, w, http.StatusNotFound, "article not found")
 }
+
+func TestArticleHandler_DeleteArticle_NotAuthor(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", int64(2))
+		c.Next()
+	})
+	router.DELETE("/api/articles/:slug", articleHandler.DeleteArticle)
+
+	slug := "someone-elses-article"
+	article := &models.Article{ID: 1, Slug: slug, AuthorID: 1, Status: models.ArticleStatusPublished}
+	m.sqlMock.ExpectBegin()
+	m.articleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(article, nil)
+	m.sqlMock.ExpectRollback()
+
+	req, _ := http.NewRequest("DELETE", "/api/articles/"+slug, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	AssertAPIError(t, w, http.StatusForbidden, "you can only delete your own articles")
+	m.articleRepo.AssertNotCalled(t, "DeleteArticleBySlug", mock.Anything, slug)
+}
+
+func TestArticleHandler_CreateArticle_ScheduledWithoutPublishedAt(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", int64(1))
+		c.Next()
+	})
+	router.POST("/api/articles", articleHandler.CreateArticle)
+
+	reqBody := dtos.CreateArticleRequest{}
+	reqBody.Article.Title = "Hello World"
+	reqBody.Article.Description = "Description"
+	reqBody.Article.Body = "Body"
+	reqBody.Article.Status = models.ArticleStatusScheduled
+	jsonBody, _ := json.Marshal(reqBody)
+
+	req, _ := http.NewRequest("POST", "/api/articles", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	AssertAPIError(t, w, http.StatusBadRequest, "Validation failed", map[string]string{"publishedAt": "invalid publication time: a scheduled article needs a publishedAt in the future"})
+	m.articleRepo.AssertNotCalled(t, "CreateArticle", mock.Anything, mock.Anything)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestArticleHandler_ListDrafts(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", int64(1))
+		c.Next()
+	})
+	router.GET("/api/user/drafts", articleHandler.ListDrafts)
+
+	now := time.Now()
+	filter := repository.ArticleFilter{AuthorID: 1, Statuses: []string{models.ArticleStatusScheduled}}
+	m.articleRepo.On("ListArticles", mock.Anything, filter, mock.Anything, mock.Anything).Return([]*models.Article{
+		{ID: 1, Slug: "coming-soon", Status: models.ArticleStatusScheduled, PublishedAt: &now, Author: &models.User{Username: "testuser"}, CreatedAt: now, UpdatedAt: now},
+	}, int64(1), nil)
+	m.favoriteRepo.On("FavoritedArticleIDs", mock.Anything, int64(1), []int64{1}).Return([]int64{}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/user/drafts?status=scheduled", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dtos.ArticlesListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Articles, 1)
+	assert.Equal(t, models.ArticleStatusScheduled, resp.Articles[0].Status)
+	assert.NotEmpty(t, resp.Articles[0].PublishedAt)
+	m.articleRepo.AssertExpectations(t)
+
+	req, _ = http.NewRequest("GET", "/api/user/drafts?status=published", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-gin-realworld-api/internal/handlers"
+	"go-gin-realworld-api/internal/repository/cached"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheHandler_GetCacheStats(t *testing.T) {
+	router := SetupRouter()
+	router.GET("/health/cache", handlers.NewCacheHandler(cached.NewCaches(100, 0)).GetCacheStats)
+	router.GET("/health/cache/disabled", handlers.NewCacheHandler(nil).GetCacheStats)
+
+	req, _ := http.NewRequest("GET", "/health/cache", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Enabled bool                      `json:"enabled"`
+		Caches  map[string]map[string]int `json:"caches"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Enabled)
+	assert.Equal(t, 100, resp.Caches["articles"]["capacity"])
+	assert.Contains(t, resp.Caches["tags"], "hits")
+
+	req, _ = http.NewRequest("GET", "/health/cache/disabled", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"enabled": false}`, w.Body.String())
+}
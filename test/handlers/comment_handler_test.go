@@ -35,7 +35,7 @@ func setupCommentHandlerTest(t *testing.T) (*gin.Engine, *handlers.CommentHandle
 
 	mockDB, sqlMock := CreateMockDB(t)
 	m.sqlMock = sqlMock
-	commentService := services.NewCommentService(mockDB, m.commentRepo, m.articleRepo)
+	commentService := services.NewCommentService(mockDB, mockDB, m.commentRepo, m.articleRepo)
 	commentHandler := handlers.NewCommentHandler(commentService)
 
 	router := SetupRouter()
@@ -56,7 +56,7 @@ func TestCommentHandler_CreateComment_Success(t *testing.T) {
 	reqBody.Comment.Body = "Test comment body"
 	jsonBody, _ := json.Marshal(reqBody)
 
-	article := &models.Article{ID: 1, Slug: slug}
+	article := &models.Article{ID: 1, Slug: slug, Status: models.ArticleStatusPublished}
 	comment := &models.Comment{
 		ID:        1,
 		Body:      "Test comment body",
@@ -109,7 +109,7 @@ func TestCommentHandler_GetComments_Success(t *testing.T) {
 	router.GET("/api/articles/:slug/comments", commentHandler.GetComments)
 
 	slug := "test-article"
-	article := &models.Article{ID: 1, Slug: slug}
+	article := &models.Article{ID: 1, Slug: slug, Status: models.ArticleStatusPublished}
 	comments := []*models.Comment{
 		{
 			ID:        1,
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-gin-realworld-api/internal/dtos"
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestArticleHandler_GetArticle_Conditional(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+	router.GET("/api/articles/:slug", articleHandler.GetArticle)
+
+	updatedAt := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	article := &models.Article{
+		ID:        1,
+		Slug:      "test-article",
+		Title:     "Test Article",
+		Status:    models.ArticleStatusPublished,
+		Version:   3,
+		Author:    &models.User{Username: "author1"},
+		CreatedAt: updatedAt,
+		UpdatedAt: updatedAt,
+	}
+	m.articleRepo.On("FindArticleBySlug", mock.Anything, "test-article").Return(article, nil)
+
+	get := func(header, value string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/api/articles/test-article", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+	assert.Empty(t, w.Header().Get("Last-Modified"))
+	assert.Equal(t, "public, no-cache", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "Authorization", w.Header().Get("Vary"))
+
+	w = get("If-None-Match", `"other", `+etag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+
+	w = get("If-None-Match", `"other"`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, w.Body.String())
+
+	// A favorite changes neither the version nor updatedAt, but it changes the ETag
+	article.FavoritesCount++
+	w = get("If-None-Match", etag)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	w = get("If-Modified-Since", "Wed, 01 May 2024 12:30:00 GMT")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestArticleHandler_GetArticle_PrivateForUser(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", int64(1))
+		c.Next()
+	})
+	router.GET("/api/articles/:slug", articleHandler.GetArticle)
+
+	article := &models.Article{ID: 1, Slug: "test-article", Status: models.ArticleStatusPublished, Author: &models.User{Username: "author1"}}
+	m.articleRepo.On("FindArticleBySlug", mock.Anything, "test-article").Return(article, nil)
+	m.favoriteRepo.On("FavoritedArticleIDs", mock.Anything, int64(1), []int64{1}).Return([]int64{1}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/articles/test-article", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "private, no-cache", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "Authorization", w.Header().Get("Vary"))
+	assert.Empty(t, w.Header().Get("Last-Modified"))
+
+	// The viewer's favorited is part of the body, so anonymous and personalized ETags differ
+	anonymous, _ := http.NewRequest("GET", "/api/articles/test-article", nil)
+	anonymousRouter, anonymousHandler, anonymousMocks := setupArticleHandlerTest(t)
+	anonymousRouter.GET("/api/articles/:slug", anonymousHandler.GetArticle)
+	anonymousMocks.articleRepo.On("FindArticleBySlug", mock.Anything, "test-article").Return(article, nil)
+	anonymousW := httptest.NewRecorder()
+	anonymousRouter.ServeHTTP(anonymousW, anonymous)
+	assert.Equal(t, http.StatusOK, anonymousW.Code)
+	assert.NotEqual(t, w.Header().Get("ETag"), anonymousW.Header().Get("ETag"))
+}
+
+func TestArticleHandler_GetThenUpdateWithVersion(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", int64(1))
+		c.Next()
+	})
+	router.GET("/api/articles/:slug", articleHandler.GetArticle)
+	router.PUT("/api/articles/:slug", articleHandler.UpdateArticle)
+
+	slug := "test-article"
+	article := &models.Article{
+		ID:       1,
+		Slug:     slug,
+		Title:    "Test Article",
+		AuthorID: 1,
+		Status:   models.ArticleStatusPublished,
+		Version:  2,
+		Author:   &models.User{Username: "author1"},
+	}
+	m.articleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(article, nil)
+	m.favoriteRepo.On("FavoritedArticleIDs", mock.Anything, int64(1), []int64{1}).Return([]int64{}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/articles/"+slug, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var got dtos.ArticleDetailResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, int64(2), got.Article.Version)
+
+	m.sqlMock.ExpectBegin()
+	m.articleRepo.On("UpdateArticle", mock.Anything, mock.AnythingOfType("*models.Article")).Return(nil)
+	m.sqlMock.ExpectCommit()
+
+	reqBody := dtos.UpdateArticleRequest{}
+	reqBody.Article.Body = "New Body"
+	jsonBody, _ := json.Marshal(reqBody)
+	req, _ = http.NewRequest("PUT", "/api/articles/"+slug, bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", fmt.Sprintf(`"%d"`, got.Article.Version))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	m.articleRepo.AssertCalled(t, "UpdateArticle", mock.Anything, mock.AnythingOfType("*models.Article"))
+}
+
+func TestArticleHandler_ListArticles_Conditional(t *testing.T) {
+	router, articleHandler, m := setupArticleHandlerTest(t)
+	router.GET("/api/articles", articleHandler.ListArticles)
+
+	updatedAt := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	articles := []*models.Article{{ID: 1, Slug: "test-article", Author: &models.User{Username: "author1"}, CreatedAt: updatedAt, UpdatedAt: updatedAt}}
+	m.articleRepo.On("ListArticles", mock.Anything, repository.ArticleFilter{}, (*int64)(nil), repository.ArticlePage{Limit: 21}).
+		Return(articles, int64(1), nil)
+
+	get := func(header, value string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/api/articles", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+	assert.Empty(t, w.Header().Get("Last-Modified"))
+
+	// Deletions and favorite counts advance no timestamp, so only the ETag validates a list
+	w = get("If-Modified-Since", "Wed, 01 May 2024 12:30:00 GMT")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = get("If-None-Match", etag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+}
+
+func TestTagHandler_GetTags_Conditional(t *testing.T) {
+	router, tagHandler, m := setupTagHandlerTest(t)
+	router.GET("/api/tags", tagHandler.GetTags)
+	m.tagRepo.On("GetAllTags", mock.Anything).Return([]string{"go"}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/tags", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"tags": ["go"]}`, w.Body.String())
+	assert.Empty(t, w.Header().Get("Last-Modified"))
+
+	req, _ = http.NewRequest("GET", "/api/tags", nil)
+	req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+}
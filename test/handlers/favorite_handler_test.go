@@ -54,14 +54,16 @@ func TestFavoriteHandler_FavoriteArticle_Success(t *testing.T) {
 	article := &models.Article{
 		ID:             1,
 		Slug:           slug,
+		Status:         models.ArticleStatusPublished,
 		FavoritesCount: 0,
 	}
 
 	m.sqlMock.ExpectBegin()
 	m.articleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(article, nil)
 	m.favoriteRepo.On("IsFavorited", mock.Anything, int64(1), int64(1)).Return(false, nil)
+	m.sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
 	m.favoriteRepo.On("AddFavorite", mock.Anything, int64(1), int64(1)).Return(nil)
-	m.articleRepo.On("UpdateArticle", mock.Anything, mock.AnythingOfType("*models.Article")).Return(nil)
+	m.favoriteRepo.On("AdjustFavoritesCount", mock.Anything, int64(1), 1).Return(nil)
 	m.sqlMock.ExpectCommit()
 
 	updatedArticle := &models.Article{
@@ -74,9 +76,8 @@ func TestFavoriteHandler_FavoriteArticle_Success(t *testing.T) {
 		Author:         &models.User{Username: "author1"},
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
-		Favorites:      []*models.Favorite{{UserID: 1, ArticleID: 1}},
 	}
-	m.favoriteRepo.On("GetArticleWithFavorites", mock.Anything, int64(1)).Return(updatedArticle, nil)
+	m.favoriteRepo.On("GetArticle", mock.Anything, int64(1)).Return(updatedArticle, nil)
 
 	req, _ := http.NewRequest("POST", "/api/articles/"+slug+"/favorite", nil)
 	w := httptest.NewRecorder()
@@ -117,6 +118,7 @@ func TestFavoriteHandler_FavoriteArticle_NotFound(t *testing.T) {
 	slug := "non-existent"
 	m.sqlMock.ExpectBegin()
 	m.articleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(nil, gorm.ErrRecordNotFound)
+	m.articleRepo.On("FindCurrentSlug", mock.Anything, slug).Return("", gorm.ErrRecordNotFound)
 	m.sqlMock.ExpectRollback()
 
 	req, _ := http.NewRequest("POST", "/api/articles/"+slug+"/favorite", nil)
@@ -126,6 +128,30 @@ func TestFavoriteHandler_FavoriteArticle_NotFound(t *testing.T) {
 	AssertAPIError(t, w, http.StatusNotFound, "article not found")
 }
 
+func TestFavoriteHandler_FavoriteArticle_MovedSlug(t *testing.T) {
+	router, favoriteHandler, m := setupFavoriteHandlerTest(t)
+
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", int64(1))
+		c.Next()
+	})
+	router.POST("/api/articles/:slug/favorite", favoriteHandler.FavoriteArticle)
+
+	m.sqlMock.ExpectBegin()
+	m.articleRepo.On("FindArticleBySlug", mock.Anything, "old-title").Return(nil, gorm.ErrRecordNotFound)
+	m.articleRepo.On("FindCurrentSlug", mock.Anything, "old-title").Return("new-title", nil)
+	m.sqlMock.ExpectRollback()
+
+	req, _ := http.NewRequest("POST", "/api/articles/old-title/favorite", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// 308 so that the client repeats the POST against the new URL
+	assert.Equal(t, http.StatusPermanentRedirect, w.Code)
+	assert.Equal(t, "/api/articles/new-title/favorite", w.Header().Get("Location"))
+	m.favoriteRepo.AssertNotCalled(t, "AddFavorite", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestFavoriteHandler_UnfavoriteArticle_Success(t *testing.T) {
 	router, favoriteHandler, m := setupFavoriteHandlerTest(t)
 
@@ -139,14 +165,14 @@ func TestFavoriteHandler_UnfavoriteArticle_Success(t *testing.T) {
 	article := &models.Article{
 		ID:             1,
 		Slug:           slug,
+		Status:         models.ArticleStatusPublished,
 		FavoritesCount: 1,
 	}
 
 	m.sqlMock.ExpectBegin()
 	m.articleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(article, nil)
-	m.favoriteRepo.On("IsFavorited", mock.Anything, int64(1), int64(1)).Return(true, nil)
-	m.favoriteRepo.On("RemoveFavorite", mock.Anything, int64(1), int64(1)).Return(nil)
-	m.articleRepo.On("UpdateArticle", mock.Anything, mock.AnythingOfType("*models.Article")).Return(nil)
+	m.favoriteRepo.On("RemoveFavorite", mock.Anything, int64(1), int64(1)).Return(true, nil)
+	m.favoriteRepo.On("AdjustFavoritesCount", mock.Anything, int64(1), -1).Return(nil)
 	m.sqlMock.ExpectCommit()
 
 	updatedArticle := &models.Article{
@@ -159,9 +185,8 @@ func TestFavoriteHandler_UnfavoriteArticle_Success(t *testing.T) {
 		Author:         &models.User{Username: "author1"},
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
-		Favorites:      []*models.Favorite{},
 	}
-	m.favoriteRepo.On("GetArticleWithFavorites", mock.Anything, int64(1)).Return(updatedArticle, nil)
+	m.favoriteRepo.On("GetArticle", mock.Anything, int64(1)).Return(updatedArticle, nil)
 
 	req, _ := http.NewRequest("DELETE", "/api/articles/"+slug+"/favorite", nil)
 	w := httptest.NewRecorder()
@@ -191,6 +216,7 @@ func TestFavoriteHandler_UnfavoriteArticle_NotFound(t *testing.T) {
 	slug := "non-existent"
 	m.sqlMock.ExpectBegin()
 	m.articleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(nil, gorm.ErrRecordNotFound)
+	m.articleRepo.On("FindCurrentSlug", mock.Anything, slug).Return("", gorm.ErrRecordNotFound)
 	m.sqlMock.ExpectRollback()
 
 	req, _ := http.NewRequest("DELETE", "/api/articles/"+slug+"/favorite", nil)
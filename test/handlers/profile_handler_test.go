@@ -21,22 +21,24 @@ import (
 )
 
 type profileHandlerMocks struct {
-	userRepo    *mocks.MockUserRepository
-	profileRepo *mocks.MockProfileRepository
-	followRepo  *mocks.MockFollowRepository
-	sqlMock     sqlmock.Sqlmock
+	userRepo     *mocks.MockUserRepository
+	profileRepo  *mocks.MockProfileRepository
+	followRepo   *mocks.MockFollowRepository
+	timelineRepo *mocks.MockTimelineRepository
+	sqlMock      sqlmock.Sqlmock
 }
 
 func setupProfileHandlerTest(t *testing.T) (*gin.Engine, *handlers.ProfileHandler, profileHandlerMocks) {
 	m := profileHandlerMocks{
-		userRepo:    new(mocks.MockUserRepository),
-		profileRepo: new(mocks.MockProfileRepository),
-		followRepo:  new(mocks.MockFollowRepository),
+		userRepo:     new(mocks.MockUserRepository),
+		profileRepo:  new(mocks.MockProfileRepository),
+		followRepo:   new(mocks.MockFollowRepository),
+		timelineRepo: new(mocks.MockTimelineRepository),
 	}
 
 	mockDB, sqlMock := CreateMockDB(t)
 	m.sqlMock = sqlMock
-	profileService := services.NewProfileService(mockDB, m.userRepo, m.profileRepo, m.followRepo)
+	profileService := services.NewProfileService(mockDB, m.userRepo, m.profileRepo, m.followRepo, m.timelineRepo)
 	profileHandler := handlers.NewProfileHandler(profileService)
 
 	router := SetupRouter()
@@ -143,6 +145,7 @@ func TestProfileHandler_FollowUser_Success(t *testing.T) {
 	m.userRepo.On("FindUserByUsername", mock.Anything, username, []bool(nil)).Return(followee, nil)
 	m.followRepo.On("IsFollowing", mock.Anything, int64(1), int64(2)).Return(false, nil).Once()
 	m.followRepo.On("CreateFollow", mock.Anything, mock.AnythingOfType("*models.Follow")).Return(nil)
+	m.timelineRepo.On("BackfillTimeline", mock.Anything, int64(1), int64(2)).Return(nil)
 	m.sqlMock.ExpectCommit()
 
 	// After follow, it calls GetProfileByUsername
@@ -167,6 +170,7 @@ func TestProfileHandler_FollowUser_Success(t *testing.T) {
 
 	m.userRepo.AssertExpectations(t)
 	m.followRepo.AssertExpectations(t)
+	m.timelineRepo.AssertExpectations(t)
 }
 
 func TestProfileHandler_UnfollowUser_Success(t *testing.T) {
@@ -184,6 +188,7 @@ func TestProfileHandler_UnfollowUser_Success(t *testing.T) {
 	m.sqlMock.ExpectBegin()
 	m.userRepo.On("FindUserByUsername", mock.Anything, username, []bool(nil)).Return(followee, nil)
 	m.followRepo.On("DeleteFollow", mock.Anything, int64(1), int64(2)).Return(nil)
+	m.timelineRepo.On("PruneTimeline", mock.Anything, int64(1), int64(2)).Return(nil)
 	m.sqlMock.ExpectCommit()
 
 	// After unfollow, it calls GetProfileByUsername
@@ -208,4 +213,5 @@ func TestProfileHandler_UnfollowUser_Success(t *testing.T) {
 
 	m.userRepo.AssertExpectations(t)
 	m.followRepo.AssertExpectations(t)
+	m.timelineRepo.AssertExpectations(t)
 }
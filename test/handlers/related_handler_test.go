@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-gin-realworld-api/internal/dtos"
+	"go-gin-realworld-api/internal/handlers"
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+	"go-gin-realworld-api/internal/services"
+	"go-gin-realworld-api/test/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func setupRelatedHandlerTest(t *testing.T) (*gin.Engine, *mocks.MockRelatedRepository, articleHandlerMocks) {
+	relatedRepo := new(mocks.MockRelatedRepository)
+	m := articleHandlerMocks{
+		articleRepo:  new(mocks.MockArticleRepository),
+		favoriteRepo: new(mocks.MockFavoriteRepository),
+		followRepo:   new(mocks.MockFollowRepository),
+		timelineRepo: new(mocks.MockTimelineRepository),
+	}
+
+	mockDB, sqlMock := CreateMockDB(t)
+	m.sqlMock = sqlMock
+	articleService := services.NewArticleService(mockDB, mockDB, m.articleRepo, m.favoriteRepo, m.followRepo, m.timelineRepo, nil)
+	relatedService := services.NewRelatedService(mockDB, relatedRepo, m.articleRepo, articleService)
+	relatedHandler := handlers.NewRelatedHandler(relatedService)
+
+	router := SetupRouter()
+	router.GET("/api/articles/:slug/related", relatedHandler.RelatedArticles)
+	return router, relatedRepo, m
+}
+
+func TestRelatedHandler_RelatedArticles(t *testing.T) {
+	router, relatedRepo, m := setupRelatedHandlerTest(t)
+
+	now := time.Now()
+	author := &models.User{Username: "author1"}
+	m.articleRepo.On("FindArticleBySlug", mock.Anything, "source").Return(&models.Article{ID: 1, Slug: "source", Status: models.ArticleStatusPublished, Author: author}, nil)
+	// Two shared tags score 4 (weight 2), three co-favorites score 3 (weight 1)
+	relatedRepo.On("ListTagOverlaps", mock.Anything, int64(1), (*int64)(nil), mock.Anything).Return([]repository.RelatedArticle{{ArticleID: 2, Shared: 2}}, nil)
+	relatedRepo.On("ListCoFavorites", mock.Anything, int64(1), (*int64)(nil), mock.Anything).Return([]repository.RelatedArticle{{ArticleID: 3, Shared: 3}}, nil)
+	m.articleRepo.On("FindArticlesByIDs", mock.Anything, []int64{2, 3}).Return([]*models.Article{
+		{ID: 3, Slug: "co-favorited", Author: author, CreatedAt: now, UpdatedAt: now},
+		{ID: 2, Slug: "same-tags", Author: author, CreatedAt: now, UpdatedAt: now},
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/articles/source/related", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dtos.ArticlesListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Articles, 2)
+	assert.Equal(t, "same-tags", resp.Articles[0].Slug)
+	assert.Equal(t, "co-favorited", resp.Articles[1].Slug)
+	assert.Nil(t, resp.ArticlesCount)
+	relatedRepo.AssertExpectations(t)
+}
+
+func TestRelatedHandler_RelatedArticles_NotFound(t *testing.T) {
+	router, relatedRepo, m := setupRelatedHandlerTest(t)
+
+	m.articleRepo.On("FindArticleBySlug", mock.Anything, "missing").Return(nil, gorm.ErrRecordNotFound)
+	m.articleRepo.On("FindCurrentSlug", mock.Anything, "missing").Return("", gorm.ErrRecordNotFound)
+
+	req, _ := http.NewRequest("GET", "/api/articles/missing/related", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	AssertAPIError(t, w, http.StatusNotFound, "article not found", nil)
+	relatedRepo.AssertNotCalled(t, "ListTagOverlaps")
+}
+
+func TestRelatedHandler_RelatedArticles_InvalidLimit(t *testing.T) {
+	router, relatedRepo, _ := setupRelatedHandlerTest(t)
+
+	req, _ := http.NewRequest("GET", "/api/articles/source/related?limit=50", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	relatedRepo.AssertNotCalled(t, "ListTagOverlaps")
+}
@@ -6,7 +6,9 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"go-gin-realworld-api/internal/dtos"
 	"go-gin-realworld-api/internal/handlers"
+	"go-gin-realworld-api/internal/repository"
 	"go-gin-realworld-api/internal/services"
 	"go-gin-realworld-api/test/mocks"
 
@@ -28,7 +30,7 @@ func setupTagHandlerTest(t *testing.T) (*gin.Engine, *handlers.TagHandler, tagHa
 
 	mockDB, sqlMock := CreateMockDB(t)
 	m.sqlMock = sqlMock
-	tagService := services.NewTagService(mockDB, m.tagRepo)
+	tagService := services.NewTagService(mockDB, mockDB, m.tagRepo)
 	tagHandler := handlers.NewTagHandler(tagService)
 
 	router := SetupRouter()
@@ -70,3 +72,70 @@ func TestTagHandler_GetTags_Error(t *testing.T) {
 
 	m.tagRepo.AssertExpectations(t)
 }
+
+func TestTagHandler_GetTags_WithCounts(t *testing.T) {
+	router, tagHandler, m := setupTagHandlerTest(t)
+	router.GET("/api/tags", tagHandler.GetTags)
+
+	m.tagRepo.On("ListTagCounts", mock.Anything, repository.TagSortPopular, 2).Return([]repository.TagCount{
+		{Name: "go", Articles: 5},
+		{Name: "web", Articles: 2},
+	}, nil).Twice()
+
+	req, _ := http.NewRequest("GET", "/api/tags?withCounts=true&sort=popular&limit=2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dtos.TagCountsResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []dtos.TagCountResponse{{Name: "go", ArticlesCount: 5}, {Name: "web", ArticlesCount: 2}}, resp.Tags)
+
+	// Without withCounts only the names are returned, in the same order
+	req, _ = http.NewRequest("GET", "/api/tags?sort=popular&limit=2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var names map[string][]string
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &names))
+	assert.Equal(t, []string{"go", "web"}, names["tags"])
+
+	m.tagRepo.AssertExpectations(t)
+	m.tagRepo.AssertNotCalled(t, "GetAllTags", mock.Anything)
+}
+
+func TestTagHandler_GetTags_InvalidSort(t *testing.T) {
+	router, tagHandler, _ := setupTagHandlerTest(t)
+	router.GET("/api/tags", tagHandler.GetTags)
+
+	req, _ := http.NewRequest("GET", "/api/tags?sort=newest", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	AssertAPIError(t, w, http.StatusBadRequest, "Validation failed", map[string]string{"Sort": "is invalid"})
+}
+
+func TestTagHandler_SuggestTags(t *testing.T) {
+	router, tagHandler, m := setupTagHandlerTest(t)
+	router.GET("/api/tags/suggest", tagHandler.SuggestTags)
+
+	// The prefix is normalized like a tag name and the limit defaults to 10
+	m.tagRepo.On("SuggestTags", mock.Anything, "web d", 10).Return([]string{"web dev", "web design"}, nil).Once()
+
+	req, _ := http.NewRequest("GET", "/api/tags/suggest?prefix=%20Web%20%20D", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string][]string
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []string{"web dev", "web design"}, resp["tags"])
+
+	req, _ = http.NewRequest("GET", "/api/tags/suggest", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	AssertAPIError(t, w, http.StatusBadRequest, "Validation failed", map[string]string{"Prefix": "is required"})
+	m.tagRepo.AssertExpectations(t)
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-gin-realworld-api/internal/dtos"
+	"go-gin-realworld-api/internal/handlers"
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/services"
+	"go-gin-realworld-api/test/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTrendingHandlerTest(t *testing.T) (*gin.Engine, *handlers.TrendingHandler, *mocks.MockTrendingRepository, articleHandlerMocks) {
+	trendingRepo := new(mocks.MockTrendingRepository)
+	m := articleHandlerMocks{
+		articleRepo:  new(mocks.MockArticleRepository),
+		favoriteRepo: new(mocks.MockFavoriteRepository),
+		followRepo:   new(mocks.MockFollowRepository),
+		timelineRepo: new(mocks.MockTimelineRepository),
+	}
+
+	mockDB, sqlMock := CreateMockDB(t)
+	m.sqlMock = sqlMock
+	articleService := services.NewArticleService(mockDB, mockDB, m.articleRepo, m.favoriteRepo, m.followRepo, m.timelineRepo, nil)
+	trendingService := services.NewTrendingService(mockDB, mockDB, trendingRepo, articleService)
+	trendingHandler := handlers.NewTrendingHandler(trendingService)
+
+	router := SetupRouter()
+	return router, trendingHandler, trendingRepo, m
+}
+
+func TestTrendingHandler_TrendingArticles_Ranked(t *testing.T) {
+	router, trendingHandler, trendingRepo, m := setupTrendingHandlerTest(t)
+	router.GET("/api/articles/trending", trendingHandler.TrendingArticles)
+
+	now := time.Now()
+	trendingRepo.On("ListTrending", mock.Anything, "7d", 20, 0).Return([]int64{2, 1}, int64(2), nil)
+	// Articles are loaded in no particular order and returned in ranking order
+	m.articleRepo.On("FindArticlesByIDs", mock.Anything, []int64{2, 1}).Return([]*models.Article{
+		{ID: 1, Slug: "second", Author: &models.User{Username: "author1"}, CreatedAt: now, UpdatedAt: now},
+		{ID: 2, Slug: "first", Author: &models.User{Username: "author1"}, CreatedAt: now, UpdatedAt: now},
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/articles/trending?window=7d", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dtos.ArticlesListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []string{"first", "second"}, []string{resp.Articles[0].Slug, resp.Articles[1].Slug})
+	assert.Equal(t, 2, *resp.ArticlesCount)
+	trendingRepo.AssertExpectations(t)
+	m.articleRepo.AssertNotCalled(t, "ListArticles")
+}
+
+func TestTrendingHandler_TrendingArticles_InvalidWindow(t *testing.T) {
+	router, trendingHandler, trendingRepo, _ := setupTrendingHandlerTest(t)
+	router.GET("/api/articles/trending", trendingHandler.TrendingArticles)
+
+	req, _ := http.NewRequest("GET", "/api/articles/trending?window=1y", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	AssertAPIError(t, w, http.StatusBadRequest, "Validation failed", map[string]string{"Window": "is invalid"})
+	trendingRepo.AssertNotCalled(t, "ListTrending")
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -275,3 +276,84 @@ func TestUserHandler_UpdateUser_Validation(t *testing.T) {
 
 	AssertAPIError(t, w, http.StatusBadRequest, "Invalid request body format")
 }
+
+func TestUserHandler_PatchUser_ClearsBio(t *testing.T) {
+	router, userHandler, m := setupUserHandlerTest(t)
+
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", int64(1))
+		c.Next()
+	})
+	router.PATCH("/api/user", userHandler.PatchUser)
+
+	existingUser := &models.User{ID: 1, Username: "olduser", Email: "old@example.com"}
+	existingProfile := &models.Profile{
+		UserID: 1,
+		Bio:    sql.NullString{String: "old bio", Valid: true},
+		Image:  sql.NullString{String: "https://example.com/a.jpg", Valid: true},
+	}
+
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectCommit()
+
+	m.userRepo.On("FindUserByID", mock.Anything, int64(1)).Return(existingUser, nil)
+	m.userRepo.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *models.User) bool {
+		return u.Username == "olduser" && u.Email == "old@example.com"
+	})).Return(nil)
+	m.profileRepo.On("FindProfileByUserID", mock.Anything, int64(1)).Return(existingProfile, nil)
+	m.profileRepo.On("UpdateProfile", mock.Anything, mock.MatchedBy(func(p *models.Profile) bool {
+		return !p.Bio.Valid && p.Image.String == "https://example.com/a.jpg"
+	})).Return(nil)
+
+	req, _ := http.NewRequest("PATCH", "/api/user", bytes.NewBufferString(`{"user": {"bio": null}}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dtos.UpdateUserResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "olduser", resp.User.Username)
+	assert.Empty(t, resp.User.Bio)
+	assert.Equal(t, "https://example.com/a.jpg", resp.User.Image)
+
+	m.userRepo.AssertExpectations(t)
+	m.profileRepo.AssertExpectations(t)
+}
+
+func TestUserHandler_PatchUser_Validation(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		payload     string
+		status      int
+		message     string
+		details     map[string]string
+	}{
+		{"null email", "application/merge-patch+json", `{"user": {"email": null}}`, http.StatusBadRequest, "Validation failed", map[string]string{"email": "cannot be null"}},
+		{"empty username", "application/json", `{"user": {"username": ""}}`, http.StatusBadRequest, "Validation failed", map[string]string{"username": "cannot be empty"}},
+		{"missing user", "application/merge-patch+json", `{"user": null}`, http.StatusBadRequest, "Validation failed", map[string]string{"User": "is required"}},
+		{"form body", "application/x-www-form-urlencoded", `bio=x`, http.StatusUnsupportedMediaType, "request body must be application/merge-patch+json or application/json", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, userHandler, m := setupUserHandlerTest(t)
+
+			router.Use(func(c *gin.Context) {
+				c.Set("user_id", int64(1))
+				c.Next()
+			})
+			router.PATCH("/api/user", userHandler.PatchUser)
+
+			req, _ := http.NewRequest("PATCH", "/api/user", bytes.NewBufferString(tt.payload))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			AssertAPIError(t, w, tt.status, tt.message, tt.details)
+			m.userRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+		})
+	}
+}
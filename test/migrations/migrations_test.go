@@ -0,0 +1,190 @@
+package migrations
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-gin-realworld-api/internal/migrations"
+	"go-gin-realworld-api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func createSQLiteDB(t *testing.T) *gorm.DB {
+	dsn := "file:" + filepath.Join(t.TempDir(), "test.db") + "?_foreign_keys=on"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestNew_LoadsEveryDriver(t *testing.T) {
+	for _, driver := range []string{"mysql", "postgres", "sqlite"} {
+		t.Run(driver, func(t *testing.T) {
+			_, err := migrations.New(nil, driver)
+			assert.NoError(t, err)
+		})
+	}
+
+	_, err := migrations.New(nil, "oracle")
+	assert.ErrorIs(t, err, migrations.ErrUnknownDriver)
+}
+
+func TestMigrator_Up_CreatesSchemaForModels(t *testing.T) {
+	ctx := context.Background()
+	db := createSQLiteDB(t)
+	migrator, err := migrations.New(db, "sqlite")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, migrator.CheckUpToDate(ctx), migrations.ErrSchemaBehind)
+
+	applied, err := migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	assert.NotEmpty(t, applied)
+	assert.NoError(t, migrator.CheckUpToDate(ctx))
+
+	// Running again is a no-op
+	applied, err = migrator.Up(ctx, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, applied)
+
+	// Every column GORM expects must exist
+	for _, model := range []interface{}{
+		&models.User{}, &models.Profile{}, &models.Follow{}, &models.Article{},
+		&models.Comment{}, &models.Favorite{}, &models.Tag{}, &models.ArticleTag{},
+		&models.TimelineEntry{},
+	} {
+		stmt := &gorm.Statement{DB: db}
+		require.NoError(t, stmt.Parse(model))
+		assert.True(t, db.Migrator().HasTable(model), "missing table %s", stmt.Schema.Table)
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			assert.True(t, db.Migrator().HasColumn(model, field.DBName), "missing column %s.%s", stmt.Schema.Table, field.DBName)
+		}
+	}
+
+	// Unique indexes are in place
+	user := &models.User{Username: "john", Email: "john@example.com", Password: "x"}
+	require.NoError(t, db.Create(user).Error)
+	assert.ErrorIs(t, db.Create(&models.User{Username: "john", Email: "other@example.com", Password: "x"}).Error, gorm.ErrDuplicatedKey)
+}
+
+func TestMigrator_Down_RollsBack(t *testing.T) {
+	ctx := context.Background()
+	db := createSQLiteDB(t)
+	migrator, err := migrations.New(db, "sqlite")
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+
+	reverted, err := migrator.Down(ctx, 0)
+	require.NoError(t, err)
+	assert.Len(t, reverted, 1)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, statuses[len(statuses)-1].AppliedAt)
+	assert.ErrorIs(t, migrator.CheckUpToDate(ctx), migrations.ErrSchemaBehind)
+}
+
+func TestMigrator_Up_WaitsForLock(t *testing.T) {
+	ctx := context.Background()
+	db := createSQLiteDB(t)
+	migrator, err := migrations.New(db, "sqlite")
+	require.NoError(t, err)
+	migrator.LockTimeout = 100 * time.Millisecond
+
+	// Status creates the bookkeeping tables; then simulate another instance holding the lock
+	_, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("INSERT INTO schema_migrations_lock (id, locked_at, locked_by) VALUES (1, ?, 'other-host:1')", time.Now()).Error)
+
+	_, err = migrator.Up(ctx, 0)
+	assert.ErrorIs(t, err, migrations.ErrLockTimeout)
+	assert.ErrorContains(t, err, "held by other-host:1")
+	assert.ErrorContains(t, err, "migrate unlock")
+	assert.ErrorIs(t, migrator.CheckUpToDate(ctx), migrations.ErrSchemaBehind)
+
+	// Once released, migrating succeeds and the lock is released again
+	require.NoError(t, db.Exec("DELETE FROM schema_migrations_lock").Error)
+	_, err = migrator.Up(ctx, 0)
+	assert.NoError(t, err)
+
+	var locks int64
+	db.Table("schema_migrations_lock").Count(&locks)
+	assert.Equal(t, int64(0), locks)
+}
+
+func TestMigrator_Up_BreaksExpiredLock(t *testing.T) {
+	ctx := context.Background()
+	db := createSQLiteDB(t)
+	migrator, err := migrations.New(db, "sqlite")
+	require.NoError(t, err)
+	migrator.LockTimeout = 100 * time.Millisecond
+	migrator.LockExpiry = time.Minute
+
+	// A process that died an hour ago left its lock behind
+	_, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("INSERT INTO schema_migrations_lock (id, locked_at, locked_by) VALUES (1, ?, 'dead-host:1')", time.Now().Add(-time.Hour)).Error)
+
+	_, err = migrator.Up(ctx, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, migrator.CheckUpToDate(ctx))
+
+	var locks int64
+	db.Table("schema_migrations_lock").Count(&locks)
+	assert.Equal(t, int64(0), locks)
+}
+
+func TestMigrator_Unlock(t *testing.T) {
+	ctx := context.Background()
+	db := createSQLiteDB(t)
+	migrator, err := migrations.New(db, "sqlite")
+	require.NoError(t, err)
+	migrator.LockTimeout = 100 * time.Millisecond
+
+	released, err := migrator.Unlock(ctx)
+	require.NoError(t, err)
+	assert.False(t, released)
+
+	require.NoError(t, db.Exec("INSERT INTO schema_migrations_lock (id, locked_at, locked_by) VALUES (1, ?, 'other-host:1')", time.Now()).Error)
+	released, err = migrator.Unlock(ctx)
+	require.NoError(t, err)
+	assert.True(t, released)
+
+	_, err = migrator.Up(ctx, 0)
+	assert.NoError(t, err)
+}
+
+func TestCreate_WritesNextVersionForEveryDriver(t *testing.T) {
+	dir := t.TempDir()
+	for _, driver := range []string{"mysql", "sqlite"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, driver), 0o755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "mysql", "0003_existing.up.sql"), []byte("SELECT 1;"), 0o644))
+
+	created, err := migrations.Create(dir, "Add Widgets!")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dir, "mysql", "0004_add_widgets.up.sql"),
+		filepath.Join(dir, "mysql", "0004_add_widgets.down.sql"),
+		filepath.Join(dir, "sqlite", "0004_add_widgets.up.sql"),
+		filepath.Join(dir, "sqlite", "0004_add_widgets.down.sql"),
+	}, created)
+
+	_, err = migrations.Create(dir, "   ")
+	assert.ErrorIs(t, err, migrations.ErrInvalidMigration)
+}
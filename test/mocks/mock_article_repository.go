@@ -1,7 +1,10 @@
 package mocks
 
 import (
+	"time"
+
 	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
 
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
@@ -13,17 +16,17 @@ type MockArticleRepository struct {
 }
 
 // ListArticles mock method
-func (m *MockArticleRepository) ListArticles(db *gorm.DB, tag, author string, favorited *bool, currentUserID *int64, limit, offset int) ([]*models.Article, int64, error) {
-	args := m.Called(db, tag, author, favorited, currentUserID, limit, offset)
+func (m *MockArticleRepository) ListArticles(db *gorm.DB, filter repository.ArticleFilter, currentUserID *int64, page repository.ArticlePage) ([]*models.Article, int64, error) {
+	args := m.Called(db, filter, currentUserID, page)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(int64), args.Error(2)
 	}
 	return args.Get(0).([]*models.Article), args.Get(1).(int64), args.Error(2)
 }
 
-// FeedArticles mock method
-func (m *MockArticleRepository) FeedArticles(db *gorm.DB, userID int64, limit, offset int) ([]*models.Article, int64, error) {
-	args := m.Called(db, userID, limit, offset)
+// SearchArticles mock method
+func (m *MockArticleRepository) SearchArticles(db *gorm.DB, search repository.ArticleSearch) ([]*models.Article, int64, error) {
+	args := m.Called(db, search)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(int64), args.Error(2)
 	}
@@ -39,6 +42,21 @@ func (m *MockArticleRepository) FindArticleBySlug(db *gorm.DB, slug string) (*mo
 	return args.Get(0).(*models.Article), args.Error(1)
 }
 
+// FindArticlesByIDs mock method
+func (m *MockArticleRepository) FindArticlesByIDs(db *gorm.DB, ids []int64) ([]*models.Article, error) {
+	args := m.Called(db, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Article), args.Error(1)
+}
+
+// FindCurrentSlug mock method
+func (m *MockArticleRepository) FindCurrentSlug(db *gorm.DB, slug string) (string, error) {
+	args := m.Called(db, slug)
+	return args.String(0), args.Error(1)
+}
+
 // CreateArticle mock method
 func (m *MockArticleRepository) CreateArticle(db *gorm.DB, article *models.Article) error {
 	args := m.Called(db, article)
@@ -62,3 +80,18 @@ func (m *MockArticleRepository) AssignTagsToArticle(db *gorm.DB, articleID int64
 	args := m.Called(db, articleID, tagNames)
 	return args.Error(0)
 }
+
+// ListDueScheduled mock method
+func (m *MockArticleRepository) ListDueScheduled(db *gorm.DB, now time.Time, limit int) ([]int64, error) {
+	args := m.Called(db, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+// PublishScheduled mock method
+func (m *MockArticleRepository) PublishScheduled(db *gorm.DB, articleID int64) (bool, error) {
+	args := m.Called(db, articleID)
+	return args.Bool(0), args.Error(1)
+}
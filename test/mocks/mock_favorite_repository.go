@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
 
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
@@ -19,9 +20,9 @@ func (m *MockFavoriteRepository) AddFavorite(db *gorm.DB, userID, articleID int6
 }
 
 // RemoveFavorite mock method
-func (m *MockFavoriteRepository) RemoveFavorite(db *gorm.DB, userID, articleID int64) error {
+func (m *MockFavoriteRepository) RemoveFavorite(db *gorm.DB, userID, articleID int64) (bool, error) {
 	args := m.Called(db, userID, articleID)
-	return args.Error(0)
+	return args.Bool(0), args.Error(1)
 }
 
 // IsFavorited mock method
@@ -30,11 +31,35 @@ func (m *MockFavoriteRepository) IsFavorited(db *gorm.DB, userID, articleID int6
 	return args.Bool(0), args.Error(1)
 }
 
-// GetArticleWithFavorites mock method
-func (m *MockFavoriteRepository) GetArticleWithFavorites(db *gorm.DB, articleID int64) (*models.Article, error) {
+// FavoritedArticleIDs mock method
+func (m *MockFavoriteRepository) FavoritedArticleIDs(db *gorm.DB, userID int64, articleIDs []int64) ([]int64, error) {
+	args := m.Called(db, userID, articleIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+// GetArticle mock method
+func (m *MockFavoriteRepository) GetArticle(db *gorm.DB, articleID int64) (*models.Article, error) {
 	args := m.Called(db, articleID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.Article), args.Error(1)
 }
+
+// AdjustFavoritesCount mock method
+func (m *MockFavoriteRepository) AdjustFavoritesCount(db *gorm.DB, articleID int64, delta int) error {
+	args := m.Called(db, articleID, delta)
+	return args.Error(0)
+}
+
+// ReconcileFavoritesCounts mock method
+func (m *MockFavoriteRepository) ReconcileFavoritesCounts(db *gorm.DB) ([]repository.FavoritesCountDrift, error) {
+	args := m.Called(db)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.FavoritesCountDrift), args.Error(1)
+}
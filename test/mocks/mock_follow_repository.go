@@ -29,3 +29,9 @@ func (m *MockFollowRepository) IsFollowing(db *gorm.DB, followerID, followeeID i
 	args := m.Called(db, followerID, followeeID)
 	return args.Bool(0), args.Error(1)
 }
+
+// CountFollowers mock method
+func (m *MockFollowRepository) CountFollowers(db *gorm.DB, userID int64) (int64, error) {
+	args := m.Called(db, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"go-gin-realworld-api/internal/repository"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockRelatedRepository is a mock implementation of RelatedRepository
+type MockRelatedRepository struct {
+	mock.Mock
+}
+
+// ListTagOverlaps mock method
+func (m *MockRelatedRepository) ListTagOverlaps(db *gorm.DB, articleID int64, excludeAuthorID *int64, limit int) ([]repository.RelatedArticle, error) {
+	args := m.Called(db, articleID, excludeAuthorID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.RelatedArticle), args.Error(1)
+}
+
+// ListCoFavorites mock method
+func (m *MockRelatedRepository) ListCoFavorites(db *gorm.DB, articleID int64, excludeAuthorID *int64, limit int) ([]repository.RelatedArticle, error) {
+	args := m.Called(db, articleID, excludeAuthorID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.RelatedArticle), args.Error(1)
+}
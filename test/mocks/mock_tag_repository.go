@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"go-gin-realworld-api/internal/repository"
+
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
@@ -18,3 +20,42 @@ func (m *MockTagRepository) GetAllTags(db *gorm.DB) ([]string, error) {
 	}
 	return args.Get(0).([]string), args.Error(1)
 }
+
+// ListTagCounts mock method
+func (m *MockTagRepository) ListTagCounts(db *gorm.DB, sort repository.TagSort, limit int) ([]repository.TagCount, error) {
+	args := m.Called(db, sort, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.TagCount), args.Error(1)
+}
+
+// RenameTag mock method
+func (m *MockTagRepository) RenameTag(db *gorm.DB, from, to string) error {
+	args := m.Called(db, from, to)
+	return args.Error(0)
+}
+
+// MergeTags mock method
+func (m *MockTagRepository) MergeTags(db *gorm.DB, from, into string) (int64, error) {
+	args := m.Called(db, from, into)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// SuggestTags mock method
+func (m *MockTagRepository) SuggestTags(db *gorm.DB, prefix string, limit int) ([]string, error) {
+	args := m.Called(db, prefix, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// DeleteUnusedTags mock method
+func (m *MockTagRepository) DeleteUnusedTags(db *gorm.DB) ([]string, error) {
+	args := m.Called(db)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
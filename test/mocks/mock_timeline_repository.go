@@ -0,0 +1,65 @@
+package mocks
+
+import (
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockTimelineRepository is a mock implementation of TimelineRepository
+type MockTimelineRepository struct {
+	mock.Mock
+}
+
+// FanOutArticle mock method
+func (m *MockTimelineRepository) FanOutArticle(db *gorm.DB, article *models.Article) error {
+	args := m.Called(db, article)
+	return args.Error(0)
+}
+
+// BackfillTimeline mock method
+func (m *MockTimelineRepository) BackfillTimeline(db *gorm.DB, userID, authorID int64) error {
+	args := m.Called(db, userID, authorID)
+	return args.Error(0)
+}
+
+// PruneTimeline mock method
+func (m *MockTimelineRepository) PruneTimeline(db *gorm.DB, userID, authorID int64) error {
+	args := m.Called(db, userID, authorID)
+	return args.Error(0)
+}
+
+// RemoveArticle mock method
+func (m *MockTimelineRepository) RemoveArticle(db *gorm.DB, articleID int64) error {
+	args := m.Called(db, articleID)
+	return args.Error(0)
+}
+
+// ListTimeline mock method
+func (m *MockTimelineRepository) ListTimeline(db *gorm.DB, userID int64, filter repository.FeedFilter, page repository.ArticlePage) ([]*models.TimelineEntry, int64, error) {
+	args := m.Called(db, userID, filter, page)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*models.TimelineEntry), args.Get(1).(int64), args.Error(2)
+}
+
+// ListFanOutOnRead mock method
+func (m *MockTimelineRepository) ListFanOutOnRead(db *gorm.DB, userID int64, filter repository.FeedFilter, page repository.ArticlePage) ([]*models.TimelineEntry, int64, error) {
+	args := m.Called(db, userID, filter, page)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*models.TimelineEntry), args.Get(1).(int64), args.Error(2)
+}
+
+// ListFeed mock method
+func (m *MockTimelineRepository) ListFeed(db *gorm.DB, userID int64, filter repository.FeedFilter, page repository.ArticlePage) ([]*models.TimelineEntry, int64, error) {
+	args := m.Called(db, userID, filter, page)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*models.TimelineEntry), args.Get(1).(int64), args.Error(2)
+}
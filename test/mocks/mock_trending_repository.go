@@ -0,0 +1,54 @@
+package mocks
+
+import (
+	"time"
+
+	"go-gin-realworld-api/internal/repository"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockTrendingRepository is a mock implementation of TrendingRepository
+type MockTrendingRepository struct {
+	mock.Mock
+}
+
+// CountFavoritesByBand mock method
+func (m *MockTrendingRepository) CountFavoritesByBand(db *gorm.DB, bounds []time.Time) ([]repository.ActivityCount, error) {
+	args := m.Called(db, bounds)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.ActivityCount), args.Error(1)
+}
+
+// CountCommentsByBand mock method
+func (m *MockTrendingRepository) CountCommentsByBand(db *gorm.DB, bounds []time.Time) ([]repository.ActivityCount, error) {
+	args := m.Called(db, bounds)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.ActivityCount), args.Error(1)
+}
+
+// ClaimTrendingRefresh mock method
+func (m *MockTrendingRepository) ClaimTrendingRefresh(db *gorm.DB, now, since time.Time) (bool, error) {
+	args := m.Called(db, now, since)
+	return args.Bool(0), args.Error(1)
+}
+
+// ReplaceTrendingScores mock method
+func (m *MockTrendingRepository) ReplaceTrendingScores(db *gorm.DB, window string, scores []repository.TrendingScore, computedAt time.Time) error {
+	args := m.Called(db, window, scores, computedAt)
+	return args.Error(0)
+}
+
+// ListTrending mock method
+func (m *MockTrendingRepository) ListTrending(db *gorm.DB, window string, limit, offset int) ([]int64, int64, error) {
+	args := m.Called(db, window, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]int64), args.Get(1).(int64), args.Error(2)
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"testing"
+
+	"go-gin-realworld-api/internal/repository/cached"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// Rows are changed with raw SQL behind the decorators' back to tell cached reads from fresh ones
+
+func TestCachedArticleRepository_FindArticleBySlug(t *testing.T) {
+	b := NewSQLiteBackend(t)
+	caches := cached.NewCaches(10, 0)
+	articleRepo := cached.NewCachedArticleRepository(b.ArticleRepo, caches)
+	favoriteRepo := cached.NewCachedFavoriteRepository(b.FavoriteRepo, caches)
+	alice := b.SeedUser(t, "alice")
+	article := b.SeedArticle(t, "cached", alice.ID, 0)
+
+	found, err := articleRepo.FindArticleBySlug(b.DB, "cached")
+	assert.NoError(t, err)
+	assert.Equal(t, "cached", found.Title)
+
+	// Served from the cache, and changing the returned copy leaves the cache alone
+	found.Title = "changed by caller"
+	assert.NoError(t, b.DB.Exec("UPDATE articles SET title = ? WHERE id = ?", "stale", article.ID).Error)
+	found, err = articleRepo.FindArticleBySlug(b.DB, "cached")
+	assert.NoError(t, err)
+	assert.Equal(t, "cached", found.Title)
+
+	// Reads inside a transaction bypass the cache
+	assert.NoError(t, b.DB.Transaction(func(tx *gorm.DB) error {
+		found, err := articleRepo.FindArticleBySlug(tx, "cached")
+		assert.NoError(t, err)
+		assert.Equal(t, "stale", found.Title)
+		return nil
+	}))
+
+	// A favorite invalidates the article
+	assert.NoError(t, favoriteRepo.AdjustFavoritesCount(b.DB, article.ID, 1))
+	found, err = articleRepo.FindArticleBySlug(b.DB, "cached")
+	assert.NoError(t, err)
+	assert.Equal(t, "stale", found.Title)
+	assert.Equal(t, 1, found.FavoritesCount)
+
+	// A new slug invalidates the article under its old one
+	found.Title, found.Slug = "Renamed", "renamed"
+	assert.NoError(t, articleRepo.UpdateArticle(b.DB, found))
+	_, err = articleRepo.FindArticleBySlug(b.DB, "cached")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	found, err = articleRepo.FindArticleBySlug(b.DB, "renamed")
+	assert.NoError(t, err)
+	assert.Equal(t, "Renamed", found.Title)
+
+	assert.NoError(t, articleRepo.DeleteArticleBySlug(b.DB, "renamed"))
+	_, err = articleRepo.FindArticleBySlug(b.DB, "renamed")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	stats := caches.Stats()["articles"]
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(5), stats.Misses)
+}
+
+func TestCachedTagRepository_GetAllTags(t *testing.T) {
+	b := NewSQLiteBackend(t)
+	caches := cached.NewCaches(10, 0)
+	articleRepo := cached.NewCachedArticleRepository(b.ArticleRepo, caches)
+	tagRepo := cached.NewCachedTagRepository(b.TagRepo, caches)
+	alice := b.SeedUser(t, "alice")
+	article := b.SeedArticle(t, "tagged", alice.ID, 0)
+	assert.NoError(t, articleRepo.AssignTagsToArticle(b.DB, article.ID, []string{"go"}))
+
+	tags, err := tagRepo.GetAllTags(b.DB)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go"}, tags)
+
+	// Writes that bypass the repositories are not seen until the entry is dropped
+	assert.NoError(t, b.DB.Exec("INSERT INTO tags (name) VALUES ('hidden')").Error)
+	assert.NoError(t, b.DB.Exec("INSERT INTO article_tags (article_id, tag_id) SELECT ?, id FROM tags WHERE name = 'hidden'", article.ID).Error)
+	tags, err = tagRepo.GetAllTags(b.DB)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go"}, tags)
+
+	// Assigning tags can create new ones
+	assert.NoError(t, articleRepo.AssignTagsToArticle(b.DB, article.ID, []string{"gin"}))
+	tags, err = tagRepo.GetAllTags(b.DB)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gin"}, tags)
+
+	stats := caches.Stats()["tags"]
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(2), stats.Misses)
+
+	// Renaming a tag changes the tag list and the cached articles
+	found, err := articleRepo.FindArticleBySlug(b.DB, "tagged")
+	assert.NoError(t, err)
+	assert.Equal(t, "gin", found.ArticleTags[0].Tag.Name)
+	assert.NoError(t, tagRepo.RenameTag(b.DB, "gin", "gin-gonic"))
+	tags, err = tagRepo.GetAllTags(b.DB)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gin-gonic"}, tags)
+	found, err = articleRepo.FindArticleBySlug(b.DB, "tagged")
+	assert.NoError(t, err)
+	assert.Equal(t, "gin-gonic", found.ArticleTags[0].Tag.Name)
+}
+
+func TestCachedRepositories_InvalidateOnCommit(t *testing.T) {
+	b := NewSQLiteBackend(t)
+	caches := cached.NewCaches(10, 0)
+	caches.UsePrimary(b.DB)
+	articleRepo := cached.NewCachedArticleRepository(b.ArticleRepo, caches)
+	userRepo := cached.NewCachedUserRepository(b.UserRepo, caches)
+	alice := b.SeedUser(t, "alice")
+	b.SeedArticle(t, "cached", alice.ID, 0)
+
+	found, err := articleRepo.FindArticleBySlug(b.DB, "cached")
+	assert.NoError(t, err)
+
+	// The entry stays until the transaction commits, as other readers still see the old row
+	assert.NoError(t, b.DB.Transaction(func(tx *gorm.DB) error {
+		found.Title = "Edited"
+		assert.NoError(t, articleRepo.UpdateArticle(tx, found))
+		cachedCopy, err := articleRepo.FindArticleBySlug(b.DB, "cached")
+		assert.NoError(t, err)
+		assert.Equal(t, "cached", cachedCopy.Title)
+		return nil
+	}))
+	found, err = articleRepo.FindArticleBySlug(b.DB, "cached")
+	assert.NoError(t, err)
+	assert.Equal(t, "Edited", found.Title)
+
+	// A rolled back write keeps the entry
+	assert.Error(t, b.DB.Transaction(func(tx *gorm.DB) error {
+		found.Title = "Rolled back"
+		assert.NoError(t, articleRepo.UpdateArticle(tx, found))
+		return gorm.ErrInvalidData
+	}))
+	found, err = articleRepo.FindArticleBySlug(b.DB, "cached")
+	assert.NoError(t, err)
+	assert.Equal(t, "Edited", found.Title)
+
+	// Renaming the author drops their articles, which carry the username
+	alice.Username = "alice2"
+	assert.NoError(t, userRepo.UpdateUser(b.DB, alice))
+	found, err = articleRepo.FindArticleBySlug(b.DB, "cached")
+	assert.NoError(t, err)
+	assert.Equal(t, "alice2", found.Author.Username)
+
+	stats := caches.Stats()["articles"]
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(3), stats.Misses)
+}
+
+func TestCachedArticleRepository_FillsFromPrimary(t *testing.T) {
+	b := NewSQLiteBackend(t)
+	caches := cached.NewCaches(10, 0)
+	caches.UsePrimary(b.DB)
+	articleRepo := cached.NewCachedArticleRepository(b.ArticleRepo, caches)
+	alice := b.SeedUser(t, "alice")
+	b.SeedArticle(t, "cached", alice.ID, 0)
+
+	// A replica that has not caught up yet is not read on a miss
+	replica := CreateSQLiteDB(t)
+	found, err := articleRepo.FindArticleBySlug(replica, "cached")
+	assert.NoError(t, err)
+	assert.Equal(t, "cached", found.Slug)
+}
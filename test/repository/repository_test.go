@@ -0,0 +1,1217 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-gin-realworld-api/internal/dtos"
+	appErrors "go-gin-realworld-api/internal/errors"
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+	"go-gin-realworld-api/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestUserRepository_CreateUser_DuplicateKey(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		err := b.UserRepo.CreateUser(b.DB, &models.User{Username: "john", Email: "john@example.com", Password: "x"})
+		assert.NoError(t, err)
+
+		err = b.UserRepo.CreateUser(b.DB, &models.User{Username: "john", Email: "other@example.com", Password: "x"})
+		assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+	})
+}
+
+func TestUserRepository_FindUserByUsername_WithProfile(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		user := b.SeedUser(t, "john")
+		assert.NoError(t, b.ProfileRepo.CreateProfile(b.DB, &models.Profile{UserID: user.ID}))
+
+		found, err := b.UserRepo.FindUserByUsername(b.DB, "john", true)
+		assert.NoError(t, err)
+		assert.NotNil(t, found.Profile)
+		assert.Equal(t, user.ID, found.Profile.UserID)
+
+		_, err = b.UserRepo.FindUserByUsername(b.DB, "nobody")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestUserService_RegisterUser_AlreadyExists(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		userService := services.NewUserService(b.DB, b.UserRepo, b.ProfileRepo, b.FollowRepo)
+
+		_, err := userService.RegisterUser(context.Background(), "john", "john@example.com", "password")
+		assert.NoError(t, err)
+
+		_, err = userService.RegisterUser(context.Background(), "john", "john@example.com", "password")
+		assert.Equal(t, appErrors.ErrUserAlreadyExists, err)
+	})
+}
+
+func TestArticleRepository_ListArticles_Filters(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		bob := b.SeedUser(t, "bob")
+		first := b.SeedArticle(t, "first", alice.ID, time.Hour)
+		second := b.SeedArticle(t, "second", bob.ID, time.Minute)
+
+		assert.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, first.ID, []string{"go", "gin"}))
+		assert.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, second.ID, []string{"go"}))
+		assert.NoError(t, b.FavoriteRepo.AddFavorite(b.DB, bob.ID, first.ID))
+
+		// Newest first
+		articles, total, err := b.ArticleRepo.ListArticles(b.DB, repository.ArticleFilter{}, nil, repository.ArticlePage{Limit: 20})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []string{"second", "first"}, Slugs(articles))
+
+		// By tag
+		articles, total, err = b.ArticleRepo.ListArticles(b.DB, repository.ArticleFilter{Tags: []string{"gin"}}, nil, repository.ArticlePage{Limit: 20})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []string{"first"}, Slugs(articles))
+		assert.Len(t, articles[0].ArticleTags, 2)
+		assert.Equal(t, "alice", articles[0].Author.Username)
+
+		// By author
+		articles, total, err = b.ArticleRepo.ListArticles(b.DB, repository.ArticleFilter{Author: "bob"}, nil, repository.ArticlePage{Limit: 20})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []string{"second"}, Slugs(articles))
+
+		// By favorited
+		favorited := true
+		articles, total, err = b.ArticleRepo.ListArticles(b.DB, repository.ArticleFilter{Favorited: &favorited}, &bob.ID, repository.ArticlePage{Limit: 20})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []string{"first"}, Slugs(articles))
+
+		// By not favorited
+		notFavorited := false
+		articles, total, err = b.ArticleRepo.ListArticles(b.DB, repository.ArticleFilter{Favorited: &notFavorited}, &bob.ID, repository.ArticlePage{Limit: 20})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []string{"second"}, Slugs(articles))
+
+		// Favorited filter is ignored for anonymous users
+		_, total, err = b.ArticleRepo.ListArticles(b.DB, repository.ArticleFilter{Favorited: &favorited}, nil, repository.ArticlePage{Limit: 20})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+
+		// Pagination keeps the total
+		articles, total, err = b.ArticleRepo.ListArticles(b.DB, repository.ArticleFilter{}, nil, repository.ArticlePage{Limit: 1, Offset: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []string{"first"}, Slugs(articles))
+
+		// Offset past the end
+		articles, total, err = b.ArticleRepo.ListArticles(b.DB, repository.ArticleFilter{}, nil, repository.ArticlePage{Limit: 20, Offset: 5})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Empty(t, articles)
+	})
+}
+
+func TestArticleRepository_ListArticles_TagFilters(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		first := b.SeedArticle(t, "first", alice.ID, time.Hour)
+		second := b.SeedArticle(t, "second", alice.ID, time.Minute)
+		third := b.SeedArticle(t, "third", alice.ID, 0)
+		require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, first.ID, []string{"go", "testing", "beginner"}))
+		require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, second.ID, []string{"go", "testing"}))
+		require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, third.ID, []string{"go", "gin"}))
+
+		for _, tc := range []struct {
+			name   string
+			filter repository.ArticleFilter
+			slugs  []string
+		}{
+			{"all", repository.ArticleFilter{Tags: []string{"go", "testing"}}, []string{"second", "first"}},
+			{"any", repository.ArticleFilter{Tags: []string{"gin", "testing"}, TagMode: repository.TagModeAny}, []string{"third", "second", "first"}},
+			{"all but excluded", repository.ArticleFilter{Tags: []string{"go", "testing"}, ExcludeTags: []string{"beginner"}}, []string{"second"}},
+			{"excluded only", repository.ArticleFilter{ExcludeTags: []string{"testing", "missing"}}, []string{"third"}},
+			{"missing tag", repository.ArticleFilter{Tags: []string{"go", "missing"}}, []string{}},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				// Each article is counted once, however many of its tags match
+				articles, total, err := b.ArticleRepo.ListArticles(b.DB, tc.filter, nil, repository.ArticlePage{Limit: 20})
+				assert.NoError(t, err)
+				assert.Equal(t, int64(len(tc.slugs)), total)
+				assert.Equal(t, tc.slugs, Slugs(articles))
+			})
+		}
+	})
+}
+
+func TestArticleRepository_ListArticles_SortsAndDates(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		old := b.SeedArticle(t, "old", alice.ID, 48*time.Hour)
+		middle := b.SeedArticle(t, "middle", alice.ID, 24*time.Hour)
+		recent := b.SeedArticle(t, "recent", alice.ID, time.Hour)
+
+		require.NoError(t, b.FavoriteRepo.AdjustFavoritesCount(b.DB, middle.ID, 2))
+		require.NoError(t, b.FavoriteRepo.AdjustFavoritesCount(b.DB, old.ID, 1))
+		for range 2 {
+			require.NoError(t, b.CommentRepo.CreateComment(b.DB, &models.Comment{Body: "hi", ArticleID: old.ID, AuthorID: alice.ID}))
+		}
+		require.NoError(t, b.CommentRepo.CreateComment(b.DB, &models.Comment{Body: "hi", ArticleID: recent.ID, AuthorID: alice.ID}))
+		middle.Title = "edited"
+		require.NoError(t, b.ArticleRepo.UpdateArticle(b.DB, middle))
+
+		for _, tc := range []struct {
+			sort  repository.ArticleSort
+			slugs []string
+		}{
+			{repository.ArticleSortNewest, []string{"recent", "middle", "old"}},
+			{repository.ArticleSortOldest, []string{"old", "middle", "recent"}},
+			{repository.ArticleSortMostFavorited, []string{"middle", "old", "recent"}},
+			{repository.ArticleSortMostCommented, []string{"old", "recent", "middle"}},
+			{repository.ArticleSortRecentlyUpdated, []string{"middle", "recent", "old"}},
+		} {
+			t.Run(string(tc.sort), func(t *testing.T) {
+				articles, _, err := b.ArticleRepo.ListArticles(b.DB, repository.ArticleFilter{}, nil, repository.ArticlePage{Limit: 20, Sort: tc.sort})
+				assert.NoError(t, err)
+				assert.Equal(t, tc.slugs, Slugs(articles))
+
+				// Offset pages follow the same order
+				articles, _, err = b.ArticleRepo.ListArticles(b.DB, repository.ArticleFilter{}, nil, repository.ArticlePage{Limit: 1, Offset: 1, Sort: tc.sort})
+				assert.NoError(t, err)
+				assert.Equal(t, tc.slugs[1:2], Slugs(articles))
+			})
+		}
+
+		// The range includes its start and excludes its end
+		filter := repository.ArticleFilter{CreatedAfter: middle.CreatedAt, CreatedBefore: recent.CreatedAt}
+		articles, total, err := b.ArticleRepo.ListArticles(b.DB, filter, nil, repository.ArticlePage{Limit: 20})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []string{"middle"}, Slugs(articles))
+
+		filter = repository.ArticleFilter{CreatedAfter: time.Now().Add(-36 * time.Hour)}
+		articles, total, err = b.ArticleRepo.ListArticles(b.DB, filter, nil, repository.ArticlePage{Limit: 20, Sort: repository.ArticleSortMostFavorited})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []string{"middle", "recent"}, Slugs(articles))
+	})
+}
+
+// ArticleIDs returns the article IDs of timeline entries in order
+func ArticleIDs(entries []*models.TimelineEntry) []int64 {
+	ids := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ArticleID)
+	}
+	return ids
+}
+
+func TestTimelineRepository_FanOutAndBackfill(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		bob := b.SeedUser(t, "bob")
+		carol := b.SeedUser(t, "carol")
+		require.NoError(t, b.FollowRepo.CreateFollow(b.DB, &models.Follow{FollowerID: alice.ID, FolloweeID: bob.ID}))
+
+		old := b.SeedArticle(t, "old-by-bob", bob.ID, time.Hour)
+		byCarol := b.SeedArticle(t, "by-carol", carol.ID, time.Minute)
+		recent := b.SeedArticle(t, "new-by-bob", bob.ID, time.Second)
+		for _, article := range []*models.Article{old, byCarol, recent} {
+			require.NoError(t, b.TimelineRepo.FanOutArticle(b.DB, article))
+		}
+		// Fanning out twice does not duplicate entries
+		require.NoError(t, b.TimelineRepo.FanOutArticle(b.DB, recent))
+
+		entries, total, err := b.TimelineRepo.ListTimeline(b.DB, alice.ID, repository.FeedFilter{}, repository.ArticlePage{Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []int64{recent.ID, old.ID}, ArticleIDs(entries))
+		assert.Equal(t, bob.ID, entries[0].AuthorID)
+
+		// A new follower gets the author's existing articles
+		require.NoError(t, b.FollowRepo.CreateFollow(b.DB, &models.Follow{FollowerID: alice.ID, FolloweeID: carol.ID}))
+		require.NoError(t, b.TimelineRepo.BackfillTimeline(b.DB, alice.ID, carol.ID))
+		entries, total, err = b.TimelineRepo.ListTimeline(b.DB, alice.ID, repository.FeedFilter{}, repository.ArticlePage{Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Equal(t, []int64{recent.ID, byCarol.ID, old.ID}, ArticleIDs(entries))
+
+		// Keyset pages follow the article order
+		after := &repository.ArticleCursor{CreatedAt: recent.CreatedAt, ID: recent.ID}
+		entries, _, err = b.TimelineRepo.ListTimeline(b.DB, alice.ID, repository.FeedFilter{}, repository.ArticlePage{Limit: 1, After: after, SkipCount: true})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{byCarol.ID}, ArticleIDs(entries))
+		before := &repository.ArticleCursor{CreatedAt: old.CreatedAt, ID: old.ID}
+		entries, _, err = b.TimelineRepo.ListTimeline(b.DB, alice.ID, repository.FeedFilter{}, repository.ArticlePage{Limit: 2, Before: before, SkipCount: true})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{recent.ID, byCarol.ID}, ArticleIDs(entries))
+
+		// Unfollowing prunes the author's articles; deleting an article removes it everywhere
+		require.NoError(t, b.TimelineRepo.PruneTimeline(b.DB, alice.ID, carol.ID))
+		require.NoError(t, b.ArticleRepo.DeleteArticleBySlug(b.DB, "old-by-bob"))
+		entries, total, err = b.TimelineRepo.ListTimeline(b.DB, alice.ID, repository.FeedFilter{}, repository.ArticlePage{Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []int64{recent.ID}, ArticleIDs(entries))
+
+		entries, total, err = b.TimelineRepo.ListTimeline(b.DB, bob.ID, repository.FeedFilter{}, repository.ArticlePage{Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), total)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestTimelineRepository_ListFanOutOnRead(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		bob := b.SeedUser(t, "bob")
+		carol := b.SeedUser(t, "carol")
+		require.NoError(t, b.FollowRepo.CreateFollow(b.DB, &models.Follow{FollowerID: alice.ID, FolloweeID: bob.ID}))
+
+		b.SeedArticle(t, "fanned-out", bob.ID, time.Hour)
+		popular := &models.Article{Slug: "popular", Title: "popular", Description: "desc", Body: "body", AuthorID: bob.ID, FanOutOnRead: true}
+		require.NoError(t, b.ArticleRepo.CreateArticle(b.DB, popular))
+		other := &models.Article{Slug: "other", Title: "other", Description: "desc", Body: "body", AuthorID: carol.ID, FanOutOnRead: true}
+		require.NoError(t, b.ArticleRepo.CreateArticle(b.DB, other))
+
+		entries, total, err := b.TimelineRepo.ListFanOutOnRead(b.DB, alice.ID, repository.FeedFilter{}, repository.ArticlePage{Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []int64{popular.ID}, ArticleIDs(entries))
+		assert.Equal(t, alice.ID, entries[0].UserID)
+
+		// Backfilling skips articles read on demand
+		require.NoError(t, b.TimelineRepo.BackfillTimeline(b.DB, alice.ID, bob.ID))
+		entries, _, err = b.TimelineRepo.ListTimeline(b.DB, alice.ID, repository.FeedFilter{}, repository.ArticlePage{Limit: 20})
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+		assert.NotEqual(t, popular.ID, entries[0].ArticleID)
+
+		count, err := b.FollowRepo.CountFollowers(b.DB, bob.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
+
+func TestTimelineRepository_ListFeed_SortsAndDates(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		bob := b.SeedUser(t, "bob")
+		carol := b.SeedUser(t, "carol")
+		dave := b.SeedUser(t, "dave")
+		for _, followee := range []int64{bob.ID, carol.ID} {
+			require.NoError(t, b.FollowRepo.CreateFollow(b.DB, &models.Follow{FollowerID: alice.ID, FolloweeID: followee}))
+		}
+
+		// Bob's articles are fanned out, carol's and dave's are read on demand
+		old := b.SeedArticle(t, "old", bob.ID, 48*time.Hour)
+		recent := b.SeedArticle(t, "recent", bob.ID, time.Hour)
+		for _, article := range []*models.Article{old, recent} {
+			require.NoError(t, b.TimelineRepo.FanOutArticle(b.DB, article))
+		}
+		createdAt := time.Now().Add(-24 * time.Hour)
+		middle := &models.Article{Slug: "middle", Title: "middle", Description: "desc", Body: "body", AuthorID: carol.ID, FanOutOnRead: true, CreatedAt: createdAt, UpdatedAt: createdAt}
+		require.NoError(t, b.ArticleRepo.CreateArticle(b.DB, middle))
+		unfollowed := &models.Article{Slug: "unfollowed", Title: "unfollowed", Description: "desc", Body: "body", AuthorID: dave.ID, FanOutOnRead: true}
+		require.NoError(t, b.ArticleRepo.CreateArticle(b.DB, unfollowed))
+
+		require.NoError(t, b.FavoriteRepo.AdjustFavoritesCount(b.DB, middle.ID, 2))
+		require.NoError(t, b.FavoriteRepo.AdjustFavoritesCount(b.DB, old.ID, 1))
+		for range 2 {
+			require.NoError(t, b.CommentRepo.CreateComment(b.DB, &models.Comment{Body: "hi", ArticleID: old.ID, AuthorID: alice.ID}))
+		}
+		require.NoError(t, b.CommentRepo.CreateComment(b.DB, &models.Comment{Body: "hi", ArticleID: recent.ID, AuthorID: alice.ID}))
+		middle.Body = "edited"
+		require.NoError(t, b.ArticleRepo.UpdateArticle(b.DB, middle))
+
+		for _, tc := range []struct {
+			sort repository.ArticleSort
+			ids  []int64
+		}{
+			{repository.ArticleSortOldest, []int64{old.ID, middle.ID, recent.ID}},
+			{repository.ArticleSortMostFavorited, []int64{middle.ID, old.ID, recent.ID}},
+			{repository.ArticleSortMostCommented, []int64{old.ID, recent.ID, middle.ID}},
+			{repository.ArticleSortRecentlyUpdated, []int64{middle.ID, recent.ID, old.ID}},
+		} {
+			t.Run(string(tc.sort), func(t *testing.T) {
+				entries, total, err := b.TimelineRepo.ListFeed(b.DB, alice.ID, repository.FeedFilter{}, repository.ArticlePage{Limit: 20, Sort: tc.sort})
+				require.NoError(t, err)
+				assert.Equal(t, int64(3), total)
+				assert.Equal(t, tc.ids, ArticleIDs(entries))
+				assert.Equal(t, alice.ID, entries[0].UserID)
+
+				entries, _, err = b.TimelineRepo.ListFeed(b.DB, alice.ID, repository.FeedFilter{}, repository.ArticlePage{Limit: 1, Offset: 1, Sort: tc.sort})
+				require.NoError(t, err)
+				assert.Equal(t, tc.ids[1:2], ArticleIDs(entries))
+			})
+		}
+
+		// The range includes its start and excludes its end, from both sources
+		filter := repository.FeedFilter{CreatedAfter: time.Now().Add(-36 * time.Hour)}
+		entries, total, err := b.TimelineRepo.ListFeed(b.DB, alice.ID, filter, repository.ArticlePage{Limit: 20, Sort: repository.ArticleSortMostFavorited})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []int64{middle.ID, recent.ID}, ArticleIDs(entries))
+
+		filter = repository.FeedFilter{CreatedAfter: old.CreatedAt, CreatedBefore: recent.CreatedAt}
+		entries, total, err = b.TimelineRepo.ListTimeline(b.DB, alice.ID, filter, repository.ArticlePage{Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []int64{old.ID}, ArticleIDs(entries))
+		entries, total, err = b.TimelineRepo.ListFanOutOnRead(b.DB, alice.ID, filter, repository.ArticlePage{Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []int64{middle.ID}, ArticleIDs(entries))
+
+		filter = repository.FeedFilter{CreatedBefore: old.CreatedAt}
+		entries, total, err = b.TimelineRepo.ListTimeline(b.DB, alice.ID, filter, repository.ArticlePage{Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), total)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestArticleRepository_ListArticles_Keyset(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		b.SeedArticle(t, "a", alice.ID, 4*time.Hour)
+		b.SeedArticle(t, "b", alice.ID, 3*time.Hour)
+		third := b.SeedArticle(t, "c", alice.ID, 2*time.Hour)
+		b.SeedArticle(t, "d", alice.ID, time.Hour)
+		b.SeedArticle(t, "e", alice.ID, 0)
+
+		cursor := &repository.ArticleCursor{CreatedAt: third.CreatedAt, ID: third.ID}
+
+		articles, total, err := b.ArticleRepo.ListArticles(b.DB, repository.ArticleFilter{}, nil, repository.ArticlePage{Limit: 20, After: cursor})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), total)
+		assert.Equal(t, []string{"b", "a"}, Slugs(articles))
+
+		// Before keeps the articles closest to the cursor, newest first
+		articles, _, err = b.ArticleRepo.ListArticles(b.DB, repository.ArticleFilter{}, nil, repository.ArticlePage{Limit: 1, Before: cursor})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"d"}, Slugs(articles))
+
+		articles, total, err = b.ArticleRepo.ListArticles(b.DB, repository.ArticleFilter{}, nil, repository.ArticlePage{Limit: 20, Before: cursor, SkipCount: true})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), total)
+		assert.Equal(t, []string{"e", "d"}, Slugs(articles))
+	})
+}
+
+func TestArticleRepository_SearchArticles(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		bob := b.SeedUser(t, "bob")
+		seeded := []*models.Article{
+			{Slug: "in-body", Title: "Weekly notes", Description: "Misc", Body: "Some thoughts on golang generics", AuthorID: alice.ID},
+			{Slug: "in-title", Title: "Golang generics", Description: "An introduction", Body: "Type parameters explained", AuthorID: bob.ID},
+			{Slug: "unrelated", Title: "Cooking", Description: "Pasta", Body: "Boil water", AuthorID: alice.ID},
+		}
+		for _, article := range seeded {
+			require.NoError(t, b.ArticleRepo.CreateArticle(b.DB, article))
+		}
+		require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, seeded[0].ID, []string{"go"}))
+
+		search := repository.ArticleSearch{Query: "golang generics", Terms: []string{"golang", "generics"}, Limit: 20}
+
+		// Title matches rank above body matches
+		articles, total, err := b.ArticleRepo.SearchArticles(b.DB, search)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []string{"in-title", "in-body"}, Slugs(articles))
+		assert.Equal(t, "bob", articles[0].Author.Username)
+
+		// The tag and author filters apply
+		tagged := search
+		tagged.Tag = "go"
+		articles, total, err = b.ArticleRepo.SearchArticles(b.DB, tagged)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []string{"in-body"}, Slugs(articles))
+
+		byAuthor := search
+		byAuthor.Author = "bob"
+		articles, _, err = b.ArticleRepo.SearchArticles(b.DB, byAuthor)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"in-title"}, Slugs(articles))
+
+		// Pagination keeps the total
+		paged := search
+		paged.Limit, paged.Offset = 1, 1
+		articles, total, err = b.ArticleRepo.SearchArticles(b.DB, paged)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []string{"in-body"}, Slugs(articles))
+
+		articles, total, err = b.ArticleRepo.SearchArticles(b.DB, repository.ArticleSearch{Query: "kubernetes", Terms: []string{"kubernetes"}, Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), total)
+		assert.Empty(t, articles)
+	})
+}
+
+func TestArticleService_UniqueSlugs(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		ctx := context.Background()
+		alice := b.SeedUser(t, "alice")
+		articleService := services.NewArticleService(b.DB, b.DB, b.ArticleRepo, b.FavoriteRepo, b.FollowRepo, b.TimelineRepo, nil)
+
+		newArticle := func(title string) *dtos.CreateArticleRequest {
+			req := &dtos.CreateArticleRequest{}
+			req.Article.Title = title
+			req.Article.Description = "desc"
+			req.Article.Body = "body"
+			return req
+		}
+
+		first, err := articleService.CreateArticle(ctx, newArticle("Hello World"), alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "hello-world", first.Article.Slug)
+
+		second, err := articleService.CreateArticle(ctx, newArticle("Hello World"), alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "hello-world-2", second.Article.Slug)
+
+		// Renaming onto a taken title allocates the next free slug
+		other, err := articleService.CreateArticle(ctx, newArticle("Other"), alice.ID)
+		require.NoError(t, err)
+		update := &dtos.UpdateArticleRequest{}
+		update.Article.Title = "Hello World"
+		renamed, err := articleService.UpdateArticle(ctx, other.Article.Slug, update, alice.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "hello-world-3", renamed.Article.Slug)
+
+		// Saving the same title again keeps the slug
+		renamed, err = articleService.UpdateArticle(ctx, renamed.Article.Slug, update, alice.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "hello-world-3", renamed.Article.Slug)
+
+		// Concurrent creates with the same title all get distinct slugs
+		var wg sync.WaitGroup
+		slugs := make([]string, 10)
+		errs := make([]error, 10)
+		for i := range slugs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				created, err := articleService.CreateArticle(ctx, newArticle("Race"), alice.ID)
+				errs[i] = err
+				if err == nil {
+					slugs[i] = created.Article.Slug
+				}
+			}()
+		}
+		wg.Wait()
+
+		unique := make(map[string]bool)
+		for i, slug := range slugs {
+			require.NoError(t, errs[i])
+			assert.True(t, strings.HasPrefix(slug, "race"), slug)
+			unique[slug] = true
+		}
+		assert.Len(t, unique, len(slugs))
+	})
+}
+
+func TestArticleService_SlugHistory(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		ctx := context.Background()
+		alice := b.SeedUser(t, "alice")
+		articleService := services.NewArticleService(b.DB, b.DB, b.ArticleRepo, b.FavoriteRepo, b.FollowRepo, b.TimelineRepo, nil)
+
+		req := &dtos.CreateArticleRequest{}
+		req.Article.Title = "Hello World"
+		req.Article.Description = "desc"
+		req.Article.Body = "body"
+		created, err := articleService.CreateArticle(ctx, req, alice.ID)
+		require.NoError(t, err)
+
+		update := &dtos.UpdateArticleRequest{}
+		update.Article.Title = "Goodbye World"
+		renamed, err := articleService.UpdateArticle(ctx, created.Article.Slug, update, alice.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "goodbye-world", renamed.Article.Slug)
+
+		// The old slug resolves to the current one
+		current, err := b.ArticleRepo.FindCurrentSlug(b.DB, "hello-world")
+		require.NoError(t, err)
+		assert.Equal(t, "goodbye-world", current)
+
+		_, err = articleService.GetArticleBySlug(ctx, "hello-world", nil)
+		var moved *appErrors.SlugMovedError
+		require.ErrorAs(t, err, &moved)
+		assert.Equal(t, "goodbye-world", moved.Slug)
+
+		_, err = b.ArticleRepo.FindCurrentSlug(b.DB, "never-used")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		// Another article cannot take over the old slug
+		other, err := articleService.CreateArticle(ctx, req, alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "hello-world-2", other.Article.Slug)
+
+		// but the article that used it can have it back
+		update.Article.Title = "Hello World"
+		restored, err := articleService.UpdateArticle(ctx, renamed.Article.Slug, update, alice.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "hello-world", restored.Article.Slug)
+
+		current, err = b.ArticleRepo.FindCurrentSlug(b.DB, "goodbye-world")
+		require.NoError(t, err)
+		assert.Equal(t, "hello-world", current)
+
+		// Deleting the article releases its whole history
+		require.NoError(t, b.ArticleRepo.DeleteArticleBySlug(b.DB, "hello-world"))
+		_, err = b.ArticleRepo.FindCurrentSlug(b.DB, "goodbye-world")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestArticleService_Feed(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		ctx := context.Background()
+		alice := b.SeedUser(t, "alice")
+		b.SeedUser(t, "bob")
+		b.SeedUser(t, "carol")
+		bob, err := b.UserRepo.FindUserByUsername(b.DB, "bob")
+		require.NoError(t, err)
+		carol, err := b.UserRepo.FindUserByUsername(b.DB, "carol")
+		require.NoError(t, err)
+
+		articleService := services.NewArticleService(b.DB, b.DB, b.ArticleRepo, b.FavoriteRepo, b.FollowRepo, b.TimelineRepo, nil)
+		profileService := services.NewProfileService(b.DB, b.UserRepo, b.ProfileRepo, b.FollowRepo, b.TimelineRepo)
+		create := func(title string, authorID int64) {
+			req := &dtos.CreateArticleRequest{}
+			req.Article.Title = title
+			req.Article.Description = "desc"
+			req.Article.Body = "body"
+			_, err := articleService.CreateArticle(ctx, req, authorID)
+			require.NoError(t, err)
+		}
+		feed := func(query *dtos.FeedArticlesQuery) *dtos.ArticlesListResponse {
+			resp, err := articleService.GetFeedArticles(ctx, alice.ID, query)
+			require.NoError(t, err)
+			return resp
+		}
+		slugs := func(resp *dtos.ArticlesListResponse) []string {
+			slugs := make([]string, 0, len(resp.Articles))
+			for _, article := range resp.Articles {
+				slugs = append(slugs, article.Slug)
+			}
+			return slugs
+		}
+
+		// Following backfills the author's earlier articles, later ones are fanned out
+		create("bob 1", bob.ID)
+		_, err = profileService.FollowUser(ctx, alice.ID, "bob")
+		require.NoError(t, err)
+		create("bob 2", bob.ID)
+
+		// Carol has more followers than the limit: her articles are read on demand
+		_, err = profileService.FollowUser(ctx, alice.ID, "carol")
+		require.NoError(t, err)
+		articleService.SetFanOutMaxFollowers(0)
+		create("carol 1", carol.ID)
+		articleService.SetFanOutMaxFollowers(100)
+		create("bob 3", bob.ID)
+
+		resp := feed(&dtos.FeedArticlesQuery{})
+		assert.Equal(t, []string{"bob-3", "carol-1", "bob-2", "bob-1"}, slugs(resp))
+		assert.Equal(t, 4, *resp.ArticlesCount)
+
+		// Offset and cursor pages span both sources
+		resp = feed(&dtos.FeedArticlesQuery{Limit: 2, Offset: 1})
+		assert.Equal(t, []string{"carol-1", "bob-2"}, slugs(resp))
+		resp = feed(&dtos.FeedArticlesQuery{Limit: 2})
+		next := feed(&dtos.FeedArticlesQuery{Limit: 2, Cursor: resp.NextCursor})
+		assert.Equal(t, []string{"bob-2", "bob-1"}, slugs(next))
+		prev := feed(&dtos.FeedArticlesQuery{Limit: 2, Cursor: next.PrevCursor})
+		assert.Equal(t, []string{"bob-3", "carol-1"}, slugs(prev))
+
+		// Other sorts read both sources in one query, paged by offset
+		resp = feed(&dtos.FeedArticlesQuery{Sort: "oldest"})
+		assert.Equal(t, []string{"bob-1", "bob-2", "carol-1", "bob-3"}, slugs(resp))
+		assert.Equal(t, 4, *resp.ArticlesCount)
+		resp = feed(&dtos.FeedArticlesQuery{Sort: "oldest", Limit: 2, Offset: 1})
+		assert.Equal(t, []string{"bob-2", "carol-1"}, slugs(resp))
+		assert.Empty(t, resp.NextCursor)
+
+		// Unfollowing removes both kinds of articles
+		_, err = profileService.UnfollowUser(ctx, alice.ID, "carol")
+		require.NoError(t, err)
+		_, err = profileService.UnfollowUser(ctx, alice.ID, "bob")
+		require.NoError(t, err)
+		resp = feed(&dtos.FeedArticlesQuery{})
+		assert.Empty(t, resp.Articles)
+		assert.Equal(t, 0, *resp.ArticlesCount)
+	})
+}
+
+func TestArticleRepository_CreateArticle_DuplicateSlug(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		b.SeedArticle(t, "hello-world", alice.ID, 0)
+
+		err := b.ArticleRepo.CreateArticle(b.DB, &models.Article{Slug: "hello-world", Title: "Hello World", Description: "d", Body: "b", AuthorID: alice.ID})
+		assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+	})
+}
+
+func TestArticleRepository_UpdateArticle_Version(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		article := b.SeedArticle(t, "versioned", alice.ID, 0)
+		assert.Equal(t, int64(1), article.Version)
+
+		first, err := b.ArticleRepo.FindArticleBySlug(b.DB, "versioned")
+		require.NoError(t, err)
+		second, err := b.ArticleRepo.FindArticleBySlug(b.DB, "versioned")
+		require.NoError(t, err)
+
+		first.Title = "First"
+		assert.NoError(t, b.ArticleRepo.UpdateArticle(b.DB, first))
+		assert.Equal(t, int64(2), first.Version)
+
+		// The second editor read version 1 and must not overwrite the first edit
+		second.Title = "Second"
+		assert.ErrorIs(t, b.ArticleRepo.UpdateArticle(b.DB, second), repository.ErrVersionConflict)
+		assert.Equal(t, int64(1), second.Version)
+
+		// Favorites do not change the version
+		assert.NoError(t, b.FavoriteRepo.AdjustFavoritesCount(b.DB, article.ID, 1))
+
+		found, err := b.ArticleRepo.FindArticleBySlug(b.DB, "versioned")
+		assert.NoError(t, err)
+		assert.Equal(t, "First", found.Title)
+		assert.Equal(t, int64(2), found.Version)
+		assert.Equal(t, 1, found.FavoritesCount)
+	})
+}
+
+func TestArticleService_UpdateArticle_IfMatch(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		ctx := context.Background()
+		alice := b.SeedUser(t, "alice")
+		bob := b.SeedUser(t, "bob")
+		articleService := services.NewArticleService(b.DB, b.DB, b.ArticleRepo, b.FavoriteRepo, b.FollowRepo, b.TimelineRepo, nil)
+		favoriteService := services.NewFavoriteService(b.DB, b.FavoriteRepo, b.ArticleRepo)
+		b.SeedArticle(t, "draft", alice.ID, 0)
+
+		update := &dtos.UpdateArticleRequest{}
+		update.Article.Body = "edited"
+		updated, err := articleService.UpdateArticle(ctx, "draft", update, alice.ID, []int64{1})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), updated.Article.Version)
+
+		_, err = favoriteService.FavoriteArticle(ctx, "draft", bob.ID)
+		require.NoError(t, err)
+
+		// An editor still at version 1 is told the current version
+		update.Article.Body = "lost"
+		_, err = articleService.UpdateArticle(ctx, "draft", update, alice.ID, []int64{1})
+		var conflict *appErrors.VersionConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, int64(2), conflict.Version)
+
+		// No tag matches nothing; no precondition matches anything
+		_, err = articleService.UpdateArticle(ctx, "draft", update, alice.ID, []int64{})
+		assert.ErrorAs(t, err, &conflict)
+		updated, err = articleService.UpdateArticle(ctx, "draft", update, alice.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "lost", updated.Article.Body)
+		assert.Equal(t, int64(3), updated.Article.Version)
+		assert.Equal(t, 1, updated.Article.FavoritesCount)
+	})
+}
+
+func TestArticleService_PatchArticle(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		ctx := context.Background()
+		alice := b.SeedUser(t, "alice")
+		articleService := services.NewArticleService(b.DB, b.DB, b.ArticleRepo, b.FavoriteRepo, b.FollowRepo, b.TimelineRepo, nil)
+
+		create := &dtos.CreateArticleRequest{}
+		create.Article.Title = "Patched"
+		create.Article.Description = "desc"
+		create.Article.Body = "body"
+		create.Article.TagList = []string{"go", "web"}
+		created, err := articleService.CreateArticle(ctx, create, alice.ID)
+		require.NoError(t, err)
+
+		patch := func(body string) *dtos.PatchArticleRequest {
+			var req dtos.PatchArticleRequest
+			require.NoError(t, json.Unmarshal([]byte(body), &req))
+			require.Empty(t, req.Validate())
+			return &req
+		}
+
+		// Absent members are left alone; an empty description is a value like any other
+		patched, err := articleService.PatchArticle(ctx, created.Article.Slug, patch(`{"article": {"description": ""}}`), alice.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "", patched.Article.Description)
+		assert.Equal(t, "body", patched.Article.Body)
+		assert.ElementsMatch(t, []string{"go", "web"}, patched.Article.TagList)
+
+		// An empty list removes every tag, and so does null
+		patched, err = articleService.PatchArticle(ctx, created.Article.Slug, patch(`{"article": {"tagList": []}}`), alice.ID, nil)
+		require.NoError(t, err)
+		assert.Empty(t, patched.Article.TagList)
+
+		_, err = articleService.PatchArticle(ctx, created.Article.Slug, patch(`{"article": {"tagList": ["go"]}}`), alice.ID, nil)
+		require.NoError(t, err)
+		patched, err = articleService.PatchArticle(ctx, created.Article.Slug, patch(`{"article": {"tagList": null}}`), alice.ID, nil)
+		require.NoError(t, err)
+		assert.Empty(t, patched.Article.TagList)
+
+		// PUT still treats an empty list as not provided
+		_, err = articleService.PatchArticle(ctx, created.Article.Slug, patch(`{"article": {"tagList": ["go"]}}`), alice.ID, nil)
+		require.NoError(t, err)
+		update := &dtos.UpdateArticleRequest{}
+		update.Article.TagList = []string{}
+		updated, err := articleService.UpdateArticle(ctx, created.Article.Slug, update, alice.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"go"}, updated.Article.TagList)
+		assert.Equal(t, "", updated.Article.Description)
+	})
+}
+
+func TestArticleRepository_DeleteArticleBySlug_Cascades(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		article := b.SeedArticle(t, "doomed", alice.ID, 0)
+		assert.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, article.ID, []string{"go"}))
+		assert.NoError(t, b.CommentRepo.CreateComment(b.DB, &models.Comment{Body: "hi", ArticleID: article.ID, AuthorID: alice.ID}))
+		assert.NoError(t, b.FavoriteRepo.AddFavorite(b.DB, alice.ID, article.ID))
+
+		assert.NoError(t, b.ArticleRepo.DeleteArticleBySlug(b.DB, "doomed"))
+
+		_, err := b.ArticleRepo.FindArticleBySlug(b.DB, "doomed")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		comments, err := b.CommentRepo.GetCommentsByArticleID(b.DB, article.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, comments)
+
+		isFavorited, err := b.FavoriteRepo.IsFavorited(b.DB, alice.ID, article.ID)
+		assert.NoError(t, err)
+		assert.False(t, isFavorited)
+
+		// Tags themselves outlive the article, though no longer listed
+		counts, err := b.TagRepo.ListTagCounts(b.DB, repository.TagSortName, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.TagCount{{Name: "go", Articles: 0}}, counts)
+		tags, err := b.TagRepo.GetAllTags(b.DB)
+		assert.NoError(t, err)
+		assert.Empty(t, tags)
+	})
+}
+
+func TestFavoriteRepository_FavoritedArticleIDs(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		bob := b.SeedUser(t, "bob")
+		first := b.SeedArticle(t, "first", alice.ID, time.Hour)
+		second := b.SeedArticle(t, "second", alice.ID, time.Minute)
+		third := b.SeedArticle(t, "third", alice.ID, 0)
+		assert.NoError(t, b.FavoriteRepo.AddFavorite(b.DB, bob.ID, first.ID))
+		assert.NoError(t, b.FavoriteRepo.AddFavorite(b.DB, bob.ID, third.ID))
+		assert.NoError(t, b.FavoriteRepo.AddFavorite(b.DB, alice.ID, second.ID))
+
+		ids, err := b.FavoriteRepo.FavoritedArticleIDs(b.DB, bob.ID, []int64{first.ID, second.ID})
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{first.ID}, ids)
+
+		ids, err = b.FavoriteRepo.FavoritedArticleIDs(b.DB, bob.ID, []int64{})
+		assert.NoError(t, err)
+		assert.Empty(t, ids)
+
+		// Articles no longer carry their favorites
+		article, err := b.ArticleRepo.FindArticleBySlug(b.DB, "first")
+		assert.NoError(t, err)
+		assert.Empty(t, article.Favorites)
+	})
+}
+
+func TestFavoriteRepository_FavoritesCount(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		bob := b.SeedUser(t, "bob")
+		article := b.SeedArticle(t, "counted", alice.ID, 0)
+		other := b.SeedArticle(t, "other", alice.ID, time.Minute)
+
+		assert.NoError(t, b.FavoriteRepo.AdjustFavoritesCount(b.DB, article.ID, 1))
+		assert.NoError(t, b.FavoriteRepo.AdjustFavoritesCount(b.DB, article.ID, 1))
+		assert.NoError(t, b.FavoriteRepo.AdjustFavoritesCount(b.DB, other.ID, -1))
+
+		// Saving a stale copy of the article keeps the count
+		article.Title = "Counted"
+		assert.NoError(t, b.ArticleRepo.UpdateArticle(b.DB, article))
+
+		found, err := b.ArticleRepo.FindArticleBySlug(b.DB, "counted")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, found.FavoritesCount)
+		assert.Equal(t, "Counted", found.Title)
+		found, err = b.ArticleRepo.FindArticleBySlug(b.DB, "other")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, found.FavoritesCount)
+
+		// Only bob's favorite exists, so the count of 2 has drifted
+		assert.NoError(t, b.FavoriteRepo.AddFavorite(b.DB, bob.ID, article.ID))
+		drifts, err := b.FavoriteRepo.ReconcileFavoritesCounts(b.DB)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.FavoritesCountDrift{{ArticleID: article.ID, Slug: "counted", Stored: 2, Actual: 1}}, drifts)
+
+		found, err = b.ArticleRepo.FindArticleBySlug(b.DB, "counted")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, found.FavoritesCount)
+
+		drifts, err = b.FavoriteRepo.ReconcileFavoritesCounts(b.DB)
+		assert.NoError(t, err)
+		assert.Empty(t, drifts)
+
+		removed, err := b.FavoriteRepo.RemoveFavorite(b.DB, bob.ID, article.ID)
+		assert.NoError(t, err)
+		assert.True(t, removed)
+		removed, err = b.FavoriteRepo.RemoveFavorite(b.DB, bob.ID, article.ID)
+		assert.NoError(t, err)
+		assert.False(t, removed)
+	})
+}
+
+func TestTagRepository_ListTagCounts(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		first := b.SeedArticle(t, "first", alice.ID, 0)
+		second := b.SeedArticle(t, "second", alice.ID, time.Minute)
+		require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, first.ID, []string{"go", "web"}))
+		require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, second.ID, []string{"go", "api"}))
+		require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, second.ID, []string{"go"}))
+
+		counts, err := b.TagRepo.ListTagCounts(b.DB, repository.TagSortName, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.TagCount{{Name: "api", Articles: 0}, {Name: "go", Articles: 2}, {Name: "web", Articles: 1}}, counts)
+
+		counts, err = b.TagRepo.ListTagCounts(b.DB, repository.TagSortPopular, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.TagCount{{Name: "go", Articles: 2}, {Name: "web", Articles: 1}}, counts)
+	})
+}
+
+func TestTagRepository_SuggestTags(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		first := b.SeedArticle(t, "first", alice.ID, 0)
+		second := b.SeedArticle(t, "second", alice.ID, time.Minute)
+		require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, first.ID, []string{"golang", "gopher", "go_kit", "web"}))
+		require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, second.ID, []string{"gopher", "gorm"}))
+
+		tags, err := b.TagRepo.SuggestTags(b.DB, "go", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"gopher", "go_kit", "golang"}, tags)
+
+		// Wildcards are matched literally
+		tags, err = b.TagRepo.SuggestTags(b.DB, "go_", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"go_kit"}, tags)
+
+		tags, err = b.TagRepo.SuggestTags(b.DB, "rust", 10)
+		assert.NoError(t, err)
+		assert.Empty(t, tags)
+	})
+}
+
+func TestTagRepository_RenameMergeAndDeleteUnused(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		first := b.SeedArticle(t, "first", alice.ID, 0)
+		second := b.SeedArticle(t, "second", alice.ID, time.Minute)
+		require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, first.ID, []string{"golang", "go"}))
+		require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, second.ID, []string{"golnag", "web"}))
+
+		assert.NoError(t, b.TagRepo.RenameTag(b.DB, "golnag", "go-lang"))
+		assert.ErrorIs(t, b.TagRepo.RenameTag(b.DB, "go-lang", "go"), gorm.ErrDuplicatedKey)
+		assert.ErrorIs(t, b.TagRepo.RenameTag(b.DB, "missing", "other"), gorm.ErrRecordNotFound)
+
+		// The first article has both tags, so it keeps a single go
+		merged, err := b.TagRepo.MergeTags(b.DB, "golang", "go")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), merged)
+		merged, err = b.TagRepo.MergeTags(b.DB, "go-lang", "go")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), merged)
+		_, err = b.TagRepo.MergeTags(b.DB, "golang", "go")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		counts, err := b.TagRepo.ListTagCounts(b.DB, repository.TagSortName, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.TagCount{{Name: "go", Articles: 2}, {Name: "web", Articles: 1}}, counts)
+
+		// Only tags without articles are collected
+		require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, second.ID, []string{"go"}))
+		deleted, err := b.TagRepo.DeleteUnusedTags(b.DB)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"web"}, deleted)
+		tags, err := b.TagRepo.GetAllTags(b.DB)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"go"}, tags)
+	})
+}
+
+func TestCommentRepository_GetCommentByID_Preloads(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		bob := b.SeedUser(t, "bob")
+		article := b.SeedArticle(t, "post", alice.ID, 0)
+
+		comment := &models.Comment{Body: "nice", ArticleID: article.ID, AuthorID: bob.ID}
+		assert.NoError(t, b.CommentRepo.CreateComment(b.DB, comment))
+
+		found, err := b.CommentRepo.GetCommentByID(b.DB, comment.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "bob", found.Author.Username)
+		assert.Equal(t, alice.ID, found.Article.AuthorID)
+
+		assert.NoError(t, b.CommentRepo.DeleteComment(b.DB, comment.ID))
+		_, err = b.CommentRepo.GetCommentByID(b.DB, comment.ID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestTrendingRepository_ActivityAndRankings(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		bob := b.SeedUser(t, "bob")
+		first := b.SeedArticle(t, "first", alice.ID, time.Hour)
+		second := b.SeedArticle(t, "second", alice.ID, time.Minute)
+		third := b.SeedArticle(t, "third", alice.ID, 0)
+
+		carol := b.SeedUser(t, "carol")
+		require.NoError(t, b.FavoriteRepo.AddFavorite(b.DB, bob.ID, first.ID))
+		require.NoError(t, b.FavoriteRepo.AddFavorite(b.DB, carol.ID, first.ID))
+		require.NoError(t, b.CommentRepo.CreateComment(b.DB, &models.Comment{Body: "hi", ArticleID: second.ID, AuthorID: bob.ID}))
+
+		// Activity falls in the first band whose bound it is not before
+		now := time.Now()
+		bounds := []time.Time{now.Add(time.Minute), now.Add(-time.Minute), now.Add(-time.Hour)}
+		favorites, err := b.TrendingRepo.CountFavoritesByBand(b.DB, bounds)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.ActivityCount{{ArticleID: first.ID, Band: 1, Count: 2}}, favorites)
+		comments, err := b.TrendingRepo.CountCommentsByBand(b.DB, bounds)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.ActivityCount{{ArticleID: second.ID, Band: 1, Count: 1}}, comments)
+		favorites, err = b.TrendingRepo.CountFavoritesByBand(b.DB, bounds[:1])
+		assert.NoError(t, err)
+		assert.Empty(t, favorites)
+
+		// Highest score first, ties to the newer article
+		scores := []repository.TrendingScore{{ArticleID: first.ID, Score: 1}, {ArticleID: second.ID, Score: 3}, {ArticleID: third.ID, Score: 1}}
+		require.NoError(t, b.TrendingRepo.ReplaceTrendingScores(b.DB, "24h", scores, time.Now()))
+		require.NoError(t, b.TrendingRepo.ReplaceTrendingScores(b.DB, "7d", scores[:1], time.Now()))
+		ids, total, err := b.TrendingRepo.ListTrending(b.DB, "24h", 20, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Equal(t, []int64{second.ID, third.ID, first.ID}, ids)
+		ids, _, err = b.TrendingRepo.ListTrending(b.DB, "24h", 1, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{third.ID}, ids)
+
+		// Replacing a ranking leaves the other windows alone
+		require.NoError(t, b.TrendingRepo.ReplaceTrendingScores(b.DB, "24h", nil, time.Now()))
+		_, total, err = b.TrendingRepo.ListTrending(b.DB, "24h", 20, 0)
+		assert.NoError(t, err)
+		assert.Zero(t, total)
+		ids, _, err = b.TrendingRepo.ListTrending(b.DB, "7d", 20, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{first.ID}, ids)
+
+		// Deleting an article removes its scores
+		require.NoError(t, b.ArticleRepo.DeleteArticleBySlug(b.DB, "first"))
+		_, total, err = b.TrendingRepo.ListTrending(b.DB, "7d", 20, 0)
+		assert.NoError(t, err)
+		assert.Zero(t, total)
+	})
+}
+
+func TestTrendingRepository_ClaimTrendingRefresh(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		now := time.Now()
+		claimed, err := b.TrendingRepo.ClaimTrendingRefresh(b.DB, now, now.Add(-time.Minute))
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+
+		// Refreshed within the last minute: another server skips it
+		claimed, err = b.TrendingRepo.ClaimTrendingRefresh(b.DB, now.Add(time.Second), now.Add(-time.Minute))
+		assert.NoError(t, err)
+		assert.False(t, claimed)
+
+		later := now.Add(2 * time.Minute)
+		claimed, err = b.TrendingRepo.ClaimTrendingRefresh(b.DB, later, later.Add(-time.Minute))
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+	})
+}
+
+func TestRelatedRepository_TagOverlapsAndCoFavorites(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		bob := b.SeedUser(t, "bob")
+		carol := b.SeedUser(t, "carol")
+		source := b.SeedArticle(t, "source", alice.ID, time.Hour)
+		twoTags := b.SeedArticle(t, "two-tags", bob.ID, time.Minute)
+		oneTag := b.SeedArticle(t, "one-tag", alice.ID, 0)
+		unrelated := b.SeedArticle(t, "unrelated", bob.ID, 0)
+
+		require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, source.ID, []string{"go", "testing", "web"}))
+		require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, twoTags.ID, []string{"go", "testing"}))
+		require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, oneTag.ID, []string{"web", "css"}))
+		require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, unrelated.ID, []string{"rust"}))
+
+		// Most shared tags first; the source article is left out
+		related, err := b.RelatedRepo.ListTagOverlaps(b.DB, source.ID, nil, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.RelatedArticle{{ArticleID: twoTags.ID, Shared: 2}, {ArticleID: oneTag.ID, Shared: 1}}, related)
+		related, err = b.RelatedRepo.ListTagOverlaps(b.DB, source.ID, nil, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.RelatedArticle{{ArticleID: twoTags.ID, Shared: 2}}, related)
+		related, err = b.RelatedRepo.ListTagOverlaps(b.DB, source.ID, &bob.ID, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.RelatedArticle{{ArticleID: oneTag.ID, Shared: 1}}, related)
+
+		// Bob and carol favorited the source; both favorited unrelated, only carol one-tag
+		for _, fav := range []struct{ userID, articleID int64 }{
+			{bob.ID, source.ID}, {carol.ID, source.ID}, {bob.ID, unrelated.ID}, {carol.ID, unrelated.ID}, {carol.ID, oneTag.ID},
+		} {
+			require.NoError(t, b.FavoriteRepo.AddFavorite(b.DB, fav.userID, fav.articleID))
+		}
+		related, err = b.RelatedRepo.ListCoFavorites(b.DB, source.ID, nil, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.RelatedArticle{{ArticleID: unrelated.ID, Shared: 2}, {ArticleID: oneTag.ID, Shared: 1}}, related)
+		related, err = b.RelatedRepo.ListCoFavorites(b.DB, source.ID, &alice.ID, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.RelatedArticle{{ArticleID: unrelated.ID, Shared: 2}}, related)
+
+		// The limit also caps the users scanned: only carol, who favorited the source last
+		related, err = b.RelatedRepo.ListCoFavorites(b.DB, source.ID, nil, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.RelatedArticle{{ArticleID: unrelated.ID, Shared: 1}}, related)
+	})
+}
+
+func TestArticleRepository_StatusesAndScheduling(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		bob := b.SeedUser(t, "bob")
+		require.NoError(t, b.FollowRepo.CreateFollow(b.DB, &models.Follow{FollowerID: bob.ID, FolloweeID: alice.ID}))
+		published := b.SeedArticle(t, "published", alice.ID, time.Hour)
+		draft := b.SeedArticle(t, "draft", alice.ID, time.Minute)
+		scheduled := b.SeedArticle(t, "scheduled", alice.ID, time.Minute)
+		due := b.SeedArticle(t, "due", alice.ID, time.Minute)
+		assert.Equal(t, models.ArticleStatusPublished, published.Status)
+		for _, article := range []*models.Article{published, draft, scheduled, due} {
+			require.NoError(t, b.ArticleRepo.AssignTagsToArticle(b.DB, article.ID, []string{"go"}))
+		}
+
+		future, past := time.Now().Add(time.Hour), time.Now().Add(-time.Second)
+		draft.Status = models.ArticleStatusDraft
+		require.NoError(t, b.ArticleRepo.UpdateArticle(b.DB, draft))
+		scheduled.Status, scheduled.PublishedAt = models.ArticleStatusScheduled, &future
+		require.NoError(t, b.ArticleRepo.UpdateArticle(b.DB, scheduled))
+		due.Status, due.PublishedAt = models.ArticleStatusScheduled, &past
+		require.NoError(t, b.ArticleRepo.UpdateArticle(b.DB, due))
+
+		// Listings show published articles unless other statuses are asked for
+		articles, total, err := b.ArticleRepo.ListArticles(b.DB, repository.ArticleFilter{}, nil, repository.ArticlePage{Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []string{"published"}, Slugs(articles))
+		filter := repository.ArticleFilter{AuthorID: alice.ID, Statuses: []string{models.ArticleStatusDraft, models.ArticleStatusScheduled}}
+		articles, total, err = b.ArticleRepo.ListArticles(b.DB, filter, nil, repository.ArticlePage{Limit: 20, Sort: repository.ArticleSortRecentlyUpdated})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Equal(t, []string{"due", "scheduled", "draft"}, Slugs(articles))
+		filter.AuthorID = bob.ID
+		_, total, err = b.ArticleRepo.ListArticles(b.DB, filter, nil, repository.ArticlePage{Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), total)
+
+		// Tag counts leave out unpublished articles
+		counts, err := b.TagRepo.ListTagCounts(b.DB, repository.TagSortName, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []repository.TagCount{{Name: "go", Articles: 1}}, counts)
+
+		// Only the overdue article is published, once, and takes its publication time
+		ids, err := b.ArticleRepo.ListDueScheduled(b.DB, time.Now(), 10)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{due.ID}, ids)
+		ok, err := b.ArticleRepo.PublishScheduled(b.DB, due.ID)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		ok, err = b.ArticleRepo.PublishScheduled(b.DB, due.ID)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		ids, err = b.ArticleRepo.ListDueScheduled(b.DB, time.Now(), 10)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+
+		stored, err := b.ArticleRepo.FindArticleBySlug(b.DB, "due")
+		require.NoError(t, err)
+		assert.Equal(t, models.ArticleStatusPublished, stored.Status)
+		assert.WithinDuration(t, past, stored.CreatedAt, time.Second)
+		articles, _, err = b.ArticleRepo.ListArticles(b.DB, repository.ArticleFilter{}, nil, repository.ArticlePage{Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"due", "published"}, Slugs(articles))
+
+		// Backfilled timelines skip unpublished articles; removing an article takes it out
+		require.NoError(t, b.TimelineRepo.BackfillTimeline(b.DB, bob.ID, alice.ID))
+		entries, _, err := b.TimelineRepo.ListTimeline(b.DB, bob.ID, repository.FeedFilter{}, repository.ArticlePage{Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{due.ID, published.ID}, ArticleIDs(entries))
+		require.NoError(t, b.TimelineRepo.RemoveArticle(b.DB, due.ID))
+		entries, _, err = b.TimelineRepo.ListTimeline(b.DB, bob.ID, repository.FeedFilter{}, repository.ArticlePage{Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{published.ID}, ArticleIDs(entries))
+	})
+}
+
+func TestTransactions_RollBack(t *testing.T) {
+	ForEachBackend(t, func(t *testing.T, b *Backend) {
+		alice := b.SeedUser(t, "alice")
+		article := b.SeedArticle(t, "post", alice.ID, 0)
+
+		// A failed transaction undoes the writes it made before failing
+		err := b.DB.Transaction(func(tx *gorm.DB) error {
+			article.Title = "Half applied"
+			require.NoError(t, b.ArticleRepo.UpdateArticle(tx, article))
+			require.NoError(t, b.ArticleRepo.AssignTagsToArticle(tx, article.ID, []string{"go"}))
+			return gorm.ErrInvalidData
+		})
+		assert.ErrorIs(t, err, gorm.ErrInvalidData)
+		found, err := b.ArticleRepo.FindArticleBySlug(b.DB, "post")
+		require.NoError(t, err)
+		assert.Equal(t, "post", found.Title)
+		assert.Empty(t, found.ArticleTags)
+
+		// A nested transaction that fails undoes only its own writes
+		require.NoError(t, b.DB.Transaction(func(tx *gorm.DB) error {
+			require.NoError(t, b.ArticleRepo.AssignTagsToArticle(tx, article.ID, []string{"go"}))
+			assert.Error(t, tx.Transaction(func(nested *gorm.DB) error {
+				require.NoError(t, b.ArticleRepo.AssignTagsToArticle(nested, article.ID, []string{"rust"}))
+				return gorm.ErrInvalidData
+			}))
+			return nil
+		}))
+		found, err = b.ArticleRepo.FindArticleBySlug(b.DB, "post")
+		require.NoError(t, err)
+		require.Len(t, found.ArticleTags, 1)
+		assert.Equal(t, "go", found.ArticleTags[0].Tag.Name)
+	})
+}
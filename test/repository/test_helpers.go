@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-gin-realworld-api/internal/migrations"
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+	"go-gin-realworld-api/internal/repository/memory"
+	"go-gin-realworld-api/internal/repository/sqlrepo"
+
+	sqliteDriver "gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Backend bundles a database handle with the repositories of one implementation
+type Backend struct {
+	DB           *gorm.DB
+	UserRepo     repository.UserRepository
+	ProfileRepo  repository.ProfileRepository
+	FollowRepo   repository.FollowRepository
+	ArticleRepo  repository.ArticleRepository
+	CommentRepo  repository.CommentRepository
+	FavoriteRepo repository.FavoriteRepository
+	TagRepo      repository.TagRepository
+	TimelineRepo repository.TimelineRepository
+	TrendingRepo repository.TrendingRepository
+	RelatedRepo  repository.RelatedRepository
+}
+
+// CreateSQLiteDB creates a migrated SQLite database in a temporary directory
+func CreateSQLiteDB(t *testing.T) *gorm.DB {
+	dsn := "file:" + filepath.Join(t.TempDir(), "test.db") + "?_foreign_keys=on"
+	db, err := gorm.Open(sqliteDriver.Open(dsn), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to open sqlite db: %v", err)
+	}
+
+	migrator, err := migrations.New(db, "sqlite")
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background(), 0); err != nil {
+		t.Fatalf("failed to migrate sqlite db: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get sqlite instance: %v", err)
+	}
+	// Like config.ConnectToSQLite, serialize access through one connection
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	return db
+}
+
+// NewSQLiteBackend creates the SQL repositories over a fresh SQLite database
+func NewSQLiteBackend(t *testing.T) *Backend {
+	return &Backend{
+		DB:           CreateSQLiteDB(t),
+		UserRepo:     sqlrepo.NewSqlUserRepository(),
+		ProfileRepo:  sqlrepo.NewSqlProfileRepository(),
+		FollowRepo:   sqlrepo.NewSqlFollowRepository(),
+		ArticleRepo:  sqlrepo.NewSqlArticleRepository(sqlrepo.SqliteFullText{}),
+		CommentRepo:  sqlrepo.NewSqlCommentRepository(),
+		FavoriteRepo: sqlrepo.NewSqlFavoriteRepository(),
+		TagRepo:      sqlrepo.NewSqlTagRepository(),
+		TimelineRepo: sqlrepo.NewSqlTimelineRepository(),
+		TrendingRepo: sqlrepo.NewSqlTrendingRepository(),
+		RelatedRepo:  sqlrepo.NewSqlRelatedRepository(),
+	}
+}
+
+// NewMemoryBackend creates the in-memory repositories over a fresh store
+func NewMemoryBackend(t *testing.T) *Backend {
+	db, err := gorm.Open(memory.Open(), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to open memory db: %v", err)
+	}
+
+	store := memory.StoreOf(db)
+	return &Backend{
+		DB:           db,
+		UserRepo:     memory.NewMemoryUserRepository(store),
+		ProfileRepo:  memory.NewMemoryProfileRepository(store),
+		FollowRepo:   memory.NewMemoryFollowRepository(store),
+		ArticleRepo:  memory.NewMemoryArticleRepository(store),
+		CommentRepo:  memory.NewMemoryCommentRepository(store),
+		FavoriteRepo: memory.NewMemoryFavoriteRepository(store),
+		TagRepo:      memory.NewMemoryTagRepository(store),
+		TimelineRepo: memory.NewMemoryTimelineRepository(store),
+		TrendingRepo: memory.NewMemoryTrendingRepository(store),
+		RelatedRepo:  memory.NewMemoryRelatedRepository(store),
+	}
+}
+
+// ForEachBackend runs the test against every repository implementation that needs no server
+func ForEachBackend(t *testing.T, fn func(t *testing.T, b *Backend)) {
+	backends := map[string]func(t *testing.T) *Backend{
+		"sqlite": NewSQLiteBackend,
+		"memory": NewMemoryBackend,
+	}
+	for _, name := range []string{"sqlite", "memory"} {
+		t.Run(name, func(t *testing.T) {
+			fn(t, backends[name](t))
+		})
+	}
+}
+
+// SeedUser creates a user with the given username
+func (b *Backend) SeedUser(t *testing.T, username string) *models.User {
+	user := &models.User{Username: username, Email: username + "@example.com", Password: "hashed"}
+	if err := b.UserRepo.CreateUser(b.DB, user); err != nil {
+		t.Fatalf("failed to seed user: %v", err)
+	}
+	return user
+}
+
+// SeedArticle creates an article authored by the given user, created age ago
+func (b *Backend) SeedArticle(t *testing.T, slug string, authorID int64, age time.Duration) *models.Article {
+	createdAt := time.Now().Add(-age)
+	article := &models.Article{
+		Slug:        slug,
+		Title:       slug,
+		Description: "desc",
+		Body:        "body",
+		AuthorID:    authorID,
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+	}
+	if err := b.ArticleRepo.CreateArticle(b.DB, article); err != nil {
+		t.Fatalf("failed to seed article: %v", err)
+	}
+	return article
+}
+
+// Slugs returns the slugs of the articles in order
+func Slugs(articles []*models.Article) []string {
+	slugs := make([]string, 0, len(articles))
+	for _, article := range articles {
+		slugs = append(slugs, article.Slug)
+	}
+	return slugs
+}
@@ -0,0 +1,115 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-gin-realworld-api/internal/search"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hitIDs(hits []search.Hit) []int64 {
+	ids := make([]int64, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids
+}
+
+func seed(t *testing.T, idx search.SearchIndex) {
+	for _, doc := range []search.Document{
+		{ID: 1, Title: "Weekly notes", Description: "Misc", Body: "Some thoughts on connecting services", Author: "alice", Tags: []string{"notes"}},
+		{ID: 2, Title: "Connection pooling", Description: "Databases", Body: "How pools keep connections open", Author: "bob", Tags: []string{"db"}},
+		{ID: 3, Title: "Cooking", Description: "Pasta", Body: "Boil water", Author: "alice"},
+	} {
+		require.NoError(t, idx.Index(doc))
+	}
+}
+
+func TestInvertedIndex_Search(t *testing.T) {
+	idx := search.NewInvertedIndex()
+	seed(t, idx)
+
+	// Stemming matches "connected" to "connecting" and "connections"; title matches rank first
+	hits, total, err := idx.Search(search.Query{Text: "connected"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []int64{2, 1}, hitIDs(hits))
+
+	// Filters
+	hits, _, err = idx.Search(search.Query{Text: "connected", Author: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, hitIDs(hits))
+	hits, _, err = idx.Search(search.Query{Text: "connected", Tag: "db"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, hitIDs(hits))
+
+	// Pagination keeps the total
+	hits, total, err = idx.Search(search.Query{Text: "connected", Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []int64{1}, hitIDs(hits))
+
+	// Stopwords alone match nothing
+	hits, total, err = idx.Search(search.Query{Text: "the of"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Empty(t, hits)
+}
+
+func TestInvertedIndex_UpdateAndDelete(t *testing.T) {
+	idx := search.NewInvertedIndex()
+	seed(t, idx)
+
+	require.NoError(t, idx.Index(search.Document{ID: 3, Title: "Cooking pasta", Body: "Rarely about connections"}))
+	hits, _, err := idx.Search(search.Query{Text: "water"})
+	require.NoError(t, err)
+	assert.Empty(t, hits, "the old version must be gone")
+
+	require.NoError(t, idx.Delete(2))
+	require.NoError(t, idx.Delete(42))
+	hits, _, err = idx.Search(search.Query{Text: "connection"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{1, 3}, hitIDs(hits))
+	assert.Equal(t, 2, idx.Len())
+}
+
+func TestInvertedIndex_Persistence(t *testing.T) {
+	dir := t.TempDir()
+
+	idx, err := search.OpenInvertedIndex(dir)
+	require.NoError(t, err)
+	seed(t, idx)
+	require.NoError(t, idx.Delete(3))
+	require.NoError(t, idx.Close())
+	assert.ErrorIs(t, idx.Index(search.Document{ID: 4}), search.ErrIndexClosed)
+
+	// A write interrupted by a crash leaves a partial line behind
+	journal, err := os.OpenFile(filepath.Join(dir, "journal.jsonl"), os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = journal.WriteString(`{"op":"index","doc":{"id":9`)
+	require.NoError(t, err)
+	require.NoError(t, journal.Close())
+
+	reopened, err := search.OpenInvertedIndex(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reopened.Len())
+	hits, _, err := reopened.Search(search.Query{Text: "connection"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 1}, hitIDs(hits))
+
+	// Reset replaces everything and survives a reopen
+	require.NoError(t, reopened.Reset([]search.Document{{ID: 7, Title: "Fresh start"}}))
+	require.NoError(t, reopened.Close())
+
+	reopened, err = search.OpenInvertedIndex(dir)
+	require.NoError(t, err)
+	defer reopened.Close()
+	assert.Equal(t, 1, reopened.Len())
+	hits, _, err = reopened.Search(search.Query{Text: "fresh"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{7}, hitIDs(hits))
+}
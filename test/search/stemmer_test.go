@@ -0,0 +1,48 @@
+package search
+
+import (
+	"testing"
+
+	"go-gin-realworld-api/internal/search"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStem(t *testing.T) {
+	// Examples from Porter's paper
+	tests := map[string]string{
+		"caresses":       "caress",
+		"ponies":         "poni",
+		"cats":           "cat",
+		"feed":           "feed",
+		"agreed":         "agre",
+		"plastered":      "plaster",
+		"motoring":       "motor",
+		"sing":           "sing",
+		"conflated":      "conflat",
+		"hopping":        "hop",
+		"falling":        "fall",
+		"filing":         "file",
+		"happy":          "happi",
+		"relational":     "relat",
+		"conditional":    "condit",
+		"generalization": "gener",
+		"electrical":     "electr",
+		"adjustable":     "adjust",
+		"controlling":    "control",
+		"connections":    "connect",
+		"connecting":     "connect",
+	}
+	for word, stem := range tests {
+		assert.Equal(t, stem, search.Stem(word), word)
+	}
+
+	// Short and non-ASCII words are left alone
+	assert.Equal(t, "go", search.Stem("go"))
+	assert.Equal(t, "việt", search.Stem("việt"))
+}
+
+func TestAnalyze(t *testing.T) {
+	assert.Equal(t, []string{"connect", "databas", "quickli"}, search.Analyze("Connecting to the Databases, quickly!"))
+	assert.Empty(t, search.Analyze("the and of"))
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-gin-realworld-api/internal/dtos"
+	appErrors "go-gin-realworld-api/internal/errors"
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository/memory"
+	"go-gin-realworld-api/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestArticleService_DraftsAndScheduling(t *testing.T) {
+	ctx := context.Background()
+	db := CreateMemoryDB(t)
+	store := memory.StoreOf(db)
+	articleRepo := memory.NewMemoryArticleRepository(store)
+	userRepo := memory.NewMemoryUserRepository(store)
+	profileRepo := memory.NewMemoryProfileRepository(store)
+	followRepo := memory.NewMemoryFollowRepository(store)
+	timelineRepo := memory.NewMemoryTimelineRepository(store)
+	userService := services.NewUserService(db, userRepo, profileRepo, followRepo)
+	profileService := services.NewProfileService(db, userRepo, profileRepo, followRepo, timelineRepo)
+	articleService := services.NewArticleService(db, db, articleRepo, memory.NewMemoryFavoriteRepository(store), followRepo, timelineRepo, nil)
+
+	author, err := userService.RegisterUser(ctx, "author", "author@example.com", "password")
+	require.NoError(t, err)
+	reader, err := userService.RegisterUser(ctx, "reader", "reader@example.com", "password")
+	require.NoError(t, err)
+	_, err = profileService.FollowUser(ctx, reader.ID, "author")
+	require.NoError(t, err)
+
+	create := func(title, status string, publishedAt *time.Time) (*dtos.ArticleDetailResponse, error) {
+		req := &dtos.CreateArticleRequest{}
+		req.Article.Title = title
+		req.Article.Description = "Description"
+		req.Article.Body = "Body"
+		req.Article.Status = status
+		req.Article.PublishedAt = publishedAt
+		return articleService.CreateArticle(ctx, req, author.ID)
+	}
+	visible := func() ([]string, []string) {
+		listed, err := articleService.ListArticles(ctx, &dtos.ListArticlesQuery{Limit: 20}, nil)
+		require.NoError(t, err)
+		feed, err := articleService.GetFeedArticles(ctx, reader.ID, &dtos.FeedArticlesQuery{Limit: 20})
+		require.NoError(t, err)
+		return articleSlugs(listed), articleSlugs(feed)
+	}
+
+	future := time.Now().Add(time.Hour)
+	resp, err := create("Published", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.ArticleStatusPublished, resp.Article.Status)
+	assert.NotEmpty(t, resp.Article.PublishedAt)
+	resp, err = create("Draft", models.ArticleStatusDraft, nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.ArticleStatusDraft, resp.Article.Status)
+	assert.Empty(t, resp.Article.PublishedAt)
+	_, err = create("Scheduled", models.ArticleStatusScheduled, &future)
+	require.NoError(t, err)
+
+	// A scheduled article needs a publication time in the future, and only it takes one
+	_, err = create("Too Late", models.ArticleStatusScheduled, nil)
+	assert.ErrorIs(t, err, appErrors.ErrInvalidPublishedAt)
+	past := time.Now().Add(-time.Minute)
+	_, err = create("Too Late", models.ArticleStatusScheduled, &past)
+	assert.ErrorIs(t, err, appErrors.ErrInvalidPublishedAt)
+	_, err = create("Too Late", models.ArticleStatusDraft, &future)
+	assert.ErrorIs(t, err, appErrors.ErrInvalidPublishedAt)
+
+	// Unpublished articles are only seen by their author
+	listed, feed := visible()
+	assert.Equal(t, []string{"published"}, listed)
+	assert.Equal(t, []string{"published"}, feed)
+	_, err = articleService.GetArticleBySlug(ctx, "draft", &reader.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = articleService.GetArticleBySlug(ctx, "draft", &author.ID)
+	assert.NoError(t, err)
+
+	drafts, err := articleService.ListDrafts(ctx, author.ID, &dtos.ListDraftsQuery{Limit: 20})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"scheduled", "draft"}, articleSlugs(drafts))
+	drafts, err = articleService.ListDrafts(ctx, author.ID, &dtos.ListDraftsQuery{Status: models.ArticleStatusScheduled, Limit: 20})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"scheduled"}, articleSlugs(drafts))
+	drafts, err = articleService.ListDrafts(ctx, reader.ID, &dtos.ListDraftsQuery{Limit: 20})
+	require.NoError(t, err)
+	assert.Empty(t, drafts.Articles)
+
+	// Publishing a draft lists it as new and copies it to the followers' feeds
+	req := &dtos.UpdateArticleRequest{}
+	req.Article.Status = models.ArticleStatusPublished
+	resp, err = articleService.UpdateArticle(ctx, "draft", req, author.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.ArticleStatusPublished, resp.Article.Status)
+	listed, feed = visible()
+	assert.Equal(t, []string{"draft", "published"}, listed)
+	assert.Equal(t, []string{"draft", "published"}, feed)
+
+	// Nothing is due until the publication time has passed
+	published, err := articleService.PublishDueArticles(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, published)
+	scheduled, err := articleRepo.FindArticleBySlug(db, "scheduled")
+	require.NoError(t, err)
+	scheduled.PublishedAt = &past
+	require.NoError(t, articleRepo.UpdateArticle(db, scheduled))
+
+	published, err = articleService.PublishDueArticles(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	published, err = articleService.PublishDueArticles(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, published)
+	listed, feed = visible()
+	// It is listed as of its publication time, a minute ago
+	assert.Equal(t, []string{"draft", "published", "scheduled"}, listed)
+	assert.Equal(t, []string{"draft", "published", "scheduled"}, feed)
+
+	// Archiving takes an article out of listings and feeds
+	req = &dtos.UpdateArticleRequest{}
+	req.Article.Status = models.ArticleStatusArchived
+	_, err = articleService.UpdateArticle(ctx, "published", req, author.ID, nil)
+	require.NoError(t, err)
+	listed, feed = visible()
+	assert.Equal(t, []string{"draft", "scheduled"}, listed)
+	assert.Equal(t, []string{"draft", "scheduled"}, feed)
+}
+
+func TestArticleService_OnlyAuthorChangesArticles(t *testing.T) {
+	ctx := context.Background()
+	db := CreateMemoryDB(t)
+	store := memory.StoreOf(db)
+	articleRepo := memory.NewMemoryArticleRepository(store)
+	favoriteRepo := memory.NewMemoryFavoriteRepository(store)
+	followRepo := memory.NewMemoryFollowRepository(store)
+	userService := services.NewUserService(db, memory.NewMemoryUserRepository(store), memory.NewMemoryProfileRepository(store), followRepo)
+	articleService := services.NewArticleService(db, db, articleRepo, favoriteRepo, followRepo, memory.NewMemoryTimelineRepository(store), nil)
+	commentService := services.NewCommentService(db, db, memory.NewMemoryCommentRepository(store), articleRepo)
+	favoriteService := services.NewFavoriteService(db, favoriteRepo, articleRepo)
+	tagService := services.NewTagService(db, db, memory.NewMemoryTagRepository(store))
+
+	author, err := userService.RegisterUser(ctx, "author", "author@example.com", "password")
+	require.NoError(t, err)
+	other, err := userService.RegisterUser(ctx, "other", "other@example.com", "password")
+	require.NoError(t, err)
+	for _, article := range []struct{ title, status, tag string }{
+		{"Secret Plans", models.ArticleStatusDraft, "secret"},
+		{"Public Notes", models.ArticleStatusPublished, "public"},
+	} {
+		req := &dtos.CreateArticleRequest{}
+		req.Article.Title = article.title
+		req.Article.Description = "Description"
+		req.Article.Body = "Body"
+		req.Article.TagList = []string{article.tag}
+		req.Article.Status = article.status
+		_, err := articleService.CreateArticle(ctx, req, author.ID)
+		require.NoError(t, err)
+	}
+
+	// Another user cannot tell that the draft exists, let alone edit or publish it
+	update := &dtos.UpdateArticleRequest{}
+	update.Article.Body = "Leaked"
+	_, err = articleService.UpdateArticle(ctx, "secret-plans", update, other.ID, nil)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	publish := &dtos.PatchArticleRequest{Article: &dtos.ArticlePatch{Status: dtos.OptionalOf(models.ArticleStatusPublished)}}
+	_, err = articleService.PatchArticle(ctx, "secret-plans", publish, other.ID, nil)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.ErrorIs(t, articleService.DeleteArticle(ctx, "secret-plans", other.ID), gorm.ErrRecordNotFound)
+	_, err = favoriteService.UnfavoriteArticle(ctx, "secret-plans", other.ID)
+	assert.ErrorIs(t, err, appErrors.ErrNotFound)
+	_, err = commentService.GetCommentsByArticleSlug(ctx, "secret-plans", &other.ID)
+	assert.ErrorIs(t, err, appErrors.ErrNotFound)
+	_, err = commentService.GetCommentsByArticleSlug(ctx, "secret-plans", nil)
+	assert.ErrorIs(t, err, appErrors.ErrNotFound)
+	_, err = commentService.GetCommentsByArticleSlug(ctx, "secret-plans", &author.ID)
+	assert.NoError(t, err)
+	tags, err := tagService.GetAllTags(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"public"}, tags)
+
+	draft, err := articleService.GetArticleBySlug(ctx, "secret-plans", &author.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Body", draft.Article.Body)
+	assert.Equal(t, models.ArticleStatusDraft, draft.Article.Status)
+
+	// A published article is visible but still only its author's to change
+	_, err = articleService.UpdateArticle(ctx, "public-notes", update, other.ID, nil)
+	assert.ErrorIs(t, err, appErrors.ErrForbidden)
+	assert.ErrorIs(t, articleService.DeleteArticle(ctx, "public-notes", other.ID), appErrors.ErrForbidden)
+	_, err = articleService.UpdateArticle(ctx, "public-notes", update, author.ID, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, articleService.DeleteArticle(ctx, "public-notes", author.ID))
+}
+
+func TestArticleService_PatchNullPublishedAt(t *testing.T) {
+	ctx := context.Background()
+	db := CreateMemoryDB(t)
+	store := memory.StoreOf(db)
+	followRepo := memory.NewMemoryFollowRepository(store)
+	userService := services.NewUserService(db, memory.NewMemoryUserRepository(store), memory.NewMemoryProfileRepository(store), followRepo)
+	articleService := services.NewArticleService(db, db, memory.NewMemoryArticleRepository(store), memory.NewMemoryFavoriteRepository(store), followRepo, memory.NewMemoryTimelineRepository(store), nil)
+
+	author, err := userService.RegisterUser(ctx, "author", "author@example.com", "password")
+	require.NoError(t, err)
+	future := time.Now().Add(time.Hour)
+	for _, article := range []struct {
+		title, status string
+		publishedAt   *time.Time
+	}{
+		{"Scheduled", models.ArticleStatusScheduled, &future},
+		{"Published", models.ArticleStatusPublished, nil},
+	} {
+		req := &dtos.CreateArticleRequest{}
+		req.Article.Title = article.title
+		req.Article.Description = "Description"
+		req.Article.Body = "Body"
+		req.Article.Status = article.status
+		req.Article.PublishedAt = article.publishedAt
+		_, err := articleService.CreateArticle(ctx, req, author.ID)
+		require.NoError(t, err)
+	}
+	unschedule := func() *dtos.PatchArticleRequest {
+		return &dtos.PatchArticleRequest{Article: &dtos.ArticlePatch{PublishedAt: dtos.Optional[time.Time]{Set: true, Null: true}}}
+	}
+
+	// Removing the publication time of a scheduled article makes it a draft again
+	resp, err := articleService.PatchArticle(ctx, "scheduled", unschedule(), author.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.ArticleStatusDraft, resp.Article.Status)
+	assert.Empty(t, resp.Article.PublishedAt)
+
+	// It cannot stay scheduled without one
+	patch := unschedule()
+	patch.Article.Status = dtos.OptionalOf(models.ArticleStatusScheduled)
+	_, err = articleService.PatchArticle(ctx, "scheduled", patch, author.ID, nil)
+	assert.ErrorIs(t, err, appErrors.ErrInvalidPublishedAt)
+
+	// A published article keeps its publication time
+	resp, err = articleService.PatchArticle(ctx, "published", unschedule(), author.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.ArticleStatusPublished, resp.Article.Status)
+	assert.NotEmpty(t, resp.Article.PublishedAt)
+}
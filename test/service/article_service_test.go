@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"go-gin-realworld-api/internal/dtos"
 	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
 	"go-gin-realworld-api/internal/services"
 	"go-gin-realworld-api/test/mocks"
 
@@ -15,17 +17,20 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-func setupArticleServiceTest(t *testing.T) (context.Context, *services.ArticleService, *mocks.MockArticleRepository, sqlmock.Sqlmock) {
+func setupArticleServiceTest(t *testing.T) (context.Context, *services.ArticleService, *mocks.MockArticleRepository, *mocks.MockFavoriteRepository, *mocks.MockFollowRepository, *mocks.MockTimelineRepository, sqlmock.Sqlmock) {
 	mockArticleRepo := new(mocks.MockArticleRepository)
+	mockFavoriteRepo := new(mocks.MockFavoriteRepository)
+	mockFollowRepo := new(mocks.MockFollowRepository)
+	mockTimelineRepo := new(mocks.MockTimelineRepository)
 	gormDB, sqlMock := CreateMockDB(t)
-	articleService := services.NewArticleService(gormDB, mockArticleRepo)
+	articleService := services.NewArticleService(gormDB, gormDB, mockArticleRepo, mockFavoriteRepo, mockFollowRepo, mockTimelineRepo, nil)
 	ctxForTest := context.Background()
 
-	return ctxForTest, articleService, mockArticleRepo, sqlMock
+	return ctxForTest, articleService, mockArticleRepo, mockFavoriteRepo, mockFollowRepo, mockTimelineRepo, sqlMock
 }
 
 func TestArticleService_ListArticles_Success(t *testing.T) {
-	ctxForTest, articleService, mockArticleRepo, _ := setupArticleServiceTest(t)
+	ctxForTest, articleService, mockArticleRepo, mockFavoriteRepo, _, _, _ := setupArticleServiceTest(t)
 	query := &dtos.ListArticlesQuery{
 		Limit:  20,
 		Offset: 0,
@@ -46,19 +51,40 @@ func TestArticleService_ListArticles_Success(t *testing.T) {
 	}
 	total := int64(1)
 
-	mockArticleRepo.On("ListArticles", mock.Anything, "", "", (*bool)(nil), &currentUserID, 20, 0).Return(articles, total, nil)
+	mockArticleRepo.On("ListArticles", mock.Anything, repository.ArticleFilter{}, &currentUserID, repository.ArticlePage{Limit: 21}).Return(articles, total, nil)
+	mockFavoriteRepo.On("FavoritedArticleIDs", mock.Anything, currentUserID, []int64{1}).Return([]int64{1}, nil)
 
 	resp, err := articleService.ListArticles(ctxForTest, query, &currentUserID)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
-	assert.Equal(t, 1, resp.ArticlesCount)
+	assert.Equal(t, 1, *resp.ArticlesCount)
 	assert.Equal(t, "test-article", resp.Articles[0].Slug)
+	assert.True(t, resp.Articles[0].Favorited)
 	mockArticleRepo.AssertExpectations(t)
+	mockFavoriteRepo.AssertExpectations(t)
+}
+
+func TestArticleService_ListArticles_AnonymousSkipsFavorited(t *testing.T) {
+	ctxForTest, articleService, mockArticleRepo, mockFavoriteRepo, _, _, _ := setupArticleServiceTest(t)
+
+	articles := []*models.Article{
+		{ID: 1, Slug: "first", Author: &models.User{Username: "author1"}},
+		{ID: 2, Slug: "second", Author: &models.User{Username: "author1"}},
+	}
+	mockArticleRepo.On("ListArticles", mock.Anything, repository.ArticleFilter{}, (*int64)(nil), repository.ArticlePage{Limit: 21}).Return(articles, int64(2), nil)
+
+	resp, err := articleService.ListArticles(ctxForTest, &dtos.ListArticlesQuery{}, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Articles, 2)
+	assert.False(t, resp.Articles[0].Favorited)
+	assert.False(t, resp.Articles[1].Favorited)
+	mockFavoriteRepo.AssertNotCalled(t, "FavoritedArticleIDs", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestArticleService_GetArticleBySlug_Success(t *testing.T) {
-	ctxForTest, articleService, mockArticleRepo, _ := setupArticleServiceTest(t)
+	ctxForTest, articleService, mockArticleRepo, mockFavoriteRepo, _, _, _ := setupArticleServiceTest(t)
 	slug := "test-article"
 	currentUserID := int64(1)
 
@@ -68,23 +94,27 @@ func TestArticleService_GetArticleBySlug_Success(t *testing.T) {
 		Title:       "Test Article",
 		Description: "Description",
 		Body:        "Body",
+		Status:      models.ArticleStatusPublished,
 		Author: &models.User{
 			Username: "author1",
 		},
 	}
 
 	mockArticleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(article, nil)
+	mockFavoriteRepo.On("FavoritedArticleIDs", mock.Anything, currentUserID, []int64{1}).Return([]int64{}, nil)
 
 	resp, err := articleService.GetArticleBySlug(ctxForTest, slug, &currentUserID)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, slug, resp.Article.Slug)
+	assert.False(t, resp.Article.Favorited)
 	mockArticleRepo.AssertExpectations(t)
+	mockFavoriteRepo.AssertExpectations(t)
 }
 
 func TestArticleService_GetArticleBySlug_Error(t *testing.T) {
-	ctxForTest, articleService, mockArticleRepo, _ := setupArticleServiceTest(t)
+	ctxForTest, articleService, mockArticleRepo, _, _, _, _ := setupArticleServiceTest(t)
 	slug := "non-existent"
 	expectedError := errors.New("article not found")
 
@@ -99,43 +129,62 @@ func TestArticleService_GetArticleBySlug_Error(t *testing.T) {
 }
 
 func TestArticleService_GetFeedArticles_Success(t *testing.T) {
-	ctxForTest, articleService, mockArticleRepo, _ := setupArticleServiceTest(t)
+	ctxForTest, articleService, mockArticleRepo, mockFavoriteRepo, _, mockTimelineRepo, _ := setupArticleServiceTest(t)
 	userID := int64(1)
-	limit := 20
-	offset := 0
+	now := time.Now()
 
-	articles := []*models.Article{
-		{
-			ID:    1,
-			Slug:  "feed-article",
-			Title: "Feed Article",
-			Author: &models.User{
-				Username: "followed_user",
-			},
+	article := &models.Article{
+		ID:    1,
+		Slug:  "feed-article",
+		Title: "Feed Article",
+		Author: &models.User{
+			Username: "followed_user",
 		},
 	}
-	total := int64(1)
+	popular := &models.Article{
+		ID:    2,
+		Slug:  "popular-article",
+		Title: "Popular Article",
+		Author: &models.User{
+			Username: "popular_user",
+		},
+	}
+	page := repository.ArticlePage{Limit: 21}
 
-	mockArticleRepo.On("FeedArticles", mock.Anything, userID, limit, offset).Return(articles, total, nil)
+	// The timeline and the articles of popular authors are merged newest first
+	mockTimelineRepo.On("ListTimeline", mock.Anything, userID, repository.FeedFilter{}, page).
+		Return([]*models.TimelineEntry{{UserID: userID, ArticleID: 1, CreatedAt: now.Add(-time.Hour)}}, int64(1), nil)
+	mockTimelineRepo.On("ListFanOutOnRead", mock.Anything, userID, repository.FeedFilter{}, page).
+		Return([]*models.TimelineEntry{{UserID: userID, ArticleID: 2, CreatedAt: now}}, int64(1), nil)
+	mockArticleRepo.On("FindArticlesByIDs", mock.Anything, []int64{2, 1}).Return([]*models.Article{article, popular}, nil)
+	// One lookup covers the whole page
+	mockFavoriteRepo.On("FavoritedArticleIDs", mock.Anything, userID, []int64{2, 1}).Return([]int64{1}, nil).Once()
 
-	resp, err := articleService.GetFeedArticles(ctxForTest, userID, limit, offset)
+	resp, err := articleService.GetFeedArticles(ctxForTest, userID, &dtos.FeedArticlesQuery{Limit: 20})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
-	assert.Equal(t, 1, resp.ArticlesCount)
-	assert.Equal(t, "feed-article", resp.Articles[0].Slug)
+	assert.Equal(t, 2, *resp.ArticlesCount)
+	assert.Equal(t, "popular-article", resp.Articles[0].Slug)
+	assert.Equal(t, "feed-article", resp.Articles[1].Slug)
+	assert.False(t, resp.Articles[0].Favorited)
+	assert.True(t, resp.Articles[1].Favorited)
 	mockArticleRepo.AssertExpectations(t)
+	mockFavoriteRepo.AssertExpectations(t)
+	mockTimelineRepo.AssertExpectations(t)
 }
 
 func TestArticleService_CreateArticle_Success(t *testing.T) {
-	ctxForTest, articleService, mockArticleRepo, sqlMock := setupArticleServiceTest(t)
+	ctxForTest, articleService, mockArticleRepo, _, mockFollowRepo, mockTimelineRepo, sqlMock := setupArticleServiceTest(t)
 	authorID := int64(1)
 	req := &dtos.CreateArticleRequest{
 		Article: struct {
-			Title       string   `json:"title" binding:"required"`
-			Description string   `json:"description" binding:"required"`
-			Body        string   `json:"body" binding:"required"`
-			TagList     []string `json:"tagList"`
+			Title       string     `json:"title" binding:"required"`
+			Description string     `json:"description" binding:"required"`
+			Body        string     `json:"body" binding:"required"`
+			TagList     []string   `json:"tagList"`
+			Status      string     `json:"status" binding:"omitempty,oneof=draft published scheduled"`
+			PublishedAt *time.Time `json:"publishedAt"`
 		}{
 			Title:       "New Article",
 			Description: "Description",
@@ -145,6 +194,7 @@ func TestArticleService_CreateArticle_Success(t *testing.T) {
 	}
 
 	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
 	sqlMock.ExpectCommit()
 
 	mockArticleRepo.On("CreateArticle", mock.Anything, mock.MatchedBy(func(a *models.Article) bool {
@@ -155,6 +205,10 @@ func TestArticleService_CreateArticle_Success(t *testing.T) {
 	}).Return(nil)
 
 	mockArticleRepo.On("AssignTagsToArticle", mock.Anything, int64(1), req.Article.TagList).Return(nil)
+	mockFollowRepo.On("CountFollowers", mock.Anything, authorID).Return(int64(3), nil)
+	mockTimelineRepo.On("FanOutArticle", mock.Anything, mock.MatchedBy(func(a *models.Article) bool {
+		return a.ID == 1 && !a.FanOutOnRead
+	})).Return(nil)
 
 	createdArticle := &models.Article{
 		ID:          1,
@@ -175,18 +229,47 @@ func TestArticleService_CreateArticle_Success(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.Equal(t, "new-article", resp.Article.Slug)
 	mockArticleRepo.AssertExpectations(t)
+	mockTimelineRepo.AssertExpectations(t)
+}
+
+func TestArticleService_CreateArticle_FanOutOnRead(t *testing.T) {
+	ctxForTest, articleService, mockArticleRepo, _, mockFollowRepo, mockTimelineRepo, sqlMock := setupArticleServiceTest(t)
+	articleService.SetFanOutMaxFollowers(2)
+	authorID := int64(1)
+	req := &dtos.CreateArticleRequest{}
+	req.Article.Title = "Popular Article"
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectCommit()
+
+	// Too many followers: the article is marked and not copied to any timeline
+	mockFollowRepo.On("CountFollowers", mock.Anything, authorID).Return(int64(3), nil)
+	mockArticleRepo.On("CreateArticle", mock.Anything, mock.MatchedBy(func(a *models.Article) bool {
+		return a.FanOutOnRead
+	})).Return(nil)
+	mockArticleRepo.On("FindArticleBySlug", mock.Anything, "popular-article").
+		Return(&models.Article{ID: 1, Slug: "popular-article", Author: &models.User{Username: "author1"}}, nil)
+
+	_, err := articleService.CreateArticle(ctxForTest, req, authorID)
+
+	assert.NoError(t, err)
+	mockArticleRepo.AssertExpectations(t)
+	mockTimelineRepo.AssertNotCalled(t, "FanOutArticle", mock.Anything, mock.Anything)
 }
 
 func TestArticleService_UpdateArticle_Success(t *testing.T) {
-	ctxForTest, articleService, mockArticleRepo, sqlMock := setupArticleServiceTest(t)
+	ctxForTest, articleService, mockArticleRepo, mockFavoriteRepo, _, _, sqlMock := setupArticleServiceTest(t)
 	authorID := int64(1)
 	slug := "old-article"
 	req := &dtos.UpdateArticleRequest{
 		Article: struct {
-			Title       string   `json:"title"`
-			Description string   `json:"description"`
-			Body        string   `json:"body"`
-			TagList     []string `json:"tagList"`
+			Title       string     `json:"title"`
+			Description string     `json:"description"`
+			Body        string     `json:"body"`
+			TagList     []string   `json:"tagList"`
+			Status      string     `json:"status" binding:"omitempty,oneof=draft published scheduled archived"`
+			PublishedAt *time.Time `json:"publishedAt"`
 		}{
 			Title: "Updated Title",
 		},
@@ -200,6 +283,7 @@ func TestArticleService_UpdateArticle_Success(t *testing.T) {
 	}
 
 	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
 	sqlMock.ExpectCommit()
 
 	mockArticleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(existingArticle, nil)
@@ -217,49 +301,56 @@ func TestArticleService_UpdateArticle_Success(t *testing.T) {
 		},
 	}
 	mockArticleRepo.On("FindArticleBySlug", mock.Anything, "updated-title").Return(updatedArticle, nil)
+	mockFavoriteRepo.On("FavoritedArticleIDs", mock.Anything, authorID, []int64{1}).Return([]int64{1}, nil)
 
-	resp, err := articleService.UpdateArticle(ctxForTest, slug, req, authorID)
+	resp, err := articleService.UpdateArticle(ctxForTest, slug, req, authorID, nil)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, "updated-title", resp.Article.Slug)
+	assert.True(t, resp.Article.Favorited)
 	mockArticleRepo.AssertExpectations(t)
 }
 
 func TestArticleService_DeleteArticle_Success(t *testing.T) {
-	ctxForTest, articleService, mockArticleRepo, sqlMock := setupArticleServiceTest(t)
+	ctxForTest, articleService, mockArticleRepo, _, _, _, sqlMock := setupArticleServiceTest(t)
 	slug := "to-delete"
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectCommit()
 
-	mockArticleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(&models.Article{ID: 1, Slug: slug}, nil)
+	mockArticleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(&models.Article{ID: 1, Slug: slug, AuthorID: 1}, nil)
 	mockArticleRepo.On("DeleteArticleBySlug", mock.Anything, slug).Return(nil)
 
-	err := articleService.DeleteArticle(ctxForTest, slug)
+	err := articleService.DeleteArticle(ctxForTest, slug, 1)
 
 	assert.NoError(t, err)
 	mockArticleRepo.AssertExpectations(t)
 }
 
 func TestArticleService_CreateArticle_Error(t *testing.T) {
-	ctxForTest, articleService, mockArticleRepo, sqlMock := setupArticleServiceTest(t)
+	ctxForTest, articleService, mockArticleRepo, _, mockFollowRepo, _, sqlMock := setupArticleServiceTest(t)
 	authorID := int64(1)
 	req := &dtos.CreateArticleRequest{
 		Article: struct {
-			Title       string   `json:"title" binding:"required"`
-			Description string   `json:"description" binding:"required"`
-			Body        string   `json:"body" binding:"required"`
-			TagList     []string `json:"tagList"`
+			Title       string     `json:"title" binding:"required"`
+			Description string     `json:"description" binding:"required"`
+			Body        string     `json:"body" binding:"required"`
+			TagList     []string   `json:"tagList"`
+			Status      string     `json:"status" binding:"omitempty,oneof=draft published scheduled"`
+			PublishedAt *time.Time `json:"publishedAt"`
 		}{
 			Title: "New Article",
 		},
 	}
 
 	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec("ROLLBACK TO SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
 	sqlMock.ExpectRollback()
 
 	expectedError := errors.New("db error")
+	mockFollowRepo.On("CountFollowers", mock.Anything, authorID).Return(int64(0), nil)
 	mockArticleRepo.On("CreateArticle", mock.Anything, mock.Anything).Return(expectedError)
 
 	resp, err := articleService.CreateArticle(ctxForTest, req, authorID)
@@ -271,7 +362,7 @@ func TestArticleService_CreateArticle_Error(t *testing.T) {
 }
 
 func TestArticleService_UpdateArticle_NotFound(t *testing.T) {
-	ctxForTest, articleService, mockArticleRepo, sqlMock := setupArticleServiceTest(t)
+	ctxForTest, articleService, mockArticleRepo, _, _, _, sqlMock := setupArticleServiceTest(t)
 	authorID := int64(1)
 	slug := "non-existent"
 	req := &dtos.UpdateArticleRequest{}
@@ -282,7 +373,7 @@ func TestArticleService_UpdateArticle_NotFound(t *testing.T) {
 	expectedError := errors.New("article not found")
 	mockArticleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(nil, expectedError)
 
-	resp, err := articleService.UpdateArticle(ctxForTest, slug, req, authorID)
+	resp, err := articleService.UpdateArticle(ctxForTest, slug, req, authorID, nil)
 
 	assert.Error(t, err)
 	assert.Nil(t, resp)
@@ -291,7 +382,7 @@ func TestArticleService_UpdateArticle_NotFound(t *testing.T) {
 }
 
 func TestArticleService_DeleteArticle_NotFound(t *testing.T) {
-	ctxForTest, articleService, mockArticleRepo, sqlMock := setupArticleServiceTest(t)
+	ctxForTest, articleService, mockArticleRepo, _, _, _, sqlMock := setupArticleServiceTest(t)
 	slug := "non-existent"
 
 	sqlMock.ExpectBegin()
@@ -300,7 +391,7 @@ func TestArticleService_DeleteArticle_NotFound(t *testing.T) {
 	expectedError := errors.New("article not found")
 	mockArticleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(nil, expectedError)
 
-	err := articleService.DeleteArticle(ctxForTest, slug)
+	err := articleService.DeleteArticle(ctxForTest, slug, 1)
 
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
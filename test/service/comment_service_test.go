@@ -20,7 +20,7 @@ func setupCommentServiceTest(t *testing.T) (context.Context, *services.CommentSe
 	mockCommentRepo := new(mocks.MockCommentRepository)
 	mockArticleRepo := new(mocks.MockArticleRepository)
 	gormDB, sqlMock := CreateMockDB(t)
-	commentService := services.NewCommentService(gormDB, mockCommentRepo, mockArticleRepo)
+	commentService := services.NewCommentService(gormDB, gormDB, mockCommentRepo, mockArticleRepo)
 	ctxForTest := context.Background()
 
 	return ctxForTest, commentService, mockCommentRepo, mockArticleRepo, sqlMock
@@ -39,8 +39,9 @@ func TestCommentService_CreateComment_Success(t *testing.T) {
 	}
 
 	article := &models.Article{
-		ID:   10,
-		Slug: slug,
+		ID:     10,
+		Slug:   slug,
+		Status: models.ArticleStatusPublished,
 	}
 
 	sqlMock.ExpectBegin()
@@ -101,8 +102,9 @@ func TestCommentService_GetCommentsByArticleSlug_Success(t *testing.T) {
 	articleID := int64(10)
 
 	article := &models.Article{
-		ID:   articleID,
-		Slug: slug,
+		ID:     articleID,
+		Slug:   slug,
+		Status: models.ArticleStatusPublished,
 	}
 
 	comments := []*models.Comment{
@@ -125,7 +127,7 @@ func TestCommentService_GetCommentsByArticleSlug_Success(t *testing.T) {
 	mockArticleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(article, nil)
 	mockCommentRepo.On("GetCommentsByArticleID", mock.Anything, articleID).Return(comments, nil)
 
-	resp, err := commentService.GetCommentsByArticleSlug(ctxForTest, slug)
+	resp, err := commentService.GetCommentsByArticleSlug(ctxForTest, slug, nil)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
@@ -34,18 +34,18 @@ func TestFavoriteService_FavoriteArticle_Success_New(t *testing.T) {
 	article := &models.Article{
 		ID:             articleID,
 		Slug:           slug,
+		Status:         models.ArticleStatusPublished,
 		FavoritesCount: 0,
 	}
 
 	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
 	sqlMock.ExpectCommit()
 
 	mockArticleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(article, nil)
 	mockFavoriteRepo.On("IsFavorited", mock.Anything, userID, articleID).Return(false, nil)
 	mockFavoriteRepo.On("AddFavorite", mock.Anything, userID, articleID).Return(nil)
-	mockArticleRepo.On("UpdateArticle", mock.Anything, mock.MatchedBy(func(a *models.Article) bool {
-		return a.ID == articleID && a.FavoritesCount == 1
-	})).Return(nil)
+	mockFavoriteRepo.On("AdjustFavoritesCount", mock.Anything, articleID, 1).Return(nil)
 
 	updatedArticle := &models.Article{
 		ID:             articleID,
@@ -54,11 +54,8 @@ func TestFavoriteService_FavoriteArticle_Success_New(t *testing.T) {
 		Author: &models.User{
 			Username: "author1",
 		},
-		Favorites: []*models.Favorite{
-			{UserID: userID, ArticleID: articleID},
-		},
 	}
-	mockFavoriteRepo.On("GetArticleWithFavorites", mock.Anything, articleID).Return(updatedArticle, nil)
+	mockFavoriteRepo.On("GetArticle", mock.Anything, articleID).Return(updatedArticle, nil)
 
 	resp, err := favoriteService.FavoriteArticle(ctxForTest, slug, userID)
 
@@ -79,6 +76,7 @@ func TestFavoriteService_FavoriteArticle_Success_AlreadyFavorited(t *testing.T)
 	article := &models.Article{
 		ID:             articleID,
 		Slug:           slug,
+		Status:         models.ArticleStatusPublished,
 		FavoritesCount: 1,
 	}
 
@@ -95,11 +93,8 @@ func TestFavoriteService_FavoriteArticle_Success_AlreadyFavorited(t *testing.T)
 		Author: &models.User{
 			Username: "author1",
 		},
-		Favorites: []*models.Favorite{
-			{UserID: userID, ArticleID: articleID},
-		},
 	}
-	mockFavoriteRepo.On("GetArticleWithFavorites", mock.Anything, articleID).Return(updatedArticle, nil)
+	mockFavoriteRepo.On("GetArticle", mock.Anything, articleID).Return(updatedArticle, nil)
 
 	resp, err := favoriteService.FavoriteArticle(ctxForTest, slug, userID)
 
@@ -108,6 +103,50 @@ func TestFavoriteService_FavoriteArticle_Success_AlreadyFavorited(t *testing.T)
 	assert.True(t, resp.Article.Favorited)
 	mockArticleRepo.AssertExpectations(t)
 	mockFavoriteRepo.AssertExpectations(t)
+	mockFavoriteRepo.AssertNotCalled(t, "AdjustFavoritesCount", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFavoriteService_FavoriteArticle_ConcurrentlyFavorited(t *testing.T) {
+	ctxForTest, favoriteService, mockFavoriteRepo, mockArticleRepo, sqlMock := setupFavoriteServiceTest(t)
+	slug := "test-article"
+	userID := int64(1)
+	articleID := int64(10)
+
+	article := &models.Article{
+		ID:             articleID,
+		Slug:           slug,
+		Status:         models.ArticleStatusPublished,
+		FavoritesCount: 0,
+	}
+
+	// Another request adds the favorite between the check and the insert
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec("ROLLBACK TO SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectCommit()
+
+	mockArticleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(article, nil)
+	mockFavoriteRepo.On("IsFavorited", mock.Anything, userID, articleID).Return(false, nil)
+	mockFavoriteRepo.On("AddFavorite", mock.Anything, userID, articleID).Return(gorm.ErrDuplicatedKey)
+
+	updatedArticle := &models.Article{
+		ID:             articleID,
+		Slug:           slug,
+		FavoritesCount: 1,
+		Author: &models.User{
+			Username: "author1",
+		},
+	}
+	mockFavoriteRepo.On("GetArticle", mock.Anything, articleID).Return(updatedArticle, nil)
+
+	resp, err := favoriteService.FavoriteArticle(ctxForTest, slug, userID)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	assert.True(t, resp.Article.Favorited)
+	assert.Equal(t, 1, resp.Article.FavoritesCount)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	mockFavoriteRepo.AssertNotCalled(t, "AdjustFavoritesCount", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestFavoriteService_FavoriteArticle_NotFound(t *testing.T) {
@@ -119,6 +158,7 @@ func TestFavoriteService_FavoriteArticle_NotFound(t *testing.T) {
 	sqlMock.ExpectRollback()
 
 	mockArticleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(nil, gorm.ErrRecordNotFound)
+	mockArticleRepo.On("FindCurrentSlug", mock.Anything, slug).Return("", gorm.ErrRecordNotFound)
 
 	resp, err := favoriteService.FavoriteArticle(ctxForTest, slug, userID)
 
@@ -137,6 +177,7 @@ func TestFavoriteService_UnfavoriteArticle_Success(t *testing.T) {
 	article := &models.Article{
 		ID:             articleID,
 		Slug:           slug,
+		Status:         models.ArticleStatusPublished,
 		FavoritesCount: 1,
 	}
 
@@ -144,11 +185,8 @@ func TestFavoriteService_UnfavoriteArticle_Success(t *testing.T) {
 	sqlMock.ExpectCommit()
 
 	mockArticleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(article, nil)
-	mockFavoriteRepo.On("IsFavorited", mock.Anything, userID, articleID).Return(true, nil)
-	mockFavoriteRepo.On("RemoveFavorite", mock.Anything, userID, articleID).Return(nil)
-	mockArticleRepo.On("UpdateArticle", mock.Anything, mock.MatchedBy(func(a *models.Article) bool {
-		return a.ID == articleID && a.FavoritesCount == 0
-	})).Return(nil)
+	mockFavoriteRepo.On("RemoveFavorite", mock.Anything, userID, articleID).Return(true, nil)
+	mockFavoriteRepo.On("AdjustFavoritesCount", mock.Anything, articleID, -1).Return(nil)
 
 	updatedArticle := &models.Article{
 		ID:             articleID,
@@ -157,9 +195,8 @@ func TestFavoriteService_UnfavoriteArticle_Success(t *testing.T) {
 		Author: &models.User{
 			Username: "author1",
 		},
-		Favorites: []*models.Favorite{},
 	}
-	mockFavoriteRepo.On("GetArticleWithFavorites", mock.Anything, articleID).Return(updatedArticle, nil)
+	mockFavoriteRepo.On("GetArticle", mock.Anything, articleID).Return(updatedArticle, nil)
 
 	resp, err := favoriteService.UnfavoriteArticle(ctxForTest, slug, userID)
 
@@ -180,6 +217,7 @@ func TestFavoriteService_UnfavoriteArticle_NotFound(t *testing.T) {
 	sqlMock.ExpectRollback()
 
 	mockArticleRepo.On("FindArticleBySlug", mock.Anything, slug).Return(nil, gorm.ErrRecordNotFound)
+	mockArticleRepo.On("FindCurrentSlug", mock.Anything, slug).Return("", gorm.ErrRecordNotFound)
 
 	resp, err := favoriteService.UnfavoriteArticle(ctxForTest, slug, userID)
 
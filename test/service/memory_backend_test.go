@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"go-gin-realworld-api/internal/dtos"
+	"go-gin-realworld-api/internal/repository/memory"
+	"go-gin-realworld-api/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestMemoryBackend_ArticleLifecycle(t *testing.T) {
+	ctx := context.Background()
+	db := CreateMemoryDB(t)
+	store := memory.StoreOf(db)
+	userRepo := memory.NewMemoryUserRepository(store)
+	profileRepo := memory.NewMemoryProfileRepository(store)
+	followRepo := memory.NewMemoryFollowRepository(store)
+	articleRepo := memory.NewMemoryArticleRepository(store)
+	favoriteRepo := memory.NewMemoryFavoriteRepository(store)
+	timelineRepo := memory.NewMemoryTimelineRepository(store)
+
+	userService := services.NewUserService(db, userRepo, profileRepo, followRepo)
+	profileService := services.NewProfileService(db, userRepo, profileRepo, followRepo, timelineRepo)
+	articleService := services.NewArticleService(db, db, articleRepo, favoriteRepo, followRepo, timelineRepo, nil)
+	favoriteService := services.NewFavoriteService(db, favoriteRepo, articleRepo)
+
+	author, err := userService.RegisterUser(ctx, "author", "author@example.com", "password")
+	assert.NoError(t, err)
+	reader, err := userService.RegisterUser(ctx, "reader", "reader@example.com", "password")
+	assert.NoError(t, err)
+
+	req := &dtos.CreateArticleRequest{}
+	req.Article.Title = "Hello World"
+	req.Article.Description = "Description"
+	req.Article.Body = "Body"
+	req.Article.TagList = []string{"go"}
+	created, err := articleService.CreateArticle(ctx, req, author.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello-world", created.Article.Slug)
+	assert.Equal(t, []string{"go"}, created.Article.TagList)
+
+	_, err = profileService.FollowUser(ctx, reader.ID, "author")
+	assert.NoError(t, err)
+
+	feed, err := articleService.GetFeedArticles(ctx, reader.ID, &dtos.FeedArticlesQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *feed.ArticlesCount)
+
+	favorited, err := favoriteService.FavoriteArticle(ctx, "hello-world", reader.ID)
+	assert.NoError(t, err)
+	assert.True(t, favorited.Article.Favorited)
+	assert.Equal(t, 1, favorited.Article.FavoritesCount)
+
+	list, err := articleService.ListArticles(ctx, &dtos.ListArticlesQuery{Tags: []string{"go"}}, &reader.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *list.ArticlesCount)
+	assert.True(t, list.Articles[0].Favorited)
+
+	assert.NoError(t, articleService.DeleteArticle(ctx, "hello-world", author.ID))
+	_, err = articleService.GetArticleBySlug(ctx, "hello-world", nil)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestMemoryBackend_ReservedSlugs(t *testing.T) {
+	ctx := context.Background()
+	db := CreateMemoryDB(t)
+	store := memory.StoreOf(db)
+	followRepo := memory.NewMemoryFollowRepository(store)
+	userService := services.NewUserService(db, memory.NewMemoryUserRepository(store), memory.NewMemoryProfileRepository(store), followRepo)
+	articleService := services.NewArticleService(db, db, memory.NewMemoryArticleRepository(store), memory.NewMemoryFavoriteRepository(store), followRepo, memory.NewMemoryTimelineRepository(store), nil)
+
+	author, err := userService.RegisterUser(ctx, "author", "author@example.com", "password")
+	assert.NoError(t, err)
+
+	// /api/articles/trending is a route of its own, so the article gets another slug
+	req := &dtos.CreateArticleRequest{}
+	req.Article.Title = "Trending"
+	req.Article.Description = "Description"
+	req.Article.Body = "Body"
+	created, err := articleService.CreateArticle(ctx, req, author.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "trending-2", created.Article.Slug)
+
+	// Renaming an article to a reserved title does not take the route either
+	update := &dtos.UpdateArticleRequest{}
+	update.Article.Title = "Feed"
+	updated, err := articleService.UpdateArticle(ctx, "trending-2", update, author.ID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "feed-2", updated.Article.Slug)
+}
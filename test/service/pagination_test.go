@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go-gin-realworld-api/internal/dtos"
+	appErrors "go-gin-realworld-api/internal/errors"
+	"go-gin-realworld-api/internal/repository/memory"
+	"go-gin-realworld-api/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func articleSlugs(resp *dtos.ArticlesListResponse) []string {
+	slugs := make([]string, 0, len(resp.Articles))
+	for _, article := range resp.Articles {
+		slugs = append(slugs, article.Slug)
+	}
+	return slugs
+}
+
+func TestArticleService_ListArticles_CursorPagination(t *testing.T) {
+	ctx := context.Background()
+	db := CreateMemoryDB(t)
+	store := memory.StoreOf(db)
+	userService := services.NewUserService(db, memory.NewMemoryUserRepository(store), memory.NewMemoryProfileRepository(store), memory.NewMemoryFollowRepository(store))
+	articleService := services.NewArticleService(db, db, memory.NewMemoryArticleRepository(store), memory.NewMemoryFavoriteRepository(store), memory.NewMemoryFollowRepository(store), memory.NewMemoryTimelineRepository(store), nil)
+
+	author, err := userService.RegisterUser(ctx, "author", "author@example.com", "password")
+	require.NoError(t, err)
+	for i := 1; i <= 5; i++ {
+		req := &dtos.CreateArticleRequest{}
+		req.Article.Title = fmt.Sprintf("Article %d", i)
+		req.Article.Description = "Description"
+		req.Article.Body = "Body"
+		_, err := articleService.CreateArticle(ctx, req, author.ID)
+		require.NoError(t, err)
+	}
+
+	first, err := articleService.ListArticles(ctx, &dtos.ListArticlesQuery{Limit: 2}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"article-5", "article-4"}, articleSlugs(first))
+	assert.Equal(t, 5, *first.ArticlesCount)
+	assert.NotEmpty(t, first.NextCursor)
+	assert.Empty(t, first.PrevCursor)
+
+	second, err := articleService.ListArticles(ctx, &dtos.ListArticlesQuery{Limit: 2, Cursor: first.NextCursor}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"article-3", "article-2"}, articleSlugs(second))
+	assert.NotEmpty(t, second.NextCursor)
+	assert.NotEmpty(t, second.PrevCursor)
+
+	last, err := articleService.ListArticles(ctx, &dtos.ListArticlesQuery{Limit: 2, Cursor: second.NextCursor, SkipCount: true}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"article-1"}, articleSlugs(last))
+	assert.Nil(t, last.ArticlesCount)
+	assert.Empty(t, last.NextCursor)
+
+	// Paging back returns the previous page, which has no newer page before it
+	back, err := articleService.ListArticles(ctx, &dtos.ListArticlesQuery{Limit: 2, Cursor: second.PrevCursor}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"article-5", "article-4"}, articleSlugs(back))
+	assert.Equal(t, first.NextCursor, back.NextCursor)
+	assert.Empty(t, back.PrevCursor)
+
+	// Offset mode still works and links to the cursor pages around it
+	offset, err := articleService.ListArticles(ctx, &dtos.ListArticlesQuery{Limit: 2, Offset: 2}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, articleSlugs(second), articleSlugs(offset))
+	assert.Equal(t, second.NextCursor, offset.NextCursor)
+	assert.Equal(t, second.PrevCursor, offset.PrevCursor)
+
+	_, err = articleService.ListArticles(ctx, &dtos.ListArticlesQuery{Cursor: "not-a-cursor"}, nil)
+	assert.ErrorIs(t, err, appErrors.ErrInvalidCursor)
+}
@@ -15,19 +15,20 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-func setupProfileServiceTest(t *testing.T) (context.Context, *services.ProfileService, *mocks.MockUserRepository, *mocks.MockProfileRepository, *mocks.MockFollowRepository, sqlmock.Sqlmock) {
+func setupProfileServiceTest(t *testing.T) (context.Context, *services.ProfileService, *mocks.MockUserRepository, *mocks.MockProfileRepository, *mocks.MockFollowRepository, *mocks.MockTimelineRepository, sqlmock.Sqlmock) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockProfileRepo := new(mocks.MockProfileRepository)
 	mockFollowRepo := new(mocks.MockFollowRepository)
+	mockTimelineRepo := new(mocks.MockTimelineRepository)
 	gormDB, sqlMock := CreateMockDB(t)
-	profileService := services.NewProfileService(gormDB, mockUserRepo, mockProfileRepo, mockFollowRepo)
+	profileService := services.NewProfileService(gormDB, mockUserRepo, mockProfileRepo, mockFollowRepo, mockTimelineRepo)
 	ctxForTest := context.Background()
 
-	return ctxForTest, profileService, mockUserRepo, mockProfileRepo, mockFollowRepo, sqlMock
+	return ctxForTest, profileService, mockUserRepo, mockProfileRepo, mockFollowRepo, mockTimelineRepo, sqlMock
 }
 
 func TestProfileService_GetProfileByUsername_Success(t *testing.T) {
-	ctxForTest, profileService, mockUserRepo, _, mockFollowRepo, _ := setupProfileServiceTest(t)
+	ctxForTest, profileService, mockUserRepo, _, mockFollowRepo, _, _ := setupProfileServiceTest(t)
 	username := "testuser"
 	currentUserID := int64(1)
 	targetUserID := int64(2)
@@ -57,7 +58,7 @@ func TestProfileService_GetProfileByUsername_Success(t *testing.T) {
 }
 
 func TestProfileService_GetProfileByUsername_NotFound(t *testing.T) {
-	ctxForTest, profileService, mockUserRepo, _, _, _ := setupProfileServiceTest(t)
+	ctxForTest, profileService, mockUserRepo, _, _, _, _ := setupProfileServiceTest(t)
 	username := "nonexistent"
 	expectedError := errors.New("user not found")
 
@@ -72,7 +73,7 @@ func TestProfileService_GetProfileByUsername_NotFound(t *testing.T) {
 }
 
 func TestProfileService_FollowUser_Success(t *testing.T) {
-	ctxForTest, profileService, mockUserRepo, _, mockFollowRepo, sqlMock := setupProfileServiceTest(t)
+	ctxForTest, profileService, mockUserRepo, _, mockFollowRepo, mockTimelineRepo, sqlMock := setupProfileServiceTest(t)
 	followerID := int64(1)
 	followeeUsername := "followee"
 	followeeID := int64(2)
@@ -92,6 +93,7 @@ func TestProfileService_FollowUser_Success(t *testing.T) {
 	mockFollowRepo.On("CreateFollow", mock.Anything, mock.MatchedBy(func(f *models.Follow) bool {
 		return f.FollowerID == followerID && f.FolloweeID == followeeID
 	})).Return(nil).Once()
+	mockTimelineRepo.On("BackfillTimeline", mock.Anything, followerID, followeeID).Return(nil).Once()
 
 	// GetProfileByUsername calls (after transaction)
 	mockUserRepo.On("FindUserByUsername", mock.Anything, followeeUsername, []bool{true}).Return(followee, nil).Once()
@@ -104,10 +106,11 @@ func TestProfileService_FollowUser_Success(t *testing.T) {
 	assert.True(t, resp.Profile.Following)
 	mockUserRepo.AssertExpectations(t)
 	mockFollowRepo.AssertExpectations(t)
+	mockTimelineRepo.AssertExpectations(t)
 }
 
 func TestProfileService_UnfollowUser_Success(t *testing.T) {
-	ctxForTest, profileService, mockUserRepo, _, mockFollowRepo, sqlMock := setupProfileServiceTest(t)
+	ctxForTest, profileService, mockUserRepo, _, mockFollowRepo, mockTimelineRepo, sqlMock := setupProfileServiceTest(t)
 	followerID := int64(1)
 	followeeUsername := "followee"
 	followeeID := int64(2)
@@ -124,6 +127,7 @@ func TestProfileService_UnfollowUser_Success(t *testing.T) {
 	// Transaction calls
 	mockUserRepo.On("FindUserByUsername", mock.Anything, followeeUsername, []bool(nil)).Return(followee, nil)
 	mockFollowRepo.On("DeleteFollow", mock.Anything, followerID, followeeID).Return(nil)
+	mockTimelineRepo.On("PruneTimeline", mock.Anything, followerID, followeeID).Return(nil)
 
 	// GetProfileByUsername calls (after transaction)
 	mockUserRepo.On("FindUserByUsername", mock.Anything, followeeUsername, []bool{true}).Return(followee, nil)
@@ -136,4 +140,5 @@ func TestProfileService_UnfollowUser_Success(t *testing.T) {
 	assert.False(t, resp.Profile.Following)
 	mockUserRepo.AssertExpectations(t)
 	mockFollowRepo.AssertExpectations(t)
+	mockTimelineRepo.AssertExpectations(t)
 }
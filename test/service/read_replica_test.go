@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"go-gin-realworld-api/internal/dtos"
+	"go-gin-realworld-api/internal/models"
+	"go-gin-realworld-api/internal/repository"
+	"go-gin-realworld-api/internal/services"
+	"go-gin-realworld-api/test/mocks"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// onDB matches a *gorm.DB derived (via WithContext or Transaction) from the given handle
+func onDB(db *gorm.DB) interface{} {
+	return mock.MatchedBy(func(arg *gorm.DB) bool {
+		return arg.Dialector == db.Dialector
+	})
+}
+
+func TestReadReplica_ArticleReadsUseReplica(t *testing.T) {
+	primary, _ := CreateMockDB(t)
+	replica, _ := CreateMockDB(t)
+	mockArticleRepo := new(mocks.MockArticleRepository)
+	mockFavoriteRepo := new(mocks.MockFavoriteRepository)
+	mockTimelineRepo := new(mocks.MockTimelineRepository)
+	articleService := services.NewArticleService(primary, replica, mockArticleRepo, mockFavoriteRepo, new(mocks.MockFollowRepository), mockTimelineRepo, nil)
+	ctx := context.Background()
+	userID := int64(1)
+	article := &models.Article{ID: 1, Slug: "test-article", Status: models.ArticleStatusPublished, Author: &models.User{Username: "author1"}}
+	entry := &models.TimelineEntry{UserID: userID, ArticleID: 1}
+
+	mockArticleRepo.On("ListArticles", onDB(replica), repository.ArticleFilter{}, &userID, repository.ArticlePage{Limit: 21}).Return([]*models.Article{article}, int64(1), nil)
+	mockTimelineRepo.On("ListTimeline", onDB(replica), userID, repository.FeedFilter{}, repository.ArticlePage{Limit: 21}).Return([]*models.TimelineEntry{entry}, int64(1), nil)
+	mockTimelineRepo.On("ListFanOutOnRead", onDB(replica), userID, repository.FeedFilter{}, repository.ArticlePage{Limit: 21}).Return([]*models.TimelineEntry{}, int64(0), nil)
+	mockArticleRepo.On("FindArticlesByIDs", onDB(replica), []int64{1}).Return([]*models.Article{article}, nil)
+	mockArticleRepo.On("FindArticleBySlug", onDB(replica), "test-article").Return(article, nil)
+	mockFavoriteRepo.On("FavoritedArticleIDs", onDB(replica), userID, []int64{1}).Return([]int64{}, nil)
+
+	_, err := articleService.ListArticles(ctx, &dtos.ListArticlesQuery{}, &userID)
+	assert.NoError(t, err)
+	_, err = articleService.GetFeedArticles(ctx, userID, &dtos.FeedArticlesQuery{})
+	assert.NoError(t, err)
+	_, err = articleService.GetArticleBySlug(ctx, "test-article", &userID)
+	assert.NoError(t, err)
+
+	mockArticleRepo.AssertExpectations(t)
+	mockFavoriteRepo.AssertExpectations(t)
+	mockTimelineRepo.AssertExpectations(t)
+}
+
+func TestReadReplica_CreateArticleRefetchUsesPrimary(t *testing.T) {
+	primary, sqlMock := CreateMockDB(t)
+	replica, _ := CreateMockDB(t)
+	mockArticleRepo := new(mocks.MockArticleRepository)
+	mockFavoriteRepo := new(mocks.MockFavoriteRepository)
+	mockFollowRepo := new(mocks.MockFollowRepository)
+	mockTimelineRepo := new(mocks.MockTimelineRepository)
+	articleService := services.NewArticleService(primary, replica, mockArticleRepo, mockFavoriteRepo, mockFollowRepo, mockTimelineRepo, nil)
+	authorID := int64(1)
+
+	req := &dtos.CreateArticleRequest{}
+	req.Article.Title = "New Article"
+	req.Article.Description = "Description"
+	req.Article.Body = "Body"
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectCommit()
+
+	mockFollowRepo.On("CountFollowers", onDB(primary), authorID).Return(int64(0), nil)
+	mockArticleRepo.On("CreateArticle", onDB(primary), mock.Anything).Return(nil)
+	mockTimelineRepo.On("FanOutArticle", onDB(primary), mock.Anything).Return(nil)
+	mockArticleRepo.On("FindArticleBySlug", onDB(primary), "new-article").Return(&models.Article{
+		ID:     1,
+		Slug:   "new-article",
+		Author: &models.User{Username: "author1"},
+	}, nil)
+
+	resp, err := articleService.CreateArticle(context.Background(), req, authorID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new-article", resp.Article.Slug)
+	mockArticleRepo.AssertExpectations(t)
+}
+
+func TestReadReplica_TagsAndCommentsUseReplica(t *testing.T) {
+	primary, _ := CreateMockDB(t)
+	replica, _ := CreateMockDB(t)
+	mockTagRepo := new(mocks.MockTagRepository)
+	mockCommentRepo := new(mocks.MockCommentRepository)
+	mockArticleRepo := new(mocks.MockArticleRepository)
+	tagService := services.NewTagService(primary, replica, mockTagRepo)
+	commentService := services.NewCommentService(primary, replica, mockCommentRepo, mockArticleRepo)
+	ctx := context.Background()
+
+	mockTagRepo.On("GetAllTags", onDB(replica)).Return([]string{"go"}, nil)
+	mockArticleRepo.On("FindArticleBySlug", onDB(replica), "test-article").Return(&models.Article{ID: 1, Status: models.ArticleStatusPublished}, nil)
+	mockCommentRepo.On("GetCommentsByArticleID", onDB(replica), int64(1)).Return([]*models.Comment{}, nil)
+
+	_, err := tagService.GetAllTags(ctx)
+	assert.NoError(t, err)
+	_, err = commentService.GetCommentsByArticleSlug(ctx, "test-article", nil)
+	assert.NoError(t, err)
+
+	mockTagRepo.AssertExpectations(t)
+	mockArticleRepo.AssertExpectations(t)
+	mockCommentRepo.AssertExpectations(t)
+}
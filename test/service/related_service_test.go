@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"go-gin-realworld-api/internal/dtos"
+	appErrors "go-gin-realworld-api/internal/errors"
+	"go-gin-realworld-api/internal/repository/memory"
+	"go-gin-realworld-api/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelatedService_RelatedArticles(t *testing.T) {
+	ctx := context.Background()
+	db := CreateMemoryDB(t)
+	store := memory.StoreOf(db)
+	articleRepo := memory.NewMemoryArticleRepository(store)
+	favoriteRepo := memory.NewMemoryFavoriteRepository(store)
+	userService := services.NewUserService(db, memory.NewMemoryUserRepository(store), memory.NewMemoryProfileRepository(store), memory.NewMemoryFollowRepository(store))
+	articleService := services.NewArticleService(db, db, articleRepo, favoriteRepo, memory.NewMemoryFollowRepository(store), memory.NewMemoryTimelineRepository(store), nil)
+	favoriteService := services.NewFavoriteService(db, favoriteRepo, articleRepo)
+	relatedService := services.NewRelatedService(db, memory.NewMemoryRelatedRepository(store), articleRepo, articleService)
+
+	author, err := userService.RegisterUser(ctx, "author", "author@example.com", "password")
+	require.NoError(t, err)
+	reader, err := userService.RegisterUser(ctx, "reader", "reader@example.com", "password")
+	require.NoError(t, err)
+	for _, article := range []struct {
+		title    string
+		authorID int64
+		tags     []string
+	}{
+		{"Source", author.ID, []string{"go", "testing"}},
+		{"Both Tags", author.ID, []string{"go", "testing"}},
+		{"One Tag", reader.ID, []string{"go"}},
+		{"Favorited", author.ID, []string{"rust"}},
+		{"Unrelated", author.ID, []string{"css"}},
+	} {
+		req := &dtos.CreateArticleRequest{}
+		req.Article.Title = article.title
+		req.Article.Description = "Description"
+		req.Article.Body = "Body"
+		req.Article.TagList = article.tags
+		_, err := articleService.CreateArticle(ctx, req, article.authorID)
+		require.NoError(t, err)
+	}
+	_, err = favoriteService.FavoriteArticle(ctx, "source", reader.ID)
+	require.NoError(t, err)
+	_, err = favoriteService.FavoriteArticle(ctx, "favorited", reader.ID)
+	require.NoError(t, err)
+
+	// Two shared tags (2 x 2) beat one co-favorite (1) and one shared tag (2)
+	related, err := relatedService.RelatedArticles(ctx, "source", &dtos.RelatedArticlesQuery{Limit: 5}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"both-tags", "one-tag", "favorited"}, articleSlugs(related))
+	assert.Nil(t, related.ArticlesCount)
+
+	// The viewer's own articles are left out
+	related, err = relatedService.RelatedArticles(ctx, "source", &dtos.RelatedArticlesQuery{Limit: 5}, &reader.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"both-tags", "favorited"}, articleSlugs(related))
+	assert.True(t, related.Articles[1].Favorited)
+
+	related, err = relatedService.RelatedArticles(ctx, "source", &dtos.RelatedArticlesQuery{Limit: 1}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"both-tags"}, articleSlugs(related))
+
+	// With tags ignored only co-favorites count
+	relatedService.SetWeights(0, 1)
+	related, err = relatedService.RelatedArticles(ctx, "source", &dtos.RelatedArticlesQuery{Limit: 5}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"favorited"}, articleSlugs(related))
+
+	_, err = relatedService.RelatedArticles(ctx, "missing", &dtos.RelatedArticlesQuery{Limit: 5}, nil)
+	assert.ErrorIs(t, err, appErrors.ErrNotFound)
+}
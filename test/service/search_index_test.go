@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"go-gin-realworld-api/internal/dtos"
+	"go-gin-realworld-api/internal/repository/memory"
+	"go-gin-realworld-api/internal/search"
+	"go-gin-realworld-api/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArticleService_SearchIndex(t *testing.T) {
+	ctx := context.Background()
+	db := CreateMemoryDB(t)
+	store := memory.StoreOf(db)
+	userService := services.NewUserService(db, memory.NewMemoryUserRepository(store), memory.NewMemoryProfileRepository(store), memory.NewMemoryFollowRepository(store))
+	articleRepo := memory.NewMemoryArticleRepository(store)
+	index := search.NewInvertedIndex()
+	articleService := services.NewArticleService(db, db, articleRepo, memory.NewMemoryFavoriteRepository(store), memory.NewMemoryFollowRepository(store), memory.NewMemoryTimelineRepository(store), index)
+
+	author, err := userService.RegisterUser(ctx, "author", "author@example.com", "password")
+	require.NoError(t, err)
+
+	create := func(title, body string, tags ...string) string {
+		req := &dtos.CreateArticleRequest{}
+		req.Article.Title = title
+		req.Article.Description = "Description"
+		req.Article.Body = body
+		req.Article.TagList = tags
+		created, err := articleService.CreateArticle(ctx, req, author.ID)
+		require.NoError(t, err)
+		return created.Article.Slug
+	}
+	searchSlugs := func(q string) []string {
+		resp, err := articleService.SearchArticles(ctx, &dtos.SearchArticlesQuery{Q: q}, nil)
+		require.NoError(t, err)
+		slugs := make([]string, 0, len(resp.Articles))
+		for _, article := range resp.Articles {
+			slugs = append(slugs, article.Slug)
+		}
+		return slugs
+	}
+
+	// Creating indexes the article, including its author and tags
+	pooling := create("Connection pooling", "Pools keep connections open", "db")
+	notes := create("Weekly notes", "Thoughts on connecting services")
+	assert.Equal(t, []string{pooling, notes}, searchSlugs("connected"))
+
+	resp, err := articleService.SearchArticles(ctx, &dtos.SearchArticlesQuery{Q: "connected", Tag: "db", Author: "author"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.ArticlesCount)
+	assert.Equal(t, "<em>Connection</em> pooling", resp.Articles[0].Highlight.Title)
+
+	// Updating re-indexes it
+	update := &dtos.UpdateArticleRequest{}
+	update.Article.Body = "Nothing to see"
+	_, err = articleService.UpdateArticle(ctx, notes, update, author.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{pooling}, searchSlugs("connected"))
+
+	// Deleting removes it
+	require.NoError(t, articleService.DeleteArticle(ctx, pooling, author.ID))
+	assert.Empty(t, searchSlugs("connected"))
+
+	// A rebuild restores an index that fell behind
+	require.NoError(t, index.Reset(nil))
+	assert.Empty(t, searchSlugs("nothing"))
+	indexed, err := articleService.RebuildSearchIndex(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, indexed)
+	assert.Equal(t, []string{notes}, searchSlugs("nothing"))
+}
+
+func TestArticleService_SearchIndexFollowsTagsAndUsernames(t *testing.T) {
+	ctx := context.Background()
+	db := CreateMemoryDB(t)
+	store := memory.StoreOf(db)
+	userService := services.NewUserService(db, memory.NewMemoryUserRepository(store), memory.NewMemoryProfileRepository(store), memory.NewMemoryFollowRepository(store))
+	index := search.NewInvertedIndex()
+	articleService := services.NewArticleService(db, db, memory.NewMemoryArticleRepository(store), memory.NewMemoryFavoriteRepository(store), memory.NewMemoryFollowRepository(store), memory.NewMemoryTimelineRepository(store), index)
+	tagService := services.NewTagService(db, db, memory.NewMemoryTagRepository(store))
+	userService.SetArticleService(articleService)
+	tagService.SetArticleService(articleService)
+
+	author, err := userService.RegisterUser(ctx, "author", "author@example.com", "password")
+	require.NoError(t, err)
+
+	req := &dtos.CreateArticleRequest{}
+	req.Article.Title = "Connection pooling"
+	req.Article.Description = "Description"
+	req.Article.Body = "Pools keep connections open"
+	req.Article.TagList = []string{"db", "sql"}
+	_, err = articleService.CreateArticle(ctx, req, author.ID)
+	require.NoError(t, err)
+
+	searchCount := func(query dtos.SearchArticlesQuery) int {
+		query.Q = "pooling"
+		resp, err := articleService.SearchArticles(ctx, &query, nil)
+		require.NoError(t, err)
+		return resp.ArticlesCount
+	}
+
+	// A renamed tag is searched by its new name
+	require.NoError(t, tagService.RenameTag(ctx, "db", "database"))
+	assert.Equal(t, 0, searchCount(dtos.SearchArticlesQuery{Tag: "db"}))
+	assert.Equal(t, 1, searchCount(dtos.SearchArticlesQuery{Tag: "database"}))
+
+	// A merged tag is searched by the tag it was merged into
+	_, err = tagService.MergeTags(ctx, "sql", "database")
+	require.NoError(t, err)
+	assert.Equal(t, 0, searchCount(dtos.SearchArticlesQuery{Tag: "sql"}))
+	assert.Equal(t, 1, searchCount(dtos.SearchArticlesQuery{Tag: "database"}))
+
+	// An author is searched by their new username
+	patch := &dtos.UpdateUserRequest{}
+	patch.User.Username = "writer"
+	_, err = userService.UpdateUser(ctx, author.ID, patch)
+	require.NoError(t, err)
+	assert.Equal(t, 0, searchCount(dtos.SearchArticlesQuery{Author: "author"}))
+	assert.Equal(t, 1, searchCount(dtos.SearchArticlesQuery{Author: "writer"}))
+}
@@ -3,8 +3,12 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
+	appErrors "go-gin-realworld-api/internal/errors"
+	"go-gin-realworld-api/internal/repository"
 	"go-gin-realworld-api/internal/services"
 	"go-gin-realworld-api/test/mocks"
 
@@ -16,7 +20,7 @@ import (
 func setupTagServiceTest(t *testing.T) (context.Context, *services.TagService, *mocks.MockTagRepository, sqlmock.Sqlmock) {
 	mockTagRepo := new(mocks.MockTagRepository)
 	gormDB, sqlMock := CreateMockDB(t)
-	tagService := services.NewTagService(gormDB, mockTagRepo)
+	tagService := services.NewTagService(gormDB, gormDB, mockTagRepo)
 	ctxForTest := context.Background()
 
 	return ctxForTest, tagService, mockTagRepo, sqlMock
@@ -48,3 +52,57 @@ func TestTagService_GetAllTags_Error(t *testing.T) {
 	assert.Equal(t, expectedError, err)
 	mockTagRepo.AssertExpectations(t)
 }
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := services.NormalizeTags([]string{"Go", "go", "go ", "  Web\tDev ", ""})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go", "web dev"}, tags)
+
+	tooMany := make([]string, services.MaxTagsPerArticle+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("tag%d", i)
+	}
+	_, err = services.NormalizeTags(tooMany)
+	assert.ErrorIs(t, err, appErrors.ErrTooManyTags)
+	_, err = services.NormalizeTags([]string{strings.Repeat("x", services.MaxTagLength+1)})
+	assert.ErrorIs(t, err, appErrors.ErrTagTooLong)
+}
+
+func TestTagService_RenameAndMergeTags_NormalizeNames(t *testing.T) {
+	ctxForTest, tagService, mockTagRepo, _ := setupTagServiceTest(t)
+
+	mockTagRepo.On("RenameTag", mock.Anything, "go", "golang").Return(nil)
+	mockTagRepo.On("MergeTags", mock.Anything, "web dev", "web").Return(int64(2), nil)
+
+	assert.NoError(t, tagService.RenameTag(ctxForTest, " Go", "GoLang"))
+	merged, err := tagService.MergeTags(ctxForTest, "Web  Dev", "WEB")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), merged)
+	_, err = tagService.MergeTags(ctxForTest, "Go", "go ")
+	assert.ErrorIs(t, err, appErrors.ErrMergeTagIntoItself)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestTagService_NormalizeExistingTags(t *testing.T) {
+	ctxForTest, tagService, mockTagRepo, sqlMock := setupTagServiceTest(t)
+
+	sqlMock.ExpectBegin()
+	mockTagRepo.On("ListTagCounts", mock.Anything, repository.TagSortName, 0).Return([]repository.TagCount{
+		{Name: "GO"}, {Name: "Go"}, {Name: "Web  Dev"}, {Name: "go"}, {Name: "rust"},
+	}, nil)
+	mockTagRepo.On("MergeTags", mock.Anything, "GO", "go").Return(int64(1), nil)
+	mockTagRepo.On("MergeTags", mock.Anything, "Go", "go").Return(int64(3), nil)
+	mockTagRepo.On("RenameTag", mock.Anything, "Web  Dev", "web dev").Return(nil)
+	sqlMock.ExpectCommit()
+
+	// The tag already named "go" is kept and the others merged into it
+	changed, err := tagService.NormalizeExistingTags(ctxForTest)
+	assert.NoError(t, err)
+	assert.Equal(t, []services.NormalizedTag{
+		{From: "GO", To: "go", Merged: true},
+		{From: "Go", To: "go", Merged: true},
+		{From: "Web  Dev", To: "web dev"},
+	}, changed)
+	mockTagRepo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"testing"
 
+	"go-gin-realworld-api/internal/repository/memory"
+
 	"github.com/DATA-DOG/go-sqlmock"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 // HashPassword hashes a password using SHA256 (same as in services)
@@ -33,3 +36,12 @@ func CreateMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
 
 	return gormDB, sqlMock
 }
+
+// CreateMemoryDB creates a no-op database handle for services backed by memory repositories
+func CreateMemoryDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(memory.Open(), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to open memory db: %v", err)
+	}
+	return db
+}
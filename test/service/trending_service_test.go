@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-gin-realworld-api/internal/dtos"
+	"go-gin-realworld-api/internal/repository/memory"
+	"go-gin-realworld-api/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrendingService_RefreshAndList(t *testing.T) {
+	ctx := context.Background()
+	db := CreateMemoryDB(t)
+	store := memory.StoreOf(db)
+	articleRepo := memory.NewMemoryArticleRepository(store)
+	favoriteRepo := memory.NewMemoryFavoriteRepository(store)
+	userService := services.NewUserService(db, memory.NewMemoryUserRepository(store), memory.NewMemoryProfileRepository(store), memory.NewMemoryFollowRepository(store))
+	articleService := services.NewArticleService(db, db, articleRepo, favoriteRepo, memory.NewMemoryFollowRepository(store), memory.NewMemoryTimelineRepository(store), nil)
+	favoriteService := services.NewFavoriteService(db, favoriteRepo, articleRepo)
+	commentService := services.NewCommentService(db, db, memory.NewMemoryCommentRepository(store), articleRepo)
+	trendingRepo := memory.NewMemoryTrendingRepository(store)
+	trendingService := services.NewTrendingService(db, db, trendingRepo, articleService)
+
+	author, err := userService.RegisterUser(ctx, "author", "author@example.com", "password")
+	require.NoError(t, err)
+	reader, err := userService.RegisterUser(ctx, "reader", "reader@example.com", "password")
+	require.NoError(t, err)
+	for i := 1; i <= 3; i++ {
+		req := &dtos.CreateArticleRequest{}
+		req.Article.Title = fmt.Sprintf("Article %d", i)
+		req.Article.Description = "Description"
+		req.Article.Body = "Body"
+		_, err := articleService.CreateArticle(ctx, req, author.ID)
+		require.NoError(t, err)
+	}
+
+	// Before the first refresh the newest articles are listed
+	trending, err := trendingService.TrendingArticles(ctx, &dtos.TrendingArticlesQuery{Window: "24h", Limit: 2}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"article-3", "article-2"}, articleSlugs(trending))
+	assert.Equal(t, 3, *trending.ArticlesCount)
+	assert.Empty(t, trending.NextCursor)
+
+	// A comment weighs more than a favorite made at the same time
+	_, err = favoriteService.FavoriteArticle(ctx, "article-1", reader.ID)
+	require.NoError(t, err)
+	comment := &dtos.CreateCommentRequest{}
+	comment.Comment.Body = "Nice"
+	_, err = commentService.CreateComment(ctx, comment, "article-2", reader.ID)
+	require.NoError(t, err)
+	require.NoError(t, trendingService.RefreshTrending(ctx))
+
+	for _, window := range []string{"24h", "7d"} {
+		trending, err = trendingService.TrendingArticles(ctx, &dtos.TrendingArticlesQuery{Window: window}, &reader.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"article-2", "article-1"}, articleSlugs(trending), window)
+		assert.Equal(t, 2, *trending.ArticlesCount)
+		assert.True(t, trending.Articles[1].Favorited)
+	}
+
+	// The background worker skips a refresh another server made within its interval
+	_, err = favoriteService.FavoriteArticle(ctx, "article-3", reader.ID)
+	require.NoError(t, err)
+	claimed, err := trendingRepo.ClaimTrendingRefresh(db, time.Now().Add(-time.Minute), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.True(t, claimed)
+	stopped, cancel := context.WithCancel(ctx)
+	cancel()
+	trendingService.RunTrendingRefresher(stopped, time.Hour)
+	trending, err = trendingService.TrendingArticles(ctx, &dtos.TrendingArticlesQuery{Window: "24h"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"article-2", "article-1"}, articleSlugs(trending))
+
+	trendingService.RunTrendingRefresher(stopped, time.Second)
+	trending, err = trendingService.TrendingArticles(ctx, &dtos.TrendingArticlesQuery{Window: "24h"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, *trending.ArticlesCount)
+}
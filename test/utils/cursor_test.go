@@ -0,0 +1,43 @@
+package utils
+
+import (
+	appErrors "go-gin-realworld-api/internal/errors"
+	"go-gin-realworld-api/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	createdAt := time.Date(2025, 3, 1, 12, 30, 15, 123456789, time.UTC)
+
+	for _, backward := range []bool{false, true} {
+		encoded := utils.EncodeCursor(utils.Cursor{CreatedAt: createdAt, ID: 42, Backward: backward})
+
+		decoded, err := utils.DecodeCursor(encoded)
+		assert.NoError(t, err)
+		assert.True(t, createdAt.Equal(decoded.CreatedAt))
+		assert.Equal(t, int64(42), decoded.ID)
+		assert.Equal(t, backward, decoded.Backward)
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "Not base64", cursor: "!!!"},
+		{name: "Garbage", cursor: "Z2FyYmFnZQ"},
+		{name: "Unknown direction", cursor: "eDoxOjE"},
+		{name: "Zero ID", cursor: "bjoxOjA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := utils.DecodeCursor(tt.cursor)
+			assert.ErrorIs(t, err, appErrors.ErrInvalidCursor)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"go-gin-realworld-api/internal/utils"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTerms(t *testing.T) {
+	assert.Equal(t, []string{"golang", "gin", "tips"}, utils.SearchTerms("  Golang, GIN & tips! golang "))
+	assert.Equal(t, []string{"tiếng", "việt"}, utils.SearchTerms("Tiếng Việt"))
+	assert.Empty(t, utils.SearchTerms("!@#$"))
+	assert.Len(t, utils.SearchTerms(strings.Repeat("a b c d e f g h i j k l ", 2)), 10)
+}
+
+func TestHighlight(t *testing.T) {
+	highlighted, matched := utils.Highlight("Learning Go with Gin", []string{"gin", "go"}, 0)
+	assert.True(t, matched)
+	assert.Equal(t, "Learning <em>Go</em> with <em>Gin</em>", highlighted)
+
+	// Text is escaped so the result is safe to render as HTML
+	highlighted, _ = utils.Highlight("<b>Go</b> & friends", []string{"go"}, 0)
+	assert.Equal(t, "&lt;b&gt;<em>Go</em>&lt;/b&gt; &amp; friends", highlighted)
+
+	highlighted, matched = utils.Highlight("Nothing here", []string{"go"}, 0)
+	assert.False(t, matched)
+	assert.Equal(t, "Nothing here", highlighted)
+}
+
+func TestHighlight_Excerpt(t *testing.T) {
+	text := strings.Repeat("filler words ", 30) + "the needle is here " + strings.Repeat("more text ", 30)
+
+	excerpt, matched := utils.Highlight(text, []string{"needle"}, 80)
+	assert.True(t, matched)
+	assert.Contains(t, excerpt, "<em>needle</em>")
+	assert.True(t, strings.HasPrefix(excerpt, "…"))
+	assert.True(t, strings.HasSuffix(excerpt, "…"))
+	assert.LessOrEqual(t, len(strings.Trim(excerpt, "…")), 80+len("<em></em>"))
+
+	// Without a match the excerpt comes from the start of the text
+	excerpt, matched = utils.Highlight(text, []string{"absent"}, 80)
+	assert.False(t, matched)
+	assert.True(t, strings.HasPrefix(excerpt, "filler words"))
+	assert.True(t, strings.HasSuffix(excerpt, "…"))
+}
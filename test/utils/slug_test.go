@@ -2,6 +2,7 @@ package utils
 
 import (
 	"go-gin-realworld-api/internal/utils"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -53,6 +54,26 @@ func TestGenerateSlug(t *testing.T) {
 			title:    "!@#$%^&*",
 			expected: "",
 		},
+		{
+			name:     "Vietnamese title",
+			title:    "Tiếng Việt",
+			expected: "tieng-viet",
+		},
+		{
+			name:     "Latin letters without decomposition",
+			title:    "Đà Nẵng straße Łódź",
+			expected: "da-nang-strasse-lodz",
+		},
+		{
+			name:     "Cyrillic title",
+			title:    "Привет, мир",
+			expected: "privet-mir",
+		},
+		{
+			name:     "Greek title",
+			title:    "Καλημέρα κόσμε",
+			expected: "kalimera-kosme",
+		},
 	}
 
 	for _, tt := range tests {
@@ -62,3 +83,51 @@ func TestGenerateSlug(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateSlug_UntransliteratedScripts(t *testing.T) {
+	// CJK titles fall back to a hash of the title
+	slug := utils.GenerateSlug("日本語のタイトル")
+	assert.Regexp(t, `^[0-9a-f]{8}$`, slug)
+	assert.Equal(t, slug, utils.GenerateSlug("日本語のタイトル"), "slugs must be deterministic")
+	assert.NotEqual(t, slug, utils.GenerateSlug("中文标题"))
+
+	// Transliterable words are kept, with the hash telling similar titles apart
+	mixed := utils.GenerateSlug("Go 入門")
+	assert.Regexp(t, `^go-[0-9a-f]{8}$`, mixed)
+	assert.NotEqual(t, mixed, utils.GenerateSlug("Go 上級"))
+}
+
+func TestGenerateSlug_MaxLength(t *testing.T) {
+	title := strings.Repeat("word ", 100)
+	slug := utils.GenerateSlug(title)
+	assert.LessOrEqual(t, len(slug), utils.MaxSlugLength)
+	assert.True(t, strings.HasSuffix(slug, "-word"), "cut at a word boundary: %s", slug)
+
+	// A single word longer than the limit is cut mid-word
+	assert.Equal(t, strings.Repeat("a", utils.MaxSlugLength), utils.GenerateSlug(strings.Repeat("a", 300)))
+
+	// The hash fallback survives truncation
+	long := utils.GenerateSlug(title + "日本")
+	assert.LessOrEqual(t, len(long), utils.MaxSlugLength)
+	assert.Regexp(t, `-word-[0-9a-f]{8}$`, long)
+}
+
+func TestSlugCandidate(t *testing.T) {
+	assert.Equal(t, "hello-world", utils.SlugCandidate("hello-world", 1))
+	assert.Equal(t, "hello-world-2", utils.SlugCandidate("hello-world", 2))
+	assert.Equal(t, "hello-world-5", utils.SlugCandidate("hello-world", 5))
+
+	// Later attempts use a random suffix
+	assert.Regexp(t, `^hello-world-[a-z0-9]{6}$`, utils.SlugCandidate("hello-world", 6))
+
+	// An empty base never yields an empty slug
+	assert.Regexp(t, `^[a-z0-9]{6}$`, utils.SlugCandidate("", 1))
+
+	// Slugs of static routes next to /api/articles/:slug always get a suffix
+	for _, title := range []string{"Feed", "Search!", "  trending  "} {
+		base := utils.GenerateSlug(title)
+		assert.Equal(t, base+"-2", utils.SlugCandidate(base, 1))
+		assert.Equal(t, base+"-3", utils.SlugCandidate(base, 2))
+	}
+	assert.Equal(t, "feed-back", utils.SlugCandidate("feed-back", 1))
+}